@@ -153,7 +153,7 @@ func TestActor_Basic(t *testing.T) {
 
 	// Start game
 	time.Sleep(time.Microsecond * 100)
-	err = tableEngine.StartTableGame()
+	err = tableEngine.StartTableGameIfNotStarted()
 	assert.Nil(t, err)
 
 	wg.Wait()
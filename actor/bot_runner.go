@@ -36,6 +36,8 @@ type botRunner struct {
 	lastGameStateTime              int64
 	timebank                       *timebank.TimeBank
 	tableInfo                      *pokertable.Table
+	strategy                       Strategy
+	rng                            *rand.Rand
 	onTableGameWagerActionUpdated  TableGameWagerActionUpdatedFunc
 	onTableAutoJoinActionRequested TableAutoJoinActionRequestFunc
 }
@@ -44,6 +46,8 @@ func NewBotRunner(playerID string) *botRunner {
 	return &botRunner{
 		playerID:                       playerID,
 		timebank:                       timebank.NewTimeBank(),
+		strategy:                       NewRandomStrategy(),
+		rng:                            rand.New(rand.NewSource(time.Now().UnixNano())),
 		onTableGameWagerActionUpdated:  func(string, string, int, string, string, int64) {},
 		onTableAutoJoinActionRequested: func(string, string, string) {},
 	}
@@ -58,6 +62,12 @@ func (br *botRunner) Humanized(enabled bool) {
 	br.isHumanized = enabled
 }
 
+// SetStrategy replaces the wager decisions of the bot, rng feeds the strategy (NewRandomStrategy with a time seed by default)
+func (br *botRunner) SetStrategy(s Strategy, rng *rand.Rand) {
+	br.strategy = s
+	br.rng = rng
+}
+
 func (br *botRunner) OnTableGameWagerActionUpdated(fn TableGameWagerActionUpdatedFunc) error {
 	br.onTableGameWagerActionUpdated = fn
 	return nil
@@ -120,8 +130,8 @@ func (br *botRunner) UpdateTableState(table *pokertable.Table) error {
 		br.lastGameStateTime = gs.UpdatedAt
 	}
 
-	// game move is allowed when the game is playing
-	if table.State.Status != pokertable.TableStateStatus_TableGamePlaying {
+	// game move is allowed when the game is playing & its first state is received
+	if gs == nil || table.State.Status != pokertable.TableStateStatus_TableGamePlaying {
 		return nil
 	}
 
@@ -130,7 +140,7 @@ func (br *botRunner) UpdateTableState(table *pokertable.Table) error {
 
 	// Somehow, this player is not in the game.
 	// It probably has no chips already or just sat down and have not participated in the game yet
-	if gamePlayerIdx == -1 {
+	if pokertable.IsUnset(gamePlayerIdx) {
 		// fmt.Printf("[DEBUG#botRunner#UpdateTableState] [3] No reaction required since bot (%s) gamePlayerIdx is -1 at table (%s) when table status is %s.\n", br.playerID, table.ID, pokertable.TableStateStatus_TableGamePlaying)
 		return nil
 	}
@@ -167,11 +177,11 @@ func (br *botRunner) requestMove(gs *pokerlib.GameState, playerIdx int) error {
 	*/
 
 	// Do ready() and pay() automatically
-	if gs.HasAction(playerIdx, "ready") {
+	if gs.HasAction(playerIdx, string(pokertable.Action_Ready)) {
 		return br.actions.Ready()
-	} else if gs.HasAction(playerIdx, "pass") {
+	} else if gs.HasAction(playerIdx, string(pokertable.Action_Pass)) {
 		return br.actions.Pass()
-	} else if gs.HasAction(playerIdx, "pay") {
+	} else if gs.HasAction(playerIdx, string(pokertable.Action_Pay)) {
 
 		// Pay for ante and blinds
 		switch gs.Status.CurrentEvent {
@@ -213,49 +223,6 @@ func (br *botRunner) requestMove(gs *pokerlib.GameState, playerIdx int) error {
 	})
 }
 
-func (br *botRunner) calcActionProbabilities(actions []string) map[string]float64 {
-
-	probabilities := make(map[string]float64)
-	totalWeight := 0.0
-	for _, action := range actions {
-
-		for _, p := range actionProbabilities {
-			if action == p.Action {
-				probabilities[action] = p.Weight
-				totalWeight += p.Weight
-				break
-			}
-		}
-	}
-
-	scaleRatio := 1.0 / totalWeight
-	weightLevel := 0.0
-	for action, weight := range probabilities {
-		scaledWeight := weight * scaleRatio
-		weightLevel += scaledWeight
-		probabilities[action] = weightLevel
-	}
-
-	return probabilities
-}
-
-func (br *botRunner) calcAction(actions []string) string {
-
-	// Select action randomly
-	rand.Seed(time.Now().UnixNano())
-
-	probabilities := br.calcActionProbabilities(actions)
-	randomNum := rand.Float64()
-
-	for action, probability := range probabilities {
-		if randomNum < probability {
-			return action
-		}
-	}
-
-	return actions[len(actions)-1]
-}
-
 func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 
 	player := gs.Players[playerIdx]
@@ -265,14 +232,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 		return nil
 	}
 
-	action := player.AllowedActions[0]
-
-	if len(player.AllowedActions) > 1 {
-		action = br.calcAction(player.AllowedActions)
-	}
-
-	// Calculate chips
-	chips := int64(0)
+	action, chips := br.strategy.Decide(gs, playerIdx, br.rng)
 
 	/*
 		// Debugging messages
@@ -286,15 +246,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 	*/
 
 	switch action {
-	case "bet":
-
-		minBet := gs.Status.MiniBet
-
-		if player.InitialStackSize <= minBet {
-			return br.actions.Bet(player.InitialStackSize)
-		}
-
-		chips = rand.Int63n(player.InitialStackSize-minBet) + minBet
+	case pokertable.WagerAction_Bet:
 
 		err := br.actions.Bet(chips)
 		if err != nil {
@@ -303,22 +255,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 
 		br.updateWagerAction(pokertable.WagerAction_Bet, chips)
 		return nil
-	case "raise":
-
-		maxChipLevel := player.InitialStackSize
-		minChipLevel := gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
-
-		if maxChipLevel <= minChipLevel {
-			err := br.actions.Raise(maxChipLevel)
-			if err != nil {
-				return err
-			}
-
-			br.updateWagerAction(pokertable.WagerAction_Raise, maxChipLevel)
-			return nil
-		}
-
-		chips = rand.Int63n(maxChipLevel-minChipLevel) + minChipLevel
+	case pokertable.WagerAction_Raise:
 
 		err := br.actions.Raise(chips)
 		if err != nil {
@@ -327,7 +264,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 
 		br.updateWagerAction(pokertable.WagerAction_Raise, chips)
 		return nil
-	case "call":
+	case pokertable.WagerAction_Call:
 		// TODO: should move logic to pokertable
 		wager := int64(0)
 		gamePlayerIdx := br.tableInfo.FindGamePlayerIdx(br.playerID)
@@ -342,7 +279,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 
 		br.updateWagerAction(pokertable.WagerAction_Call, wager)
 		return nil
-	case "check":
+	case pokertable.WagerAction_Check:
 		err := br.actions.Check()
 		if err != nil {
 			return err
@@ -350,7 +287,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 
 		br.updateWagerAction(pokertable.WagerAction_Check, 0)
 		return nil
-	case "allin":
+	case pokertable.WagerAction_AllIn:
 		// TODO: should move logic to pokertable
 		wager := int64(0)
 		gamePlayerIdx := br.tableInfo.FindGamePlayerIdx(br.playerID)
@@ -376,7 +313,7 @@ func (br *botRunner) requestAI(gs *pokerlib.GameState, playerIdx int) error {
 	return nil
 }
 
-func (br *botRunner) updateWagerAction(action string, chips int64) {
+func (br *botRunner) updateWagerAction(action pokertable.PlayerActionType, chips int64) {
 	tableID := ""
 	gameCount := 0
 	round := ""
@@ -388,5 +325,5 @@ func (br *botRunner) updateWagerAction(action string, chips int64) {
 			round = br.tableInfo.State.GameState.Status.Round
 		}
 	}
-	br.onTableGameWagerActionUpdated(tableID, br.curGameID, gameCount, round, action, chips)
+	br.onTableGameWagerActionUpdated(tableID, br.curGameID, gameCount, round, string(action), chips)
 }
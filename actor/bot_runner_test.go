@@ -126,7 +126,7 @@ func TestActor_BotRunner_Humanize(t *testing.T) {
 
 	// Start game
 	time.Sleep(time.Microsecond * 100)
-	err = tableEngine.StartTableGame()
+	err = tableEngine.StartTableGameIfNotStarted()
 	assert.Nil(t, err)
 
 	wg.Wait()
@@ -152,7 +152,7 @@ func TestActor_ObserverRunner_PlayerAct(t *testing.T) {
 
 	// Start game
 	time.Sleep(time.Microsecond * 100)
-	err = tableEngine.StartTableGame()
+	err = tableEngine.StartTableGameIfNotStarted()
 	assert.Nil(t, err)
 
 	wg.Wait()
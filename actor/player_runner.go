@@ -95,7 +95,7 @@ func (pr *playerRunner) UpdateTableState(table *pokertable.Table) error {
 
 		// Somehow, this player is not in the game.
 		// It probably has no chips already.
-		if gamePlayerIdx == -1 {
+		if pokertable.IsUnset(gamePlayerIdx) {
 			return nil
 		}
 
@@ -120,7 +120,7 @@ func (pr *playerRunner) OnTableStateUpdated(fn func(*pokertable.Table)) error {
 func (pr *playerRunner) requestMove(gs *pokerlib.GameState, playerIdx int) error {
 
 	// Do pass automatically
-	if gs.HasAction(playerIdx, "pass") {
+	if gs.HasAction(playerIdx, string(pokertable.Action_Pass)) {
 		return pr.actions.Pass()
 	}
 
@@ -150,11 +150,11 @@ func (pr *playerRunner) requestMove(gs *pokerlib.GameState, playerIdx int) error
 func (pr *playerRunner) automate(gs *pokerlib.GameState, playerIdx int) error {
 
 	// Default actions for automation when player has no response
-	if gs.HasAction(playerIdx, "ready") {
+	if gs.HasAction(playerIdx, string(pokertable.Action_Ready)) {
 		return pr.actions.Ready()
-	} else if gs.HasAction(playerIdx, "check") {
+	} else if gs.HasAction(playerIdx, string(pokertable.WagerAction_Check)) {
 		return pr.actions.Check()
-	} else if gs.HasAction(playerIdx, "fold") {
+	} else if gs.HasAction(playerIdx, string(pokertable.WagerAction_Fold)) {
 		return pr.actions.Fold()
 	}
 
@@ -0,0 +1,203 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/settlement"
+	"github.com/d-protocol/pokertable"
+)
+
+var (
+	ErrSimulationNotEnoughPlayers = errors.New("simulation: at least two strategies are required")
+	ErrSimulationStalled          = errors.New("simulation: hand is not closed")
+)
+
+const (
+	SimulationBankroll = 1000 // Chips every simulated player starts with
+	SimulationSB       = 5
+	SimulationBB       = 10
+
+	simulationTableID      = "simulation"
+	simulationMaxDuration  = 24 * 60 * 60         // Seconds, long enough to never end the simulated table
+	simulationHandTimeout  = 10 * time.Second     // A hand not settled in time is considered stalled
+	simulationPollInterval = 1 * time.Millisecond // Interval the bots read the table at
+)
+
+// seededGameBackend shuffles the deck of every game with the simulation rng
+type seededGameBackend struct {
+	*pokertable.NativeGameBackend
+	rng *rand.Rand
+}
+
+func (b *seededGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
+	gs, err := b.NativeGameBackend.CreateGame(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// no card is dealt yet, replace the shuffled deck
+	deck := append([]string{}, opts.Deck...)
+	b.rng.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
+	gs.Meta.Deck = deck
+	return gs, nil
+}
+
+/*
+SimulateHands plays hands to completion between the strategies on a table engine, key: player id
+  - Use case: Strategy testing
+  - Deterministic for a given seed & strategy set, the seed shuffles the decks & feeds the strategies
+  - Every player is a bot runner with its strategy, bots act on a snapshot of the table one poll at a time
+  - Players are seated by player id with SimulationBankroll chips, blinds are SimulationSB/SimulationBB & the button starts from the first seat
+  - Stops early once a single player has chips left, returns the result of every played hand
+*/
+func SimulateHands(count int, seed int64, strategies map[string]Strategy) ([]*settlement.Result, error) {
+	if len(strategies) < 2 {
+		return nil, ErrSimulationNotEnoughPlayers
+	}
+
+	playerIDs := make([]string, 0, len(strategies))
+	for playerID := range strategies {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	rng := rand.New(rand.NewSource(seed))
+	backend := &seededGameBackend{
+		NativeGameBackend: pokertable.NewNativeGameBackend(),
+		rng:               rng,
+	}
+
+	options := pokertable.NewTableEngineOptions()
+	options.GameContinueInterval = 0
+	engine := pokertable.NewTableEngine(options, pokertable.WithGameBackend(backend))
+
+	// Record the result of every hand as it settles
+	var settledLock sync.Mutex
+	settled := make([]*settlement.Result, 0, count)
+	settledGameCount := 0
+	engine.OnTableUpdated(func(table *pokertable.Table) {
+		if table.State.Status != pokertable.TableStateStatus_TableGameSettled || table.State.GameCount == settledGameCount {
+			return
+		}
+
+		cloneTable, err := table.Clone()
+		if err != nil || cloneTable.State.GameState == nil || cloneTable.State.GameState.Result == nil {
+			return
+		}
+
+		settledLock.Lock()
+		defer settledLock.Unlock()
+		settled = append(settled, cloneTable.State.GameState.Result)
+		settledGameCount = table.State.GameCount
+	})
+	engine.OnReadyOpenFirstTableGame(func(competitionID, tableID string, gameCount int, playerStates []*pokertable.TablePlayerState) {
+		participants := make(map[string]int)
+		for idx, p := range playerStates {
+			participants[p.PlayerID] = idx
+		}
+		engine.SetUpTableGame(gameCount, participants)
+	})
+
+	table, err := engine.CreateTable(pokertable.TableSetting{
+		TableID: simulationTableID,
+		Meta: pokertable.TableMeta{
+			Rule:                pokertable.CompetitionRule_Default,
+			Mode:                pokertable.CompetitionMode_CT,
+			MaxDuration:         simulationMaxDuration,
+			TableMaxSeatCount:   len(playerIDs),
+			TableMinPlayerCount: 2,
+			MinChipUnit:         1,
+			InitialButtonMode:   pokertable.InitialButtonMode_FixedSeat,
+		},
+		Blind: pokertable.TableBlindState{
+			Level: 1,
+			SB:    SimulationSB,
+			BB:    SimulationBB,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer engine.CloseTable()
+
+	// Seat a bot for every strategy
+	actors := make([]Actor, 0, len(playerIDs))
+	for seat, playerID := range playerIDs {
+		if err := engine.PlayerReserve(pokertable.JoinPlayer{PlayerID: playerID, RedeemChips: SimulationBankroll, Seat: seat}); err != nil {
+			return nil, err
+		}
+		if err := engine.PlayerJoin(playerID); err != nil {
+			return nil, err
+		}
+
+		bot := NewBotRunner(playerID)
+		bot.SetStrategy(strategies[playerID], rng)
+
+		a := NewActor()
+		a.SetAdapter(NewTableEngineAdapter(engine, table))
+		a.SetRunner(bot)
+		actors = append(actors, a)
+	}
+
+	if err := engine.StartTableGame(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*settlement.Result, 0, count)
+	deadline := time.Now().Add(simulationHandTimeout)
+	for len(results) < count {
+		settledLock.Lock()
+		for len(results) < len(settled) && len(results) < count {
+			results = append(results, settled[len(results)])
+			deadline = time.Now().Add(simulationHandTimeout)
+		}
+		settledLock.Unlock()
+		if len(results) == count {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return results, fmt.Errorf("hand %d: %w", len(results)+1, ErrSimulationStalled)
+		}
+
+		var snapshot *pokertable.Table
+		if err := engine.WithReadSnapshot(func(t *pokertable.Table) {
+			snapshot = t
+		}); err != nil {
+			return results, err
+		}
+
+		if !snapshot.IsHandRunning() {
+			// A single player has chips left
+			alivePlayerIDs := make([]string, 0)
+			for _, player := range snapshot.State.PlayerStates {
+				if player.Bankroll > 0 {
+					alivePlayerIDs = append(alivePlayerIDs, player.PlayerID)
+				}
+			}
+			if len(alivePlayerIDs) < 2 {
+				break
+			}
+
+			// Players are done with the settlement of the last hand, open the next one
+			for _, playerID := range alivePlayerIDs {
+				engine.PlayerSettlementFinish(playerID)
+			}
+		} else {
+			for _, a := range actors {
+				a.GetTable().UpdateTableState(snapshot)
+			}
+		}
+
+		time.Sleep(simulationPollInterval)
+	}
+	return results, nil
+}
@@ -0,0 +1,51 @@
+package actor
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/d-protocol/pokerlib"
+	pokertable "github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSimulationStrategies() map[string]Strategy {
+	return map[string]Strategy{
+		"P1": NewRandomStrategy(),
+		"P2": NewRandomStrategy(),
+		"P3": NewRandomStrategy(),
+		"P4": StrategyFunc(func(gs *pokerlib.GameState, gamePlayerIdx int, rng *rand.Rand) (pokertable.PlayerActionType, int64) {
+			// calling station
+			for _, action := range []pokertable.PlayerActionType{pokertable.WagerAction_Check, pokertable.WagerAction_Call} {
+				if gs.HasAction(gamePlayerIdx, string(action)) {
+					return action, 0
+				}
+			}
+			return pokertable.WagerAction_AllIn, 0
+		}),
+	}
+}
+
+func TestSimulateHands_Deterministic(t *testing.T) {
+	results, err := SimulateHands(20, 42, newTestSimulationStrategies())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results)
+
+	again, err := SimulateHands(20, 42, newTestSimulationStrategies())
+	assert.NoError(t, err)
+	assert.Equal(t, results, again)
+
+	// chips are only moved between the players
+	for _, result := range results {
+		changed := int64(0)
+		for _, player := range result.Players {
+			changed += player.Changed
+		}
+		assert.Equal(t, int64(0), changed)
+	}
+}
+
+func TestSimulateHands_NotEnoughPlayers(t *testing.T) {
+	_, err := SimulateHands(1, 42, map[string]Strategy{"P1": NewRandomStrategy()})
+	assert.ErrorIs(t, err, ErrSimulationNotEnoughPlayers)
+}
@@ -0,0 +1,71 @@
+package actor
+
+import (
+	"math/rand"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+)
+
+/*
+Strategy decides the wager actions of a bot
+  - Decide is called with the game player to act, rng is the only source of randomness for reproducible simulations
+*/
+type Strategy interface {
+	Decide(gs *pokerlib.GameState, gamePlayerIdx int, rng *rand.Rand) (action pokertable.PlayerActionType, chips int64)
+}
+
+// StrategyFunc adapts a function to Strategy
+type StrategyFunc func(gs *pokerlib.GameState, gamePlayerIdx int, rng *rand.Rand) (pokertable.PlayerActionType, int64)
+
+func (fn StrategyFunc) Decide(gs *pokerlib.GameState, gamePlayerIdx int, rng *rand.Rand) (pokertable.PlayerActionType, int64) {
+	return fn(gs, gamePlayerIdx, rng)
+}
+
+/*
+NewRandomStrategy is the default strategy of the bot runner
+  - Actions are picked by actionProbabilities among the allowed ones, bets & raises are sized at random
+*/
+func NewRandomStrategy() Strategy {
+	return StrategyFunc(func(gs *pokerlib.GameState, gamePlayerIdx int, rng *rand.Rand) (pokertable.PlayerActionType, int64) {
+		player := gs.GetPlayer(gamePlayerIdx)
+
+		totalWeight := 0.0
+		for _, p := range actionProbabilities {
+			if gs.HasAction(gamePlayerIdx, p.Action) {
+				totalWeight += p.Weight
+			}
+		}
+
+		action := pokertable.WagerAction_Fold
+		randomNum := rng.Float64() * totalWeight
+		for _, p := range actionProbabilities {
+			if !gs.HasAction(gamePlayerIdx, p.Action) {
+				continue
+			}
+
+			action = pokertable.PlayerActionType(p.Action)
+			if randomNum < p.Weight {
+				break
+			}
+			randomNum -= p.Weight
+		}
+
+		switch action {
+		case pokertable.WagerAction_Bet:
+			minBet := gs.Status.MiniBet
+			if player.InitialStackSize <= minBet {
+				return action, player.InitialStackSize
+			}
+			return action, rng.Int63n(player.InitialStackSize-minBet) + minBet
+		case pokertable.WagerAction_Raise:
+			maxChipLevel := player.InitialStackSize
+			minChipLevel := gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
+			if maxChipLevel <= minChipLevel {
+				return action, maxChipLevel
+			}
+			return action, rng.Int63n(maxChipLevel-minChipLevel) + minChipLevel
+		}
+		return action, 0
+	})
+}
@@ -0,0 +1,60 @@
+package pokertable
+
+// boardTextureRanks are the card points in straight order, the ace also plays low
+var boardTextureRanks = "A23456789TJQKA"
+
+/*
+newBoardTexture analyses the community cards (e.g. "SA", "HT")
+  - An empty board (preflop) returns an empty texture
+  - Straight windows cover A-5 (wheel) up to T-A (broadway)
+*/
+func newBoardTexture(board []string) BoardTexture {
+	texture := BoardTexture{
+		Board: append([]string{}, board...),
+	}
+	if len(board) == 0 {
+		return texture
+	}
+
+	suitCounts := make(map[byte]int)
+	rankCounts := make(map[byte]int)
+	for _, card := range board {
+		if len(card) != 2 {
+			continue
+		}
+		suitCounts[card[0]]++
+		rankCounts[card[1]]++
+	}
+
+	for _, count := range rankCounts {
+		if count >= 2 {
+			texture.IsPaired = true
+		}
+	}
+
+	maxSuitCount := 0
+	for _, count := range suitCounts {
+		if count > maxSuitCount {
+			maxSuitCount = count
+		}
+	}
+	texture.IsMonotone = len(board) >= 2 && len(suitCounts) == 1
+	texture.IsTwoTone = maxSuitCount == 2
+	texture.IsRainbow = maxSuitCount == 1
+	texture.IsFlushPossible = maxSuitCount >= 3
+
+	for start := 0; start+5 <= len(boardTextureRanks); start++ {
+		connected := 0
+		for _, rank := range boardTextureRanks[start : start+5] {
+			if rankCounts[byte(rank)] > 0 {
+				connected++
+			}
+		}
+		if connected > texture.ConnectedCount {
+			texture.ConnectedCount = connected
+		}
+	}
+	texture.IsStraightDrawHeavy = texture.ConnectedCount >= 3
+
+	return texture
+}
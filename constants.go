@@ -2,7 +2,7 @@ package pokertable
 
 const (
 	// General
-	UnsetValue = -1
+	UnsetValue = -1 // Seat or index that is not set (e.g. a player not found, a seat without a player), check with IsUnset
 
 	// CompetitionMode
 	CompetitionMode_CT   = "ct"   // 倒數錦標賽
@@ -14,8 +14,33 @@ const (
 	CompetitionRule_ShortDeck = "short_deck" // 短牌
 	CompetitionRule_Omaha     = "omaha"      // 奧瑪哈
 
+	// DisconnectPolicy
+	DisconnectPolicy_FastFold = "fast_fold" // 輪到時立即過牌或棄牌
+	DisconnectPolicy_UseTimer = "use_timer" // 等待行動時間結束
+	DisconnectPolicy_SitOut   = "sit_out"   // 立即過牌或棄牌，並暫停參與之後的牌局
+
+	// AutoReadyPhase
+	AutoReadyPhase_Join = "join" // 入座逾時自動入座
+	AutoReadyPhase_Game = "game" // 牌局準備逾時自動準備 (含盲注)
+
+	// TableRole
+	TableRole_Normal   = "normal"    // 一般桌
+	TableRole_MustMove = "must_move" // 必移桌 (補位至主桌)
+	TableRole_Main     = "main"      // 主桌
+
+	// RotationDirection
+	RotationDirection_Clockwise        = "clockwise"         // 順時針 (預設)
+	RotationDirection_CounterClockwise = "counter_clockwise" // 逆時針
+
+	// InitialButtonMode
+	InitialButtonMode_Random    = "random"     // 隨機座位 (預設)
+	InitialButtonMode_FixedSeat = "fixed_seat" // 固定從第一個有坐人的座位開始
+	InitialButtonMode_HighCard  = "high_card"  // 每人發一張牌，最大者為 Dealer
+
 	// Position
 	Position_Unknown = "unknown"
+	Position_Ante    = "ante"    // OnBlindPosted only, the ante is not a seat position
+	Position_DeadBB  = "dead_bb" // OnBlindPosted only, a late registration big blind posted dead, see LateRegBlindRule_PostDead
 	Position_Dealer  = "dealer"
 	Position_SB      = "sb"
 	Position_BB      = "bb"
@@ -27,17 +52,10 @@ const (
 	Position_HJ      = "hj"
 	Position_CO      = "co"
 
-	// Action
-	Action_Ready = "ready"
-	Action_Pay   = "pay"
-
-	// Wager Action
-	WagerAction_Fold  = "fold"
-	WagerAction_Check = "check"
-	WagerAction_Call  = "call"
-	WagerAction_AllIn = "allin"
-	WagerAction_Bet   = "bet"
-	WagerAction_Raise = "raise"
+	// LateRegBlindRule
+	LateRegBlindRule_None      = ""            // 直接入局
+	LateRegBlindRule_PostDead  = "post_dead"   // 補大盲入局
+	LateRegBlindRule_WaitForBB = "wait_for_bb" // 等到大盲再入局
 
 	// Round
 	GameRound_Preflop = "preflop"
@@ -45,3 +63,21 @@ const (
 	GameRound_Turn    = "turn"
 	GameRound_River   = "river"
 )
+
+// PlayerActionType is an action a player takes in a game, the value is the pokerlib action symbol
+type PlayerActionType string
+
+const (
+	// Action
+	Action_Ready PlayerActionType = "ready"
+	Action_Pay   PlayerActionType = "pay"
+	Action_Pass  PlayerActionType = "pass"
+
+	// Wager Action
+	WagerAction_Fold  PlayerActionType = "fold"
+	WagerAction_Check PlayerActionType = "check"
+	WagerAction_Call  PlayerActionType = "call"
+	WagerAction_AllIn PlayerActionType = "allin"
+	WagerAction_Bet   PlayerActionType = "bet"
+	WagerAction_Raise PlayerActionType = "raise"
+)
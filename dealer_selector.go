@@ -0,0 +1,63 @@
+package pokertable
+
+// DealerSelection is the state a DealerSelector decides the dealer seat from
+type DealerSelection struct {
+	MaxSeatCount      int
+	DealerSeatID      int
+	SBSeatID          int
+	BBSeatID          int
+	HasDealerPlayer   bool   // A participating player sits at DealerSeatID
+	HasSBPlayer       bool   // A participating player sits at SBSeatID
+	ActiveSeatIDs     []int  // Seats with an active player in the seat manager
+	RotationDirection string // RotationDirection_*
+}
+
+// DealerSelector decides the seat game players are ordered from, including the "fake dealer" of a dead button
+type DealerSelector interface {
+	SelectDealerSeat(selection DealerSelection) int
+}
+
+type defaultDealerSelector struct{}
+
+func NewDefaultDealerSelector() DealerSelector {
+	return &defaultDealerSelector{}
+}
+
+/*
+SelectDealerSeat returns the dealer seat, or a fake dealer seat when the dealer seat is empty
+  - Empty SB: the prior active seat of the BB is the fake dealer
+  - Has SB: the prior active seat of the SB is the fake dealer
+  - Returns UnsetValue if there is no active seat
+*/
+func (ds *defaultDealerSelector) SelectDealerSeat(selection DealerSelection) int {
+	if selection.HasDealerPlayer {
+		return selection.DealerSeatID
+	}
+
+	startSeatID := selection.BBSeatID
+	if selection.HasSBPlayer {
+		startSeatID = selection.SBSeatID
+	}
+
+	activeSeatIDs := make(map[int]bool)
+	for _, seatID := range selection.ActiveSeatIDs {
+		activeSeatIDs[seatID] = true
+	}
+
+	for i := selection.MaxSeatCount - 1; i >= 0; i-- {
+		seatID := seatIDFrom(startSeatID, i, selection.MaxSeatCount, selection.RotationDirection)
+		if activeSeatIDs[seatID] {
+			return seatID
+		}
+	}
+
+	return UnsetValue
+}
+
+// seatIDFrom returns the seat id offset seats after startSeatID in the rotation direction
+func seatIDFrom(startSeatID, offset, seatCount int, direction string) int {
+	if direction == RotationDirection_CounterClockwise {
+		offset = -offset
+	}
+	return ((startSeatID+offset)%seatCount + seatCount) % seatCount
+}
@@ -0,0 +1,86 @@
+package pokertable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultDealerSelector_HasDealer(t *testing.T) {
+	ds := NewDefaultDealerSelector()
+
+	dealerSeatID := ds.SelectDealerSeat(DealerSelection{
+		MaxSeatCount:    9,
+		DealerSeatID:    4,
+		SBSeatID:        6,
+		BBSeatID:        0,
+		HasDealerPlayer: true,
+		HasSBPlayer:     true,
+		ActiveSeatIDs:   []int{0, 2, 4, 6},
+	})
+	assert.Equal(t, 4, dealerSeatID)
+}
+
+func TestDefaultDealerSelector_EmptyDealer(t *testing.T) {
+	ds := NewDefaultDealerSelector()
+
+	// prior active seat of the sb
+	dealerSeatID := ds.SelectDealerSeat(DealerSelection{
+		MaxSeatCount:  9,
+		DealerSeatID:  4,
+		SBSeatID:      6,
+		BBSeatID:      0,
+		HasSBPlayer:   true,
+		ActiveSeatIDs: []int{0, 2, 6},
+	})
+	assert.Equal(t, 2, dealerSeatID)
+}
+
+func TestDefaultDealerSelector_EmptySB(t *testing.T) {
+	ds := NewDefaultDealerSelector()
+
+	// the dealer is kept
+	dealerSeatID := ds.SelectDealerSeat(DealerSelection{
+		MaxSeatCount:    9,
+		DealerSeatID:    4,
+		SBSeatID:        6,
+		BBSeatID:        0,
+		HasDealerPlayer: true,
+		ActiveSeatIDs:   []int{0, 2, 4},
+	})
+	assert.Equal(t, 4, dealerSeatID)
+}
+
+func TestDefaultDealerSelector_EmptyDealerAndSB(t *testing.T) {
+	ds := NewDefaultDealerSelector()
+
+	// prior active seat of the bb, wrapping around
+	dealerSeatID := ds.SelectDealerSeat(DealerSelection{
+		MaxSeatCount:  9,
+		DealerSeatID:  4,
+		SBSeatID:      6,
+		BBSeatID:      1,
+		ActiveSeatIDs: []int{1, 2},
+	})
+	assert.Equal(t, 2, dealerSeatID)
+
+	// counter-clockwise looks at the higher seats first
+	dealerSeatID = ds.SelectDealerSeat(DealerSelection{
+		MaxSeatCount:      9,
+		DealerSeatID:      4,
+		SBSeatID:          2,
+		BBSeatID:          0,
+		ActiveSeatIDs:     []int{0, 5, 7},
+		RotationDirection: RotationDirection_CounterClockwise,
+	})
+	assert.Equal(t, 5, dealerSeatID)
+
+	// no active seat
+	dealerSeatID = ds.SelectDealerSeat(DealerSelection{
+		MaxSeatCount: 9,
+		DealerSeatID: 4,
+		SBSeatID:     6,
+		BBSeatID:     0,
+	})
+	assert.Equal(t, UnsetValue, dealerSeatID)
+}
@@ -1,7 +1,10 @@
 package pokertable
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,50 +14,360 @@ const (
 	TableStateEvent_GameUpdated   = "GameUpdated"
 	TableStateEvent_GameSettled   = "GameSettled"
 	TableStateEvent_PlayersLeave  = "PlayersLeave"
+	TableStateEvent_BlindUpdated  = "BlindUpdated"
 )
 
+// TableErrorChannelSize is the buffer size of the engine's error channel
+const TableErrorChannelSize = 128
+
+// TableErrorSeverity tells whether the table needs help after a TableError
+type TableErrorSeverity string
+
+const (
+	TableErrorSeverity_Error       TableErrorSeverity = "error"       // the failed event is dropped
+	TableErrorSeverity_Recoverable TableErrorSeverity = "recoverable" // the table recovers by itself, e.g. ErrBackendTimeout is retried or the hand is dealt again
+)
+
+// TableError is an async error emitted by the table engine
+type TableError struct {
+	TableID   string             `json:"table_id"`
+	GameCount int                `json:"game_count"`
+	EventName string             `json:"event_name"`
+	PlayerID  string             `json:"player_id"`
+	Severity  TableErrorSeverity `json:"severity"`
+	Err       error              `json:"-"`
+	CreatedAt int64              `json:"created_at"`
+}
+
+// tableErrorSeverity classifies the error of a TableError
+func tableErrorSeverity(err error) TableErrorSeverity {
+	if errors.Is(err, ErrBackendTimeout) {
+		return TableErrorSeverity_Recoverable
+	}
+	return TableErrorSeverity_Error
+}
+
+func (e TableError) Error() string {
+	return fmt.Sprintf("table %s: %s: %v", e.TableID, e.EventName, e.Err)
+}
+
+func (e TableError) Unwrap() error {
+	return e.Err
+}
+
 func (te *tableEngine) emitEvent(eventName string, playerID string) {
 	// refresh table
 	te.table.UpdateAt = time.Now().Unix()
 	te.table.UpdateSerial++
+	te.publishTableSnapshot()
 
 	// emit event
 	fmt.Printf("->[c: %s][t: %s][#%d][%d][%s] emit Event: %s\n", te.table.Meta.CompetitionID, te.table.ID, te.table.UpdateSerial, te.table.State.GameCount, playerID, eventName)
-	te.onTableUpdated(te.table)
+	if te.coalescedEvents {
+		te.eventBatchLock.Lock()
+		if te.lock.isHeld.Load() {
+			// delivered by flushCoalescedEvents when the lock is released
+			te.pendingEventSerial = te.table.UpdateSerial
+			te.eventBatchLock.Unlock()
+			return
+		}
+		te.emittedEventSerial = te.table.UpdateSerial
+		te.eventBatchLock.Unlock()
+	}
+
+	table := te.table
+	if te.lock.isDeferringEmits() {
+		// the live table changes before the callback is delivered, deliver the snapshot just published
+		if snapshot := te.tableSnapshot.Load(); snapshot != nil {
+			table = snapshot
+		}
+	}
+	te.lock.deliver(func() { te.onTableUpdated(table) })
+}
+
+/*
+flushCoalescedEvents delivers the updates held back under the lock as a single OnTableUpdated, see WithCoalescedEvents
+  - The lock is released, the snapshot published by the latest update is delivered instead of the table other goroutines may be changing
+*/
+func (te *tableEngine) flushCoalescedEvents() {
+	te.eventBatchLock.Lock()
+	if te.pendingEventSerial <= te.emittedEventSerial {
+		te.eventBatchLock.Unlock()
+		return
+	}
+	te.emittedEventSerial = te.pendingEventSerial
+	te.eventBatchLock.Unlock()
+
+	if snapshot := te.tableSnapshot.Load(); snapshot != nil {
+		te.onTableUpdated(snapshot)
+	}
+}
+
+/*
+tableLock is the table lock, it tells emitEvent whether the updates are made under the lock
+  - onUnlock runs after the lock is released, so that OnTableUpdated handlers may call locking methods
+  - Under LockDeferringEmits the callbacks are held back & delivered once the lock is released, see deliver
+*/
+type tableLock struct {
+	sync.Mutex
+	isHeld      atomic.Bool
+	onUnlock    func()
+	deferLock   sync.Mutex // guards isDeferring & deferred, emitters running without the lock read them too
+	isDeferring bool
+	deferred    []func()
+}
+
+func (l *tableLock) Lock() {
+	l.Mutex.Lock()
+	l.isHeld.Store(true)
+}
+
+/*
+LockDeferringEmits takes the lock for the game goroutine, the callbacks emitted until Unlock are delivered after it is released
+  - Callbacks of game states may call locking methods synchronously (e.g. act from OnTableUpdated)
+*/
+func (l *tableLock) LockDeferringEmits() {
+	l.Lock()
+
+	l.deferLock.Lock()
+	l.isDeferring = true
+	l.deferLock.Unlock()
+}
+
+func (l *tableLock) Unlock() {
+	l.deferLock.Lock()
+	deferred := l.deferred
+	l.isDeferring = false
+	l.deferred = nil
+	l.deferLock.Unlock()
+
+	l.isHeld.Store(false)
+	l.Mutex.Unlock()
+
+	for _, emit := range deferred {
+		emit()
+	}
+
+	if l.onUnlock != nil {
+		l.onUnlock()
+	}
+}
+
+// isDeferringEmits returns true while the callbacks are held back by LockDeferringEmits
+func (l *tableLock) isDeferringEmits() bool {
+	l.deferLock.Lock()
+	defer l.deferLock.Unlock()
+
+	return l.isDeferring
+}
+
+/*
+deliver runs the callback, or holds it back until Unlock under LockDeferringEmits
+  - A callback emitted without the lock while the game goroutine holds it is delivered when the game goroutine releases it
+*/
+func (l *tableLock) deliver(emit func()) {
+	l.deferLock.Lock()
+	if l.isDeferring {
+		l.deferred = append(l.deferred, emit)
+		l.deferLock.Unlock()
+		return
+	}
+	l.deferLock.Unlock()
+
+	emit()
+}
+
+// emittedTable is the table passed to the callbacks, a copy if they are delivered once the lock is released
+func (te *tableEngine) emittedTable() *Table {
+	if !te.lock.isDeferringEmits() {
+		return te.table
+	}
+
+	table, err := te.table.Clone()
+	if err != nil {
+		fmt.Printf("[DEBUG#emittedTable] Table (%s) can't be cloned. Error: %v\n", te.table.ID, err)
+		return te.table
+	}
+	return table
+}
+
+// emittedPlayerState is the player state passed to the callbacks, a copy if they are delivered once the lock is released
+func (te *tableEngine) emittedPlayerState(player *TablePlayerState) *TablePlayerState {
+	if !te.lock.isDeferringEmits() {
+		return player
+	}
+	return player.clone()
 }
 
 // TODO: replace err(error) with errMsg(string)
 func (te *tableEngine) emitErrorEvent(eventName string, playerID string, err error) {
 	fmt.Printf("->[c: %s][t: %s][#%d][%d][%s] emit ERROR Event: %s, Error: %v\n", te.table.Meta.CompetitionID, te.table.ID, te.table.UpdateSerial, te.table.State.GameCount, playerID, eventName, err)
-	te.onTableErrorUpdated(te.table, err)
+	table := te.emittedTable()
+	te.lock.deliver(func() { te.onTableErrorUpdated(table, err) })
+
+	// Never block the engine: drop the error if nobody drains the channel
+	select {
+	case te.errCh <- TableError{
+		TableID:   te.table.ID,
+		GameCount: te.table.State.GameCount,
+		EventName: eventName,
+		PlayerID:  playerID,
+		Severity:  tableErrorSeverity(err),
+		Err:       err,
+		CreatedAt: time.Now().Unix(),
+	}:
+	default:
+	}
 }
 
 func (te *tableEngine) emitTableStateEvent(eventName string) {
 	// emit event
 	// fmt.Printf("->emit state Event: %s\n", eventName)
-	te.onTableStateUpdated(eventName, te.table)
+	table := te.emittedTable()
+	te.lock.deliver(func() { te.onTableStateUpdated(eventName, table) })
 }
 
 func (te *tableEngine) emitTablePlayerStateEvent(player *TablePlayerState) {
 	// emit event
 	// fmt.Printf("->emit player state Event: %s\n", player.PlayerID)
-	te.onTablePlayerStateUpdated(te.table.Meta.CompetitionID, te.table.ID, player)
+	competitionID, tableID, player := te.table.Meta.CompetitionID, te.table.ID, te.emittedPlayerState(player)
+	te.lock.deliver(func() { te.onTablePlayerStateUpdated(competitionID, tableID, player) })
 }
 
 func (te *tableEngine) emitTablePlayerReservedEvent(player *TablePlayerState) {
 	// emit event
 	// fmt.Printf("->emit player reserved Event: %s\n", player.PlayerID)
-	te.onTablePlayerReserved(te.table.Meta.CompetitionID, te.table.ID, player)
+	competitionID, tableID, player := te.table.Meta.CompetitionID, te.table.ID, te.emittedPlayerState(player)
+	te.lock.deliver(func() { te.onTablePlayerReserved(competitionID, tableID, player) })
 }
 
 func (te *tableEngine) emitGamePlayerActionEvent(gameAction TablePlayerGameAction) {
 	// emit event
 	// fmt.Printf("->emit player game action Event: %s %s %d\n", gameAction.PlayerID, gameAction.Action, gameAction.Chips)
-	te.onGamePlayerActionUpdated(gameAction)
+	if te.handHistory != nil {
+		te.handHistory.Actions = append(te.handHistory.Actions, gameAction)
+	}
+	te.lock.deliver(func() { te.onGamePlayerActionUpdated(gameAction) })
 }
 
 func (te *tableEngine) emitReadyOpenFirstTableGame(gameCount int, playerStates []*TablePlayerState) {
 	// emit event
 	// fmt.Printf("->emit ready open first table game: %d players\n", len(playerStates))
-	te.onReadyOpenFirstTableGame(te.table.Meta.CompetitionID, te.table.ID, gameCount, playerStates)
+	competitionID, tableID := te.table.Meta.CompetitionID, te.table.ID
+	players := make([]*TablePlayerState, 0, len(playerStates))
+	for _, player := range playerStates {
+		players = append(players, te.emittedPlayerState(player))
+	}
+	te.lock.deliver(func() { te.onReadyOpenFirstTableGame(competitionID, tableID, gameCount, players) })
+}
+
+func (te *tableEngine) emitSeatOpenedEvent(seat int) {
+	// emit event
+	// fmt.Printf("->emit seat opened Event: %d\n", seat)
+	tableID := te.table.ID
+	te.lock.deliver(func() { te.onSeatOpened(tableID, seat) })
+}
+
+func (te *tableEngine) emitSeatMapChangedEvent(seatMap map[int]int) {
+	// emit event
+	// fmt.Printf("->emit seat map changed Event: %+v\n", seatMap)
+	tableID := te.table.ID
+	te.lock.deliver(func() { te.onSeatMapChanged(tableID, seatMap) })
+}
+
+func (te *tableEngine) emitTableStarvedEvent() {
+	// emit event
+	// fmt.Printf("->emit table starved Event: %s\n", te.table.ID)
+	tableID := te.table.ID
+	te.lock.deliver(func() { te.onTableStarved(tableID) })
+}
+
+func (te *tableEngine) emitInsufficientParticipantsEvent(count int) {
+	// emit event
+	// fmt.Printf("->emit insufficient participants Event: %d\n", count)
+	te.lock.deliver(func() { te.onInsufficientParticipants(count) })
+}
+
+func (te *tableEngine) emitInsuranceOfferEvent(playerID string, equity float64, maxPayout int64) {
+	// emit event
+	// fmt.Printf("->emit insurance offer Event: %s %.4f %d\n", playerID, equity, maxPayout)
+	te.lock.deliver(func() { te.onInsuranceOffer(playerID, equity, maxPayout) })
+}
+
+func (te *tableEngine) emitMisdealEvent(gameCount int, reason string) {
+	// emit event
+	// fmt.Printf("->emit misdeal Event: %d %s\n", gameCount, reason)
+	te.lock.deliver(func() { te.onMisdeal(gameCount, reason) })
+}
+
+func (te *tableEngine) emitActionRequiredEvent(action ActionRequired) {
+	// emit event
+	// fmt.Printf("->emit action required Event: %+v\n", action)
+	te.lock.deliver(func() { te.onActionRequired(action) })
+}
+
+func (te *tableEngine) emitBlindPostedEvent(playerID string, position string, amount int64) {
+	// emit event
+	// fmt.Printf("->emit blind posted Event: %s %s %d\n", playerID, position, amount)
+	te.lock.deliver(func() { te.onBlindPosted(playerID, position, amount) })
+}
+
+func (te *tableEngine) emitReadyProgressEvent(ready, total int) {
+	// emit event
+	// fmt.Printf("->emit ready progress Event: %d/%d\n", ready, total)
+	te.lock.deliver(func() { te.onReadyProgress(ready, total) })
+}
+
+func (te *tableEngine) emitGameSettledEvent(gameCount int, results []PlayerHandResult) {
+	// emit event
+	// fmt.Printf("->emit game settled Event: %d %+v\n", gameCount, results)
+	te.lock.deliver(func() { te.onGameSettled(gameCount, results) })
+}
+
+func (te *tableEngine) emitActionWarningEvent(playerID string, secondsLeft int) {
+	// emit event
+	// fmt.Printf("->emit action warning Event: %s %d\n", playerID, secondsLeft)
+	te.lock.deliver(func() { te.onActionWarning(playerID, secondsLeft) })
+}
+
+func (te *tableEngine) emitPlayerAddOnEvent(playerID string, chips int64) {
+	// emit event
+	// fmt.Printf("->emit player add-on Event: %s %d\n", playerID, chips)
+	te.lock.deliver(func() { te.onPlayerAddOn(playerID, chips) })
+}
+
+func (te *tableEngine) emitRoundChangedEvent(round string, board []string) {
+	// emit event
+	// fmt.Printf("->emit round changed Event: %s %v\n", round, board)
+	te.lock.deliver(func() { te.onRoundChanged(round, board) })
+}
+
+func (te *tableEngine) emitWalkEvent(bbPlayerID string, amount int64) {
+	// emit event
+	// fmt.Printf("->emit walk Event: %s %d\n", bbPlayerID, amount)
+	te.lock.deliver(func() { te.onWalk(bbPlayerID, amount) })
+}
+
+func (te *tableEngine) emitDealAppliedEvent(payouts map[string]int64) {
+	// emit event
+	// fmt.Printf("->emit deal applied Event: %+v\n", payouts)
+	te.lock.deliver(func() { te.onDealApplied(payouts) })
+}
+
+func (te *tableEngine) emitPlayerAutoReadyEvent(playerID string, phase string) {
+	// emit event
+	// fmt.Printf("->emit player auto ready Event: %s %s\n", playerID, phase)
+	te.lock.deliver(func() { te.onPlayerAutoReady(playerID, phase) })
+}
+
+func (te *tableEngine) emitPlayerAutoSitOutEvent(playerID string) {
+	// emit event
+	// fmt.Printf("->emit player auto sit out Event: %s\n", playerID)
+	te.lock.deliver(func() { te.onPlayerAutoSitOut(playerID) })
+}
+
+func (te *tableEngine) emitSettlementTimeoutEvent(pendingPlayerIDs []string) {
+	// emit event
+	// fmt.Printf("->emit settlement timeout Event: %+v\n", pendingPlayerIDs)
+	te.lock.deliver(func() { te.onSettlementTimeout(pendingPlayerIDs) })
 }
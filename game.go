@@ -3,6 +3,7 @@ package pokertable
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/d-protocol/pokerlib"
@@ -13,8 +14,12 @@ import (
 var (
 	ErrGamePlayerNotFound      = errors.New("game: player not found")
 	ErrGameInvalidAction       = errors.New("game: invalid action")
+	ErrNotPlayersTurn          = fmt.Errorf("%w: not the player's turn", ErrGameInvalidAction)
+	ErrActionNotAllowed        = fmt.Errorf("%w: action not allowed", ErrGameInvalidAction)
 	ErrGameUnknownEvent        = errors.New("game: unknown event")
 	ErrGameUnknownEventHandler = errors.New("game: unknown event handler")
+	ErrGameNoProgress          = errors.New("game: backend makes no progress")
+	ErrGameNotStarted          = errors.New("game: not started")
 )
 
 type Game interface {
@@ -24,11 +29,15 @@ type Game interface {
 	OnGameStateUpdated(func(*pokerlib.GameState))
 	OnGameRoundClosed(func(*pokerlib.GameState))
 	OnGameErrorUpdated(func(*pokerlib.GameState, error))
+	OnPlayerAutoReady(func(gamePlayerIdx int))
 
 	// Others
 	GetGameState() *pokerlib.GameState
 	Start() (*pokerlib.GameState, error)
 	Next() (*pokerlib.GameState, error)
+	Step(action PlayerAction) (*pokerlib.GameState, error)
+	Close()         // Stop handling game states, a closed game never settles
+	IsClosed() bool // Game is settled or closed, no more states are handled
 
 	// Group Actions
 	ReadyForAll() (*pokerlib.GameState, error)
@@ -48,43 +57,94 @@ type Game interface {
 }
 
 type game struct {
-	backend            GameBackend
-	gs                 *pokerlib.GameState
-	opts               *pokerlib.GameOptions
-	rg                 *syncsaga.ReadyGroup
-	mu                 sync.RWMutex
-	isClosed           bool
-	incomingStates     chan *pokerlib.GameState
-	onAntesReceived    func(*pokerlib.GameState)
-	onBlindsReceived   func(*pokerlib.GameState)
-	onGameStateUpdated func(*pokerlib.GameState)
-	onGameRoundClosed  (func(*pokerlib.GameState))
-	onGameErrorUpdated func(*pokerlib.GameState, error)
-}
-
-func NewGame(backend GameBackend, opts *pokerlib.GameOptions) *game {
-	rg := syncsaga.NewReadyGroup(
-		syncsaga.WithTimeout(17, func(rg *syncsaga.ReadyGroup) {
-			// Auto Ready By Default
-			states := rg.GetParticipantStates()
-			for gamePlayerIdx, isReady := range states {
-				if !isReady {
-					rg.Ready(gamePlayerIdx)
-				}
-			}
-		}),
-	)
-	return &game{
+	backend              GameBackend
+	gs                   *pokerlib.GameState
+	opts                 *pokerlib.GameOptions
+	rg                   *syncsaga.ReadyGroup
+	mu                   sync.RWMutex
+	isClosed             bool
+	incomingStates       chan *pokerlib.GameState
+	onAntesReceived      func(*pokerlib.GameState)
+	onBlindsReceived     func(*pokerlib.GameState)
+	onGameStateUpdated   func(*pokerlib.GameState)
+	onGameRoundClosed    (func(*pokerlib.GameState))
+	onGameErrorUpdated   func(*pokerlib.GameState, error)
+	onPlayerAutoReady    func(gamePlayerIdx int)
+	raiseLockedPlayers   map[int]bool // key: game player index, players who can't raise until the raises since they acted add up to a full raise
+	raiseLockedRound     string
+	raiseLockMinRaise    int64 // full raise size when the players were locked
+	noProgressCount      int   // consecutive transitions that the backend returns the same game id, round & event
+	isSync               bool
+	isAutoPostBlinds     bool
+	isManualRoundAdvance bool
+	isHandlingStates     bool
+	pendingStates        []*pokerlib.GameState // sync mode: states waiting to be handled inline
+}
+
+// PlayerAction is a single action applied by Step
+type PlayerAction struct {
+	PlayerIdx int // game player index
+	Action    PlayerActionType
+	Chips     int64 // chips for pay & bet, chip level for raise
+}
+
+type GameOpt func(*game)
+
+/*
+WithSyncMode handles game states inline instead of on the state updater goroutine
+  - Ready, ante & blinds requests are completed right away, no ready group is involved
+  - Every action returns once the game is waiting for the next player or closed
+  - The game must be driven from a single goroutine
+*/
+func WithSyncMode() GameOpt {
+	return func(g *game) {
+		g.isSync = true
+	}
+}
+
+// WithAutoPostBlinds posts the blinds right away instead of waiting for every blind player to pay
+func WithAutoPostBlinds() GameOpt {
+	return func(g *game) {
+		g.isAutoPostBlinds = true
+	}
+}
+
+/*
+WithManualRoundAdvance stops at RoundClosed instead of dealing the next round automatically
+  - The next round is dealt by Next
+*/
+func WithManualRoundAdvance() GameOpt {
+	return func(g *game) {
+		g.isManualRoundAdvance = true
+	}
+}
+
+// maxNoProgressTransitions is the number of consecutive no progress transitions before the game stops
+const maxNoProgressTransitions = 3
+
+// maxBackendTimeoutRetries is the number of times an automatic transition abandoned with ErrBackendTimeout is retried
+const maxBackendTimeoutRetries = 2
+
+func NewGame(backend GameBackend, opts *pokerlib.GameOptions, gameOpts ...GameOpt) *game {
+	g := &game{
 		backend:            backend,
 		opts:               opts,
-		rg:                 rg,
 		incomingStates:     make(chan *pokerlib.GameState, 1024),
 		onAntesReceived:    func(gs *pokerlib.GameState) {},
 		onBlindsReceived:   func(gs *pokerlib.GameState) {},
 		onGameStateUpdated: func(gs *pokerlib.GameState) {},
 		onGameRoundClosed:  func(*pokerlib.GameState) {},
 		onGameErrorUpdated: func(gs *pokerlib.GameState, err error) {},
+		onPlayerAutoReady:  func(gamePlayerIdx int) {},
+		raiseLockedPlayers: make(map[int]bool),
+	}
+	g.rg = syncsaga.NewReadyGroup(
+		syncsaga.WithTimeout(17, g.autoReady),
+	)
+	for _, opt := range gameOpts {
+		opt(g)
 	}
+	return g
 }
 
 func (g *game) OnAntesReceived(fn func(*pokerlib.GameState)) {
@@ -107,12 +167,18 @@ func (g *game) OnGameErrorUpdated(fn func(*pokerlib.GameState, error)) {
 	g.onGameErrorUpdated = fn
 }
 
+func (g *game) OnPlayerAutoReady(fn func(gamePlayerIdx int)) {
+	g.onPlayerAutoReady = fn
+}
+
 func (g *game) GetGameState() *pokerlib.GameState {
 	return g.gs
 }
 
 func (g *game) Start() (*pokerlib.GameState, error) {
-	g.runGameStateUpdater()
+	if !g.isSync {
+		g.runGameStateUpdater()
+	}
 
 	gs, err := g.backend.CreateGame(g.opts)
 	if err != nil {
@@ -123,6 +189,25 @@ func (g *game) Start() (*pokerlib.GameState, error) {
 	return g.GetGameState(), nil
 }
 
+/*
+Close stops the game without settling it
+  - Use case: The hand is voided (e.g. misdeal), states still coming from the backend are dropped
+*/
+func (g *game) Close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rg.Stop()
+	g.close()
+}
+
+func (g *game) IsClosed() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.isClosed
+}
+
 func (g *game) Next() (*pokerlib.GameState, error) {
 	gs, err := g.backend.Next(g.gs)
 	if err != nil {
@@ -133,6 +218,34 @@ func (g *game) Next() (*pokerlib.GameState, error) {
 	return g.GetGameState(), nil
 }
 
+/*
+Step applies a single player action and returns the resulting game state
+  - Use case: simulations driving a game created WithSyncMode in a tight loop
+*/
+func (g *game) Step(action PlayerAction) (*pokerlib.GameState, error) {
+	switch action.Action {
+	case Action_Ready:
+		return g.Ready(action.PlayerIdx)
+	case Action_Pay:
+		return g.Pay(action.PlayerIdx, action.Chips)
+	case Action_Pass:
+		return g.Pass(action.PlayerIdx)
+	case WagerAction_Fold:
+		return g.Fold(action.PlayerIdx)
+	case WagerAction_Check:
+		return g.Check(action.PlayerIdx)
+	case WagerAction_Call:
+		return g.Call(action.PlayerIdx)
+	case WagerAction_AllIn:
+		return g.Allin(action.PlayerIdx)
+	case WagerAction_Bet:
+		return g.Bet(action.PlayerIdx, action.Chips)
+	case WagerAction_Raise:
+		return g.Raise(action.PlayerIdx, action.Chips)
+	}
+	return g.GetGameState(), ErrGameInvalidAction
+}
+
 func (g *game) ReadyForAll() (*pokerlib.GameState, error) {
 	gs, err := g.backend.ReadyForAll(g.gs)
 	if err != nil {
@@ -201,7 +314,7 @@ func (g *game) Pay(playerIdx int, chips int64) (*pokerlib.GameState, error) {
 }
 
 func (g *game) Pass(playerIdx int) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, Action_Pass); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -215,7 +328,7 @@ func (g *game) Pass(playerIdx int) (*pokerlib.GameState, error) {
 }
 
 func (g *game) Fold(playerIdx int) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, WagerAction_Fold); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -229,7 +342,7 @@ func (g *game) Fold(playerIdx int) (*pokerlib.GameState, error) {
 }
 
 func (g *game) Check(playerIdx int) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, WagerAction_Check); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -243,7 +356,7 @@ func (g *game) Check(playerIdx int) (*pokerlib.GameState, error) {
 }
 
 func (g *game) Call(playerIdx int) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, WagerAction_Call); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -257,7 +370,7 @@ func (g *game) Call(playerIdx int) (*pokerlib.GameState, error) {
 }
 
 func (g *game) Allin(playerIdx int) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, WagerAction_AllIn); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -266,12 +379,13 @@ func (g *game) Allin(playerIdx int) (*pokerlib.GameState, error) {
 		return g.GetGameState(), err
 	}
 
+	g.updateRaiseLocks(g.gs, playerIdx, gs)
 	g.updateGameState(gs)
 	return g.GetGameState(), nil
 }
 
 func (g *game) Bet(playerIdx int, chips int64) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, WagerAction_Bet); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -280,12 +394,13 @@ func (g *game) Bet(playerIdx int, chips int64) (*pokerlib.GameState, error) {
 		return g.GetGameState(), err
 	}
 
+	g.updateRaiseLocks(g.gs, playerIdx, gs)
 	g.updateGameState(gs)
 	return g.GetGameState(), nil
 }
 
 func (g *game) Raise(playerIdx int, chipLevel int64) (*pokerlib.GameState, error) {
-	if err := g.validatePlayMove(playerIdx); err != nil {
+	if err := g.validatePlayMove(playerIdx, WagerAction_Raise); err != nil {
 		return g.GetGameState(), err
 	}
 
@@ -294,28 +409,38 @@ func (g *game) Raise(playerIdx int, chipLevel int64) (*pokerlib.GameState, error
 		return g.GetGameState(), err
 	}
 
+	g.updateRaiseLocks(g.gs, playerIdx, gs)
 	g.updateGameState(gs)
 	return g.GetGameState(), nil
 }
 
-func (g *game) validatePlayMove(playerIdx int) error {
+/*
+validatePlayMove checks the player may take the action now
+  - Returns ErrNotPlayersTurn when another player is to act
+  - Returns ErrActionNotAllowed when the action isn't in the player's AllowedActions
+*/
+func (g *game) validatePlayMove(playerIdx int, action PlayerActionType) error {
 	if p := g.gs.GetPlayer(playerIdx); p == nil {
 		return ErrGamePlayerNotFound
 	}
 
 	if g.gs.Status.CurrentPlayer != playerIdx {
-		return ErrGameInvalidAction
+		return ErrNotPlayersTurn
+	}
+
+	if !g.gs.HasAction(playerIdx, string(action)) {
+		return ErrActionNotAllowed
 	}
 
 	return nil
 }
 
-func (g *game) validateActionMove(playerIdx int, action string) error {
+func (g *game) validateActionMove(playerIdx int, action PlayerActionType) error {
 	if p := g.gs.GetPlayer(playerIdx); p == nil {
 		return ErrGamePlayerNotFound
 	}
 
-	if !g.gs.HasAction(playerIdx, action) {
+	if !g.gs.HasAction(playerIdx, string(action)) {
 		return ErrGameInvalidAction
 	}
 
@@ -326,6 +451,17 @@ func (g *game) validateActionMove(playerIdx int, action string) error {
 	return nil
 }
 
+// autoReady readies the players who are not ready when the ready group times out (Auto Ready By Default)
+func (g *game) autoReady(rg *syncsaga.ReadyGroup) {
+	states := rg.GetParticipantStates()
+	for gamePlayerIdx, isReady := range states {
+		if !isReady {
+			g.onPlayerAutoReady(int(gamePlayerIdx))
+			rg.Ready(gamePlayerIdx)
+		}
+	}
+}
+
 func (g *game) runGameStateUpdater() {
 	go func() {
 		for state := range g.incomingStates {
@@ -347,18 +483,119 @@ func (g *game) cloneState(gs *pokerlib.GameState) *pokerlib.GameState {
 	return &state
 }
 
+// minRaiseSize returns the smallest full raise over the current wager, the previous raise size but at least MiniBet
+func minRaiseSize(gs *pokerlib.GameState) int64 {
+	minRaise := gs.Status.PreviousRaiseSize
+	if minRaise < gs.Status.MiniBet {
+		minRaise = gs.Status.MiniBet
+	}
+	return minRaise
+}
+
+/*
+isFullRaise checks whether the wager from prev to gs is a full raise
+  - Raise size must be at least the previous raise size (and MiniBet)
+  - An incomplete all-in doesn't reopen action for players who already acted
+*/
+func isFullRaise(prev, gs *pokerlib.GameState) bool {
+	raised := gs.Status.CurrentWager - prev.Status.CurrentWager
+	return raised > 0 && raised >= minRaiseSize(prev)
+}
+
+/*
+updateRaiseLocks locks the players who already acted when the wager is raised by less than a full raise
+  - A full raise reopens action for everyone
+  - Incomplete raises add up: a locked player may raise again once the current wager is a full raise above the wager the player last matched
+*/
+func (g *game) updateRaiseLocks(prev *pokerlib.GameState, playerIdx int, gs *pokerlib.GameState) {
+	if isFullRaise(prev, gs) {
+		// a full raise reopens action for everyone
+		g.raiseLockedPlayers = make(map[int]bool)
+		return
+	}
+
+	// the first incomplete raise since the last full raise
+	if len(g.raiseLockedPlayers) == 0 || g.raiseLockedRound != prev.Status.Round {
+		g.raiseLockedPlayers = make(map[int]bool)
+		g.raiseLockMinRaise = minRaiseSize(prev)
+	}
+
+	// players who already acted can only call or fold
+	g.raiseLockedRound = prev.Status.Round
+	for _, p := range prev.Players {
+		if p.Idx != playerIdx && p.Acted && !p.Fold {
+			g.raiseLockedPlayers[p.Idx] = true
+		}
+	}
+}
+
+func (g *game) applyRaiseLocks(gs *pokerlib.GameState) {
+	if gs.Status.Round != g.raiseLockedRound {
+		g.raiseLockedPlayers = make(map[int]bool)
+		return
+	}
+
+	p := gs.GetPlayer(gs.Status.CurrentPlayer)
+	if p == nil || !g.raiseLockedPlayers[p.Idx] {
+		return
+	}
+
+	// the wager of the player is the wager it last matched, the raises since then add up to a full raise
+	if gs.Status.CurrentWager-p.Wager >= g.raiseLockMinRaise {
+		return
+	}
+
+	canAllinCall := p.StackSize <= gs.Status.CurrentWager-p.Wager
+	p.AllowedActions = funk.Filter(p.AllowedActions, func(action string) bool {
+		switch PlayerActionType(action) {
+		case WagerAction_Bet, WagerAction_Raise:
+			return false
+		case WagerAction_AllIn:
+			return canAllinCall
+		}
+		return true
+	}).([]string)
+}
+
 func (g *game) updateGameState(gs *pokerlib.GameState) {
 	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	state := g.cloneState(gs)
+	g.applyRaiseLocks(gs)
+	state := gs
+	if !g.isSync {
+		// sync mode hands the state to the handlers inline, no consumer goroutine shares it
+		state = g.cloneState(gs)
+	}
 	g.gs = state
 
 	if g.isClosed {
+		g.mu.Unlock()
+		return
+	}
+
+	if g.isSync {
+		g.pendingStates = append(g.pendingStates, state)
+		g.mu.Unlock()
+		g.handlePendingStates()
 		return
 	}
 
 	g.incomingStates <- state
+	g.mu.Unlock()
+}
+
+// handlePendingStates handles queued states in order, states queued by the handlers are handled by the outermost call
+func (g *game) handlePendingStates() {
+	if g.isHandlingStates {
+		return
+	}
+
+	g.isHandlingStates = true
+	for len(g.pendingStates) > 0 {
+		state := g.pendingStates[0]
+		g.pendingStates = g.pendingStates[1:]
+		g.handleGameState(state)
+	}
+	g.isHandlingStates = false
 }
 
 func (g *game) handleGameState(gs *pokerlib.GameState) {
@@ -382,19 +619,26 @@ func (g *game) handleGameState(gs *pokerlib.GameState) {
 }
 
 func (g *game) onReadyRequested(gs *pokerlib.GameState) {
+	if g.isSync {
+		if _, err := retryOnBackendTimeout(g.ReadyForAll); err != nil {
+			g.onGameErrorUpdated(gs, err)
+		}
+		return
+	}
+
 	// Preparing ready group to wait for all player ready
 	g.rg.Stop()
 	g.rg.OnCompleted(func(rg *syncsaga.ReadyGroup) {
-		if _, err := g.ReadyForAll(); err != nil {
+		if _, err := retryOnBackendTimeout(g.ReadyForAll); err != nil {
 			g.onGameErrorUpdated(gs, err)
 			return
 		}
 
 		// reset AllowedActions
 		for _, p := range gs.Players {
-			if funk.Contains(p.AllowedActions, Action_Ready) {
+			if funk.Contains(p.AllowedActions, string(Action_Ready)) {
 				p.AllowedActions = funk.Filter(p.AllowedActions, func(action string) bool {
-					return action != Action_Ready
+					return action != string(Action_Ready)
 				}).([]string)
 			}
 		}
@@ -405,7 +649,7 @@ func (g *game) onReadyRequested(gs *pokerlib.GameState) {
 		g.rg.Add(int64(p.Idx), false)
 
 		// Allow "ready" action
-		p.AllowAction(Action_Ready)
+		p.AllowAction(string(Action_Ready))
 	}
 
 	g.rg.Start()
@@ -416,10 +660,22 @@ func (g *game) onAnteRequested(gs *pokerlib.GameState) {
 		return
 	}
 
-	// Preparing ready group to wait for ante paid from all player
+	if g.isSync {
+		gameState, err := retryOnBackendTimeout(g.PayAnte)
+		if err != nil {
+			g.onGameErrorUpdated(gs, err)
+			return
+		}
+		g.onAntesReceived(gameState)
+		return
+	}
+
+	// Preparing ready group to wait for ante paid from all player.
+	// A player whose stack is less than the ante posts all remaining chips as a partial ante,
+	// goes all-in and is only eligible for the pot level matching the chips posted.
 	g.rg.Stop()
 	g.rg.OnCompleted(func(rg *syncsaga.ReadyGroup) {
-		gameState, err := g.PayAnte()
+		gameState, err := retryOnBackendTimeout(g.PayAnte)
 		if err != nil {
 			g.onGameErrorUpdated(gs, err)
 			return
@@ -430,9 +686,9 @@ func (g *game) onAnteRequested(gs *pokerlib.GameState) {
 
 		// reset AllowedActions
 		for _, p := range gs.Players {
-			if funk.Contains(p.AllowedActions, Action_Pay) {
+			if funk.Contains(p.AllowedActions, string(Action_Pay)) {
 				p.AllowedActions = funk.Filter(p.AllowedActions, func(action string) bool {
-					return action != Action_Pay
+					return action != string(Action_Pay)
 				}).([]string)
 			}
 		}
@@ -443,17 +699,27 @@ func (g *game) onAnteRequested(gs *pokerlib.GameState) {
 		g.rg.Add(int64(p.Idx), false)
 
 		// Allow "pay" action
-		p.AllowAction(Action_Pay)
+		p.AllowAction(string(Action_Pay))
 	}
 
 	g.rg.Start()
 }
 
 func (g *game) onBlindsRequested(gs *pokerlib.GameState) {
+	if g.isSync {
+		gameState, err := retryOnBackendTimeout(g.PayBlinds)
+		if err != nil {
+			g.onGameErrorUpdated(gs, err)
+			return
+		}
+		g.onBlindsReceived(gameState)
+		return
+	}
+
 	// Preparing ready group to wait for blinds
 	g.rg.Stop()
 	g.rg.OnCompleted(func(rg *syncsaga.ReadyGroup) {
-		gameState, err := g.PayBlinds()
+		gameState, err := retryOnBackendTimeout(g.PayBlinds)
 		if err != nil {
 			g.onGameErrorUpdated(gs, err)
 			return
@@ -464,46 +730,93 @@ func (g *game) onBlindsRequested(gs *pokerlib.GameState) {
 
 		// reset AllowedActions
 		for _, p := range gs.Players {
-			if funk.Contains(p.AllowedActions, Action_Pay) {
+			if funk.Contains(p.AllowedActions, string(Action_Pay)) {
 				p.AllowedActions = funk.Filter(p.AllowedActions, func(action string) bool {
-					return action != Action_Pay
+					return action != string(Action_Pay)
 				}).([]string)
 			}
 		}
 	})
 
 	g.rg.ResetParticipants()
+	blindPlayerIdxs := make([]int, 0)
 	for _, p := range gs.Players {
 		// Allow "pay" action
-		if gs.Meta.Blind.BB > 0 && gs.HasPosition(p.Idx, Position_BB) {
-			g.rg.Add(int64(p.Idx), false)
-			p.AllowAction(Action_Pay)
-		} else if gs.Meta.Blind.SB > 0 && gs.HasPosition(p.Idx, Position_SB) {
+		if (gs.Meta.Blind.BB > 0 && gs.HasPosition(p.Idx, Position_BB)) ||
+			(gs.Meta.Blind.SB > 0 && gs.HasPosition(p.Idx, Position_SB)) ||
+			(gs.Meta.Blind.Dealer > 0 && gs.HasPosition(p.Idx, Position_Dealer)) {
 			g.rg.Add(int64(p.Idx), false)
-			p.AllowAction(Action_Pay)
-		} else if gs.Meta.Blind.Dealer > 0 && gs.HasPosition(p.Idx, Position_Dealer) {
-			g.rg.Add(int64(p.Idx), false)
-			p.AllowAction(Action_Pay)
+			p.AllowAction(string(Action_Pay))
+			blindPlayerIdxs = append(blindPlayerIdxs, p.Idx)
 		}
 	}
 
 	g.rg.Start()
+
+	// Auto post: the blind players pay as soon as they are asked to
+	if g.isAutoPostBlinds {
+		for _, playerIdx := range blindPlayerIdxs {
+			g.rg.Ready(int64(playerIdx))
+		}
+	}
 }
 
 func (g *game) onRoundClosed(gs *pokerlib.GameState) {
 	g.onGameRoundClosed(gs)
 
+	// Wait for the next round to be dealt by Next
+	if g.isManualRoundAdvance {
+		return
+	}
+
 	// Next round automatically
-	gs, err := g.backend.Next(gs)
+	prev := gs
+	gs, err := retryOnBackendTimeout(func() (*pokerlib.GameState, error) {
+		return g.backend.Next(prev)
+	})
 	if err != nil {
-		g.onGameErrorUpdated(gs, err)
+		g.onGameErrorUpdated(prev, err)
 		return
 	}
 
+	// Stop if the backend keeps returning the same state
+	if isSameTransition(prev, gs) {
+		g.noProgressCount++
+		if g.noProgressCount >= maxNoProgressTransitions {
+			g.onGameErrorUpdated(gs, ErrGameNoProgress)
+			return
+		}
+	} else {
+		g.noProgressCount = 0
+	}
+
 	g.updateGameState(gs)
 }
 
+// retryOnBackendTimeout retries an automatic transition abandoned with ErrBackendTimeout, nobody else would retry it
+func retryOnBackendTimeout(call func() (*pokerlib.GameState, error)) (*pokerlib.GameState, error) {
+	gs, err := call()
+	for i := 0; i < maxBackendTimeoutRetries && errors.Is(err, ErrBackendTimeout); i++ {
+		gs, err = call()
+	}
+	return gs, err
+}
+
+func isSameTransition(prev, gs *pokerlib.GameState) bool {
+	return prev.GameID == gs.GameID &&
+		prev.Status.Round == gs.Status.Round &&
+		prev.Status.CurrentEvent == gs.Status.CurrentEvent
+}
+
 func (g *game) onGameClosed(gs *pokerlib.GameState) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.close()
+}
+
+// close stops accepting game states, the caller must hold g.mu
+func (g *game) close() {
 	if g.isClosed {
 		return
 	}
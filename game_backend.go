@@ -1,6 +1,17 @@
 package pokertable
 
-import "github.com/d-protocol/pokerlib"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+)
+
+var (
+	ErrBackendTimeout = errors.New("game backend: call timed out")
+)
 
 type GameBackend interface {
 	CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error)
@@ -16,4 +27,140 @@ type GameBackend interface {
 	Bet(gs *pokerlib.GameState, chips int64) (*pokerlib.GameState, error)
 	Raise(gs *pokerlib.GameState, chipLevel int64) (*pokerlib.GameState, error)
 	Pass(gs *pokerlib.GameState) (*pokerlib.GameState, error)
+	GetSeed(gameID string) (string, error)
+	ComputeEquity(gs *pokerlib.GameState) (map[int]float64, error) // key: game player index, value: share of the pot won over every runout
+	DealForButton(playerIDs []string) (map[string]string, error)   // key: player id, value: the card dealt to draw for the button
+}
+
+// seedReleaser is implemented by backends keeping the seeds of their games (e.g. NativeGameBackend), see WithHandRetention
+type seedReleaser interface {
+	ReleaseSeed(gameID string)
+}
+
+/*
+ContextGameBackend is implemented by backends able to cancel their calls (e.g. remote backends), see BackendCallTimeout
+  - WithContext returns the backend making its calls with ctx, ctx is done once the call is abandoned
+*/
+type ContextGameBackend interface {
+	GameBackend
+	WithContext(ctx context.Context) GameBackend
+}
+
+/*
+timeoutGameBackend abandons backend calls taking longer than the timeout with ErrBackendTimeout
+  - Use case: Remote backends that may hang, the engine fails the call instead of waiting forever
+  - A ContextGameBackend is called with a context cancelled on timeout, the call is expected to return right away
+  - Other backends keep running the abandoned call in the background until it returns, its result is dropped
+  - Player actions fail with ErrBackendTimeout & may be retried, automatic transitions are retried by the game & void the hand if they keep timing out
+*/
+type timeoutGameBackend struct {
+	backend GameBackend
+	timeout time.Duration
+}
+
+// withBackendCallTimeout wraps the backend with the call timeout, the backend is returned as is if the timeout is not positive
+func withBackendCallTimeout(gb GameBackend, timeout time.Duration) GameBackend {
+	if gb == nil || timeout <= 0 {
+		return gb
+	}
+	return &timeoutGameBackend{backend: gb, timeout: timeout}
+}
+
+// callWithTimeout runs the backend call & waits for it until the timeout, the context of a ContextGameBackend is cancelled on return
+func callWithTimeout[T any](b *timeoutGameBackend, name string, call func(gb GameBackend) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	gb := b.backend
+	if cb, ok := gb.(ContextGameBackend); ok {
+		gb = cb.WithContext(ctx)
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	resultCh := make(chan result, 1) // the abandoned call never blocks
+	go func() {
+		value, err := call(gb)
+		resultCh <- result{value: value, err: err}
+	}()
+
+	select {
+	case r := <-resultCh:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, fmt.Errorf("%w: %s exceeded %s", ErrBackendTimeout, name, b.timeout)
+	}
+}
+
+func (b *timeoutGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "CreateGame", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.CreateGame(opts) })
+}
+
+func (b *timeoutGameBackend) ReadyForAll(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "ReadyForAll", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.ReadyForAll(gs) })
+}
+
+func (b *timeoutGameBackend) PayAnte(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "PayAnte", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.PayAnte(gs) })
+}
+
+func (b *timeoutGameBackend) PayBlinds(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "PayBlinds", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.PayBlinds(gs) })
+}
+
+func (b *timeoutGameBackend) Next(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Next", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Next(gs) })
+}
+
+func (b *timeoutGameBackend) Pay(gs *pokerlib.GameState, chips int64) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Pay", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Pay(gs, chips) })
+}
+
+func (b *timeoutGameBackend) Fold(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Fold", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Fold(gs) })
+}
+
+func (b *timeoutGameBackend) Check(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Check", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Check(gs) })
+}
+
+func (b *timeoutGameBackend) Call(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Call", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Call(gs) })
+}
+
+func (b *timeoutGameBackend) Allin(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Allin", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Allin(gs) })
+}
+
+func (b *timeoutGameBackend) Bet(gs *pokerlib.GameState, chips int64) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Bet", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Bet(gs, chips) })
+}
+
+func (b *timeoutGameBackend) Raise(gs *pokerlib.GameState, chipLevel int64) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Raise", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Raise(gs, chipLevel) })
+}
+
+func (b *timeoutGameBackend) Pass(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	return callWithTimeout(b, "Pass", func(gb GameBackend) (*pokerlib.GameState, error) { return gb.Pass(gs) })
+}
+
+func (b *timeoutGameBackend) GetSeed(gameID string) (string, error) {
+	return callWithTimeout(b, "GetSeed", func(gb GameBackend) (string, error) { return gb.GetSeed(gameID) })
+}
+
+func (b *timeoutGameBackend) ReleaseSeed(gameID string) {
+	if releaser, ok := b.backend.(seedReleaser); ok {
+		releaser.ReleaseSeed(gameID)
+	}
+}
+
+func (b *timeoutGameBackend) ComputeEquity(gs *pokerlib.GameState) (map[int]float64, error) {
+	return callWithTimeout(b, "ComputeEquity", func(gb GameBackend) (map[int]float64, error) { return gb.ComputeEquity(gs) })
+}
+
+func (b *timeoutGameBackend) DealForButton(playerIDs []string) (map[string]string, error) {
+	return callWithTimeout(b, "DealForButton", func(gb GameBackend) (map[string]string, error) { return gb.DealForButton(playerIDs) })
 }
@@ -113,6 +113,96 @@ func NewPlayerGameStatistics() TablePlayerGameStatistics {
 	}
 }
 
+// StatCounter counts the hands with a chance for a stat and the hands the stat happened
+type StatCounter struct {
+	Chance int `json:"chance"`
+	Count  int `json:"count"`
+}
+
+func (sc *StatCounter) add(isChance, is bool) {
+	if !isChance {
+		return
+	}
+
+	sc.Chance++
+	if is {
+		sc.Count++
+	}
+}
+
+// Percentage returns Count / Chance in percent, 0 if there is no chance
+func (sc StatCounter) Percentage() float64 {
+	if sc.Chance == 0 {
+		return 0
+	}
+	return float64(sc.Count) * 100 / float64(sc.Chance)
+}
+
+// PlayerGameStatisticsAggregate accumulates the per-hand statistics of a player over multiple hands
+type PlayerGameStatisticsAggregate struct {
+	Hands           int         `json:"hands"`
+	VPIP            StatCounter `json:"vpip"`
+	PFR             StatCounter `json:"pfr"`
+	ATS             StatCounter `json:"ats"`
+	ThreeBet        StatCounter `json:"three_bet"`
+	Ft3B            StatCounter `json:"ft3b"`
+	CheckRaise      StatCounter `json:"check_raise"`
+	CBet            StatCounter `json:"c_bet"`
+	FtCB            StatCounter `json:"ftcb"`
+	ShowdownWinning StatCounter `json:"showdown_winning"`
+}
+
+// StatSummary is the percentage view (0 ~ 100) of the accumulated statistics
+type StatSummary struct {
+	Hands           int     `json:"hands"`
+	VPIP            float64 `json:"vpip"`
+	PFR             float64 `json:"pfr"`
+	ATS             float64 `json:"ats"`
+	ThreeBet        float64 `json:"three_bet"`
+	Ft3B            float64 `json:"ft3b"`
+	CheckRaise      float64 `json:"check_raise"`
+	CBet            float64 `json:"c_bet"`
+	FtCB            float64 `json:"ftcb"`
+	ShowdownWinning float64 `json:"showdown_winning"` // W$SD
+}
+
+// AddHand accumulates the statistics of a finished hand
+func (a *PlayerGameStatisticsAggregate) AddHand(s TablePlayerGameStatistics) {
+	a.Hands++
+	a.VPIP.add(s.IsVPIPChance, s.IsVPIP)
+	a.PFR.add(s.IsPFRChance, s.IsPFR)
+	a.ATS.add(s.IsATSChance, s.IsATS)
+	a.ThreeBet.add(s.Is3BChance, s.Is3B)
+	a.Ft3B.add(s.IsFt3BChance, s.IsFt3B)
+	a.CheckRaise.add(s.IsCheckRaiseChance, s.IsCheckRaise)
+	a.CBet.add(s.IsCBetChance, s.IsCBet)
+	a.FtCB.add(s.IsFtCBChance, s.IsFtCB)
+	a.ShowdownWinning.add(s.ShowdownWinningChance, s.IsShowdownWinning)
+}
+
+// Summary computes the percentages of the accumulated statistics
+func (a PlayerGameStatisticsAggregate) Summary() StatSummary {
+	return StatSummary{
+		Hands:           a.Hands,
+		VPIP:            a.VPIP.Percentage(),
+		PFR:             a.PFR.Percentage(),
+		ATS:             a.ATS.Percentage(),
+		ThreeBet:        a.ThreeBet.Percentage(),
+		Ft3B:            a.Ft3B.Percentage(),
+		CheckRaise:      a.CheckRaise.Percentage(),
+		CBet:            a.CBet.Percentage(),
+		FtCB:            a.FtCB.Percentage(),
+		ShowdownWinning: a.ShowdownWinning.Percentage(),
+	}
+}
+
+// Summary computes the percentages of a single hand, use PlayerGameStatisticsAggregate for multiple hands
+func (s TablePlayerGameStatistics) Summary() StatSummary {
+	var a PlayerGameStatisticsAggregate
+	a.AddHand(s)
+	return a.Summary()
+}
+
 func (te *tableEngine) refreshThreeBet(playerState *TablePlayerState, playerIdx int) {
 	// 在有玩家 3-Bet 的情況下，其他玩家 Raise 會重設該玩家 3-Bet 標籤
 	hasThreeBet := false
@@ -139,14 +229,16 @@ func (te *tableEngine) refreshThreeBet(playerState *TablePlayerState, playerIdx
 	}
 }
 
+// updateCurrentPlayerGameStatistics marks the stat chances of the player to act, the caller must hold te.lock
 func (te *tableEngine) updateCurrentPlayerGameStatistics(gs *pokerlib.GameState) {
-	te.lock.Lock()
-	defer te.lock.Unlock()
+	if te.statisticsDisabled {
+		return
+	}
 
 	// check current player
 	currentGamePlayerIdx := gs.Status.CurrentPlayer
 	currentPlayerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(currentGamePlayerIdx)
-	if currentPlayerIdx == UnsetValue {
+	if IsUnset(currentPlayerIdx) {
 		fmt.Printf("[DEBUG#updateCurrentPlayerGameStatistics] can't find current player index from game player index (%d)", currentGamePlayerIdx)
 	} else {
 		currentPlayer := te.table.State.PlayerStates[currentPlayerIdx]
@@ -188,6 +280,7 @@ func (te *tableEngine) updateCurrentPlayerGameStatistics(gs *pokerlib.GameState)
 	}
 }
 
+// isVPIPChance: preflop 時輪到玩家行動，且本手尚未 VPIP
 func (te *tableEngine) isVPIPChance(gamePlayerIdx int, gs *pokerlib.GameState) bool {
 	if !te.validateGameStatisticGameState(gamePlayerIdx, gs) {
 		return false
@@ -198,11 +291,16 @@ func (te *tableEngine) isVPIPChance(gamePlayerIdx int, gs *pokerlib.GameState) b
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		fmt.Printf("[DEBUG#isVPIPChance] can't find player index from game player index (%d)", gamePlayerIdx)
 		return false
 	}
 
+	// 標準定義: 大盲未被加注時仍有 VPIP 機會，但過牌不算 VPIP (只有跟注、下注、加注、All-in 才算)
+	if !te.options.BBCheckIsVPIPChance && funk.Contains(gs.Players[gamePlayerIdx].AllowedActions, string(WagerAction_Check)) {
+		return false
+	}
+
 	if !te.table.State.PlayerStates[playerIdx].GameStatistics.IsVPIP {
 		return true
 	}
@@ -228,17 +326,17 @@ func (te *tableEngine) isPFRChance(gamePlayerIdx int, gs *pokerlib.GameState) bo
 			continue
 		}
 
-		if p.DidAction == WagerAction_AllIn {
+		if PlayerActionType(p.DidAction) == WagerAction_AllIn {
 			if gs.Status.CurrentRaiser != p.Idx {
 				allinCall++
 			}
 		}
 
-		if p.DidAction == WagerAction_Call {
+		if PlayerActionType(p.DidAction) == WagerAction_Call {
 			call++
 		}
 
-		if p.DidAction == WagerAction_Fold {
+		if PlayerActionType(p.DidAction) == WagerAction_Fold {
 			fold++
 		}
 	}
@@ -292,11 +390,11 @@ func (te *tableEngine) is3BChance(gamePlayerIdx int, gs *pokerlib.GameState) boo
 			continue
 		}
 
-		if p.DidAction == WagerAction_AllIn && gs.Status.CurrentRaiser == p.Idx {
+		if PlayerActionType(p.DidAction) == WagerAction_AllIn && gs.Status.CurrentRaiser == p.Idx {
 			allinRaiser++
 		}
 
-		if p.DidAction == WagerAction_Raise {
+		if PlayerActionType(p.DidAction) == WagerAction_Raise {
 			raiser++
 		}
 	}
@@ -320,7 +418,7 @@ func (te *tableEngine) IsFt3BChance(gamePlayerIdx int, players []*TablePlayerSta
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		fmt.Printf("[DEBUG#IsFt3BChance] can't find player index from game player index (%d)", gamePlayerIdx)
 		return false
 	}
@@ -350,13 +448,13 @@ func (te *tableEngine) isCheckRaiseChance(gamePlayerIdx int, gs *pokerlib.GameSt
 	player := gs.GetPlayer(gamePlayerIdx)
 
 	// 自己要先 check 過
-	if player.DidAction != WagerAction_Check {
+	if PlayerActionType(player.DidAction) != WagerAction_Check {
 		return false
 	}
 
 	// 自己要可以 Raise or Allin (raiser): 後手/剩餘籌碼 > MiniBet
-	canRaise := funk.Contains(player.AllowedActions, WagerAction_Raise)
-	canAllinRaiser := funk.Contains(player.AllowedActions, WagerAction_AllIn) && player.StackSize > gs.Status.MiniBet
+	canRaise := funk.Contains(player.AllowedActions, string(WagerAction_Raise))
+	canAllinRaiser := funk.Contains(player.AllowedActions, string(WagerAction_AllIn)) && player.StackSize > gs.Status.MiniBet
 	if canRaise || canAllinRaiser {
 		return true
 	}
@@ -376,9 +474,9 @@ func (te *tableEngine) isCBetChance(gamePlayerIdx int, gs *pokerlib.GameState) b
 	// 自己在 preflop 時要是 raiser 且有下列任一動作: Bet or Raise or Allin (raiser): 後手/剩餘籌碼 > MiniBet
 	player := gs.GetPlayer(gamePlayerIdx)
 	isPreflopRaiser := gs.Status.CurrentRaiser == gamePlayerIdx
-	canBet := funk.Contains(player.AllowedActions, WagerAction_Bet)
-	canRaise := funk.Contains(player.AllowedActions, WagerAction_Raise)
-	canAllinRaiser := funk.Contains(player.AllowedActions, WagerAction_AllIn) && player.StackSize > gs.Status.MiniBet
+	canBet := funk.Contains(player.AllowedActions, string(WagerAction_Bet))
+	canRaise := funk.Contains(player.AllowedActions, string(WagerAction_Raise))
+	canAllinRaiser := funk.Contains(player.AllowedActions, string(WagerAction_AllIn)) && player.StackSize > gs.Status.MiniBet
 	validAction := canBet || canRaise || canAllinRaiser
 
 	if isPreflopRaiser && validAction {
@@ -398,7 +496,7 @@ func (te *tableEngine) isFtCBChance(gamePlayerIdx int, players []*TablePlayerSta
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		fmt.Printf("[DEBUG#isFtCBChance] can't find player index from game player index (%d)", gamePlayerIdx)
 		return false
 	}
@@ -417,14 +515,14 @@ func (te *tableEngine) isFtCBChance(gamePlayerIdx int, players []*TablePlayerSta
 }
 
 func (te *tableEngine) validateGameStatisticGameState(gamePlayerIdx int, gs *pokerlib.GameState) bool {
-	validEvent := pokerlib.GameEventSymbols[pokerlib.GameEvent_Started]
+	validEvent := pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted]
 	validRounds := []string{
 		GameRound_Preflop,
 		GameRound_Flop,
 		GameRound_Turn,
 		GameRound_River,
 	}
-	validActions := []string{
+	validActions := []PlayerActionType{
 		WagerAction_Fold,
 		WagerAction_Check,
 		WagerAction_Call,
@@ -437,8 +535,9 @@ func (te *tableEngine) validateGameStatisticGameState(gamePlayerIdx int, gs *pok
 		return false
 	}
 
+	// 玩家輪到行動且尚未行動
 	player := gs.Players[gamePlayerIdx]
-	if !player.Acted {
+	if player.Acted {
 		return false
 	}
 
@@ -447,7 +546,7 @@ func (te *tableEngine) validateGameStatisticGameState(gamePlayerIdx int, gs *pok
 	}
 
 	for _, action := range player.AllowedActions {
-		if !funk.Contains(validActions, action) {
+		if !funk.Contains(validActions, PlayerActionType(action)) {
 			return false
 		}
 	}
@@ -0,0 +1,123 @@
+package pokertable
+
+import (
+	"testing"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerGameStatisticsAggregate_Summary(t *testing.T) {
+	hands := []TablePlayerGameStatistics{
+		// raised preflop, c-bet, won at showdown
+		{IsVPIPChance: true, IsVPIP: true, IsPFRChance: true, IsPFR: true, IsCBetChance: true, IsCBet: true, ShowdownWinningChance: true, IsShowdownWinning: true},
+		// called preflop, lost at showdown
+		{IsVPIPChance: true, IsVPIP: true, IsPFRChance: true, ShowdownWinningChance: true},
+		// folded preflop
+		{IsVPIPChance: true, IsPFRChance: true, IsFold: true},
+		// walk, no chance at all
+		{},
+	}
+
+	var a PlayerGameStatisticsAggregate
+	for _, hand := range hands {
+		a.AddHand(hand)
+	}
+
+	summary := a.Summary()
+	assert.Equal(t, 4, summary.Hands)
+	assert.InDelta(t, 200.0/3, summary.VPIP, 0.001)
+	assert.InDelta(t, 100.0/3, summary.PFR, 0.001)
+	assert.Equal(t, 100.0, summary.CBet)
+	assert.Equal(t, 50.0, summary.ShowdownWinning)
+
+	// no chance, no divide by zero
+	assert.Equal(t, 0.0, summary.ThreeBet)
+	assert.Equal(t, 0.0, summary.FtCB)
+	assert.Equal(t, StatCounter{Chance: 3, Count: 2}, a.VPIP)
+}
+
+func TestTablePlayerGameStatistics_Summary(t *testing.T) {
+	summary := TablePlayerGameStatistics{IsVPIPChance: true, IsVPIP: true, IsPFRChance: true}.Summary()
+	assert.Equal(t, 1, summary.Hands)
+	assert.Equal(t, 100.0, summary.VPIP)
+	assert.Equal(t, 0.0, summary.PFR)
+
+	assert.Equal(t, StatSummary{}, PlayerGameStatisticsAggregate{}.Summary())
+}
+
+/*
+newTestPreflopTableEngine starts a preflop round on a playing table engine
+  - Game players: P3 (dealer), P4 (sb), P1 (bb), P2 (utg, first to act)
+*/
+func newTestPreflopTableEngine(t testing.TB, opts ...TableEngineOpt) (*tableEngine, *pokerlib.GameState) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), opts...)
+
+	gameOpts := pokerlib.NewStardardGameOptions()
+	gameOpts.Deck = pokerlib.NewStandardDeckCards()
+	gameOpts.Blind = pokerlib.BlindSetting{SB: 10, BB: 20}
+	gamePlayerIDs := []string{"P3", "P4", "P1", "P2"}
+	gamePlayerPositions := [][]string{{Position_Dealer}, {Position_SB}, {Position_BB}, {}}
+	te.table.State.GamePlayerIndexes = make([]int, 0)
+	for gamePlayerIdx, playerID := range gamePlayerIDs {
+		playerIdx := te.table.FindPlayerIdx(playerID)
+		te.table.State.GamePlayerIndexes = append(te.table.State.GamePlayerIndexes, playerIdx)
+		gameOpts.Players = append(gameOpts.Players, &pokerlib.PlayerSetting{
+			Bankroll:  te.table.State.PlayerStates[playerIdx].Bankroll,
+			Positions: gamePlayerPositions[gamePlayerIdx],
+		})
+	}
+
+	backend := NewNativeGameBackend()
+	gs, err := backend.CreateGame(gameOpts)
+	assert.NoError(t, err)
+	for gs.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted] {
+		switch gs.Status.CurrentEvent {
+		case pokerlib.GameEventSymbols[pokerlib.GameEvent_ReadyRequested]:
+			gs, err = backend.ReadyForAll(gs)
+		case pokerlib.GameEventSymbols[pokerlib.GameEvent_BlindsRequested]:
+			gs, err = backend.PayBlinds(gs)
+		default:
+			gs, err = backend.Next(gs)
+		}
+		assert.NoError(t, err)
+	}
+
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	te.table.State.GameState = gs
+	return te, gs
+}
+
+func TestTableEngine_UpdateCurrentPlayerGameStatistics(t *testing.T) {
+	te, gs := newTestPreflopTableEngine(t)
+
+	te.updateCurrentPlayerGameStatistics(gs)
+	utg := te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")]
+	assert.True(t, utg.GameStatistics.IsVPIPChance)
+}
+
+func TestTableEngine_UpdateCurrentPlayerGameStatistics_Disabled(t *testing.T) {
+	te, gs := newTestPreflopTableEngine(t, WithStatisticsDisabled())
+
+	te.updateCurrentPlayerGameStatistics(gs)
+	utg := te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")]
+	assert.Equal(t, NewPlayerGameStatistics(), utg.GameStatistics)
+}
+
+func BenchmarkUpdateCurrentPlayerGameStatistics(b *testing.B) {
+	b.Run("Enabled", func(b *testing.B) {
+		te, gs := newTestPreflopTableEngine(b)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			te.updateCurrentPlayerGameStatistics(gs)
+		}
+	})
+
+	b.Run("Disabled", func(b *testing.B) {
+		te, gs := newTestPreflopTableEngine(b, WithStatisticsDisabled())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			te.updateCurrentPlayerGameStatistics(gs)
+		}
+	})
+}
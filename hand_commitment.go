@@ -0,0 +1,80 @@
+package pokertable
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+/*
+NewHandCommitment calculates the commit-reveal hash of a hand
+  - Hash: hex(sha256(seed + ":" + deck joined by ","))
+  - The deck is in dealing order, NativeGameBackend shuffles it with ShuffleDeck from the seed
+  - The hash is published (TableState.HandCommitment) when the hand starts, while the seed stays hidden
+  - The seed is revealed (TableState.HandSeed) with the GameSettled event
+
+Verification:
+ 1. Record TableState.HandCommitment before any card is dealt
+ 2. Wait for the GameSettled event and take TableState.HandSeed & TableState.GameState.Meta.Deck
+ 3. Recalculate NewHandCommitment(seed, deck) and compare it with the recorded commitment
+ 4. Shuffle the unshuffled deck of the rule (pokerlib.NewStandardDeckCards or pokerlib.NewShortDeckCards) with ShuffleDeck(seed, cards) and compare it with the deck
+*/
+func NewHandCommitment(seed string, deck []string) string {
+	sum := sha256.Sum256([]byte(seed + ":" + strings.Join(deck, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyHandCommitment returns true if the revealed seed & deck match the published commitment
+func VerifyHandCommitment(commitment, seed string, deck []string) bool {
+	return NewHandCommitment(seed, deck) == commitment
+}
+
+/*
+ShuffleDeck shuffles the cards with the seed, the same seed & cards always give the same deck
+  - Fisher-Yates from the last card: card i swaps with card r mod (i + 1)
+  - r: the first 8 bytes (big endian) of sha256(seed + ":" + i + ":" + draw), draw counts from 0
+  - r at or above the largest multiple of (i + 1) is drawn again with the next draw, so every swap is unbiased
+*/
+func ShuffleDeck(seed string, cards []string) []string {
+	deck := append([]string{}, cards...)
+	for i := len(deck) - 1; i > 0; i-- {
+		n := uint64(i + 1)
+		limit := math.MaxUint64 - math.MaxUint64%n
+		for draw := 0; ; draw++ {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%d", seed, i, draw)))
+			if r := binary.BigEndian.Uint64(sum[:8]); r < limit {
+				j := r % n
+				deck[i], deck[j] = deck[j], deck[i]
+				break
+			}
+		}
+	}
+	return deck
+}
+
+/*
+CanonicalJSON serializes the table into byte-stable JSON for hashing & signing
+  - Object keys are sorted at every level, including nested pokerlib structures
+  - No insignificant whitespace, numbers are kept as encoded (no float conversion)
+*/
+func CanonicalJSON(t *Table) ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// re-encode as generic values, encoding/json writes map keys in sorted order
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
@@ -0,0 +1,44 @@
+package pokertable
+
+import (
+	"testing"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffleDeck(t *testing.T) {
+	cards := pokerlib.NewStandardDeckCards()
+	deck := ShuffleDeck("seed", cards)
+
+	// the same seed always gives the same deck, a permutation of the cards
+	assert.Equal(t, deck, ShuffleDeck("seed", cards))
+	assert.ElementsMatch(t, cards, deck)
+	assert.NotEqual(t, cards, deck)
+	assert.NotEqual(t, deck, ShuffleDeck("other seed", cards))
+	assert.Equal(t, pokerlib.NewStandardDeckCards(), cards)
+}
+
+func TestNativeGameBackend_DeckFromSeed(t *testing.T) {
+	backend := NewNativeGameBackend()
+	opts := pokerlib.NewStardardGameOptions()
+	opts.Deck = pokerlib.NewStandardDeckCards()
+	opts.Players = []*pokerlib.PlayerSetting{
+		{Bankroll: 1000, Positions: []string{Position_Dealer}},
+		{Bankroll: 1000, Positions: []string{Position_SB}},
+		{Bankroll: 1000, Positions: []string{Position_BB}},
+	}
+
+	gs, err := backend.CreateGame(opts)
+	assert.NoError(t, err)
+	seed, err := backend.GetSeed(gs.GameID)
+	assert.NoError(t, err)
+
+	// verification procedure of NewHandCommitment
+	commitment := NewHandCommitment(seed, gs.Meta.Deck)
+	assert.True(t, VerifyHandCommitment(commitment, seed, gs.Meta.Deck))
+	assert.Equal(t, ShuffleDeck(seed, pokerlib.NewStandardDeckCards()), gs.Meta.Deck)
+
+	// the options of the caller keep the unshuffled deck
+	assert.Equal(t, pokerlib.NewStandardDeckCards(), opts.Deck)
+}
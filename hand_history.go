@@ -0,0 +1,265 @@
+package pokertable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/d-protocol/pokerlib"
+)
+
+var (
+	ErrHandHistoryInvalid        = errors.New("hand history: invalid hand history")
+	ErrHandHistoryActionMismatch = errors.New("hand history: recorded action can't be replayed")
+)
+
+// maxReplayPasses is the number of passes in a row after which a replay is considered stalled
+const maxReplayPasses = 100
+
+/*
+HandHistory records a dealt hand, enough to replay it without the backend it was played with
+  - Exported by ExportHandHistory, replayed by LoadHandHistory
+*/
+type HandHistory struct {
+	Table     *Table                  `json:"table"`               // Table when the hand was dealt
+	Options   *pokerlib.GameOptions   `json:"options"`             // Game options the hand was dealt with, the deck is in dealing order
+	Actions   []TablePlayerGameAction `json:"actions"`             // Player actions in the order they were taken
+	Bankrolls map[string]int64        `json:"bankrolls,omitempty"` // key: player id, settled bankrolls of the game players (dead blinds, rounding, insurance & settlement hook applied)
+}
+
+// newHandHistory starts the history of a hand dealt with the given options
+func newHandHistory(table *Table, opts *pokerlib.GameOptions) (*HandHistory, error) {
+	cloneTable, err := table.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+	cloneOpts := &pokerlib.GameOptions{}
+	if err := json.Unmarshal(optsJSON, cloneOpts); err != nil {
+		return nil, err
+	}
+
+	return &HandHistory{
+		Table:   cloneTable,
+		Options: cloneOpts,
+		Actions: make([]TablePlayerGameAction, 0),
+	}, nil
+}
+
+// replayGameBackend deals the recorded deck instead of a shuffled one
+type replayGameBackend struct {
+	*NativeGameBackend
+	deck []string
+}
+
+func (b *replayGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
+	return b.createGame(opts, append([]string{}, b.deck...))
+}
+
+/*
+ReplaySession steps through a recorded hand, see LoadHandHistory
+  - Use case: Support teams reproducing disputes visually
+*/
+type ReplaySession struct {
+	history   *HandHistory
+	table     *Table
+	game      Game
+	actionIdx int
+	isDone    bool
+	err       error
+}
+
+/*
+LoadHandHistory parses an exported hand history into a replay session
+  - The hand is played again on NativeGameBackend with the recorded deck, no live backend is needed
+*/
+func LoadHandHistory(hh string) (*ReplaySession, error) {
+	history := &HandHistory{}
+	if err := json.Unmarshal([]byte(hh), history); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHandHistoryInvalid, err)
+	}
+
+	if history.Table == nil || history.Table.State == nil || history.Options == nil {
+		return nil, fmt.Errorf("%w: table or game options not found", ErrHandHistoryInvalid)
+	}
+
+	if len(history.Options.Players) != len(history.Table.State.GamePlayerIndexes) {
+		return nil, fmt.Errorf("%w: %d game players, %d players dealt", ErrHandHistoryInvalid, len(history.Table.State.GamePlayerIndexes), len(history.Options.Players))
+	}
+
+	for _, playerIdx := range history.Table.State.GamePlayerIndexes {
+		if playerIdx < 0 || playerIdx >= len(history.Table.State.PlayerStates) {
+			return nil, fmt.Errorf("%w: player %d not found", ErrHandHistoryInvalid, playerIdx)
+		}
+	}
+
+	return &ReplaySession{
+		history: history,
+	}, nil
+}
+
+/*
+NextStep replays the next step & returns the table after it
+  - Steps: the deal, every recorded wager action, then the settlement
+  - ok is false once every step is replayed or the replay failed (see Err), the table is the last replayed state then
+*/
+func (rs *ReplaySession) NextStep() (*Table, bool) {
+	if rs.isDone {
+		return rs.table, false
+	}
+
+	var err error
+	switch {
+	case rs.game == nil:
+		err = rs.deal()
+	case rs.nextWagerAction() != nil:
+		err = rs.act()
+	default:
+		err = rs.settle()
+		rs.isDone = true
+	}
+
+	if err != nil {
+		rs.err = err
+		rs.isDone = true
+		return rs.table, false
+	}
+	return rs.table, true
+}
+
+// Err returns the error the replay stopped with, nil if the hand was replayed completely
+func (rs *ReplaySession) Err() error {
+	return rs.err
+}
+
+func (rs *ReplaySession) deal() error {
+	opts := *rs.history.Options
+	backend := &replayGameBackend{
+		NativeGameBackend: NewNativeGameBackend(),
+		deck:              rs.history.Options.Deck,
+	}
+
+	rs.game = NewGame(backend, &opts, WithSyncMode())
+	if _, err := rs.game.Start(); err != nil {
+		return err
+	}
+
+	if err := rs.passAll(); err != nil {
+		return err
+	}
+	return rs.snapshot(TableStateStatus_TableGamePlaying, nil)
+}
+
+func (rs *ReplaySession) act() error {
+	action := rs.nextWagerAction()
+	rs.actionIdx++
+
+	if err := rs.passAll(); err != nil {
+		return err
+	}
+
+	gamePlayerIdx := rs.history.Table.FindGamePlayerIdx(action.PlayerID)
+	if IsUnset(gamePlayerIdx) || rs.game.IsClosed() || rs.game.GetGameState().Status.CurrentPlayer != gamePlayerIdx {
+		return fmt.Errorf("%w: player (%s) is not to act", ErrHandHistoryActionMismatch, action.PlayerID)
+	}
+
+	if _, err := rs.game.Step(PlayerAction{PlayerIdx: gamePlayerIdx, Action: PlayerActionType(action.Action), Chips: action.Chips}); err != nil {
+		return fmt.Errorf("%w: player (%s) %s %d: %v", ErrHandHistoryActionMismatch, action.PlayerID, action.Action, action.Chips, err)
+	}
+
+	if err := rs.passAll(); err != nil {
+		return err
+	}
+	return rs.snapshot(TableStateStatus_TableGamePlaying, action)
+}
+
+func (rs *ReplaySession) settle() error {
+	if err := rs.passAll(); err != nil {
+		return err
+	}
+
+	gs := rs.game.GetGameState()
+	if !rs.game.IsClosed() || gs.Result == nil {
+		return fmt.Errorf("%w: hand is not closed after the last recorded action", ErrHandHistoryActionMismatch)
+	}
+
+	if err := rs.snapshot(TableStateStatus_TableGameSettled, nil); err != nil {
+		return err
+	}
+	for _, result := range gs.Result.Players {
+		playerState := rs.table.State.PlayerStates[rs.table.State.GamePlayerIndexes[result.Idx]]
+		playerState.Bankroll = result.Final
+		if bankroll, exist := rs.history.Bankrolls[playerState.PlayerID]; exist {
+			// the table settles more than the game result
+			playerState.Bankroll = bankroll
+		}
+		playerState.updateSessionNet()
+	}
+	return nil
+}
+
+// nextWagerAction returns the next recorded action the players decided, nil if there is none
+func (rs *ReplaySession) nextWagerAction() *TablePlayerGameAction {
+	for ; rs.actionIdx < len(rs.history.Actions); rs.actionIdx++ {
+		action := rs.history.Actions[rs.actionIdx]
+		if action.IsForced {
+			// antes & blinds are posted by the sync mode game, a short stack posts all-in
+			continue
+		}
+
+		switch PlayerActionType(action.Action) {
+		case Action_Ready, Action_Pay, Action_Pass:
+			// completed by the sync mode game
+		default:
+			return &action
+		}
+	}
+	return nil
+}
+
+// passAll passes for every player who has nothing to decide (e.g. all-in players when the round is started)
+func (rs *ReplaySession) passAll() error {
+	for passes := 0; !rs.game.IsClosed(); passes++ {
+		gs := rs.game.GetGameState()
+		gamePlayerIdx := gs.Status.CurrentPlayer
+		if !gs.HasAction(gamePlayerIdx, string(Action_Pass)) {
+			return nil
+		}
+
+		if passes >= maxReplayPasses {
+			return fmt.Errorf("%w: hand is stalled", ErrHandHistoryActionMismatch)
+		}
+
+		if _, err := rs.game.Pass(gamePlayerIdx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshot updates the replayed table with the current game state
+func (rs *ReplaySession) snapshot(status TableStateStatus, action *TablePlayerGameAction) error {
+	table, err := rs.history.Table.Clone()
+	if err != nil {
+		return err
+	}
+
+	gsJSON, err := json.Marshal(rs.game.GetGameState())
+	if err != nil {
+		return err
+	}
+	gs := &pokerlib.GameState{}
+	if err := json.Unmarshal(gsJSON, gs); err != nil {
+		return err
+	}
+
+	table.State.Status = status
+	table.State.GameState = gs
+	table.State.LastPlayerGameAction = action
+	rs.table = table
+	return nil
+}
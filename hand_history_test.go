@@ -0,0 +1,122 @@
+package pokertable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/settlement"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadHandHistory_RoundTrip(t *testing.T) {
+	// the table settles more than the game result, the replay keeps the settled bankrolls
+	bounty := func(result *settlement.Result, table *Table) error {
+		table.State.PlayerStates[table.FindPlayerIdx("P1")].Bankroll += 100
+		return nil
+	}
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithSettlementHook(bounty))
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+	gameCount := te.table.State.GameCount
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// first actor raises, everybody calls & checks down to the showdown
+	isRaised := false
+	checkDown := checkDownTestWager(te)
+	driveTestHand(t, te, func(playerID string, gs *pokerlib.GameState) {
+		if !isRaised && gs.HasAction(gs.Status.CurrentPlayer, string(WagerAction_Raise)) && te.PlayerRaise(playerID, 60) == nil {
+			isRaised = true
+			return
+		}
+		checkDown(playerID, gs)
+	}, nil)
+	assert.True(t, isRaised)
+
+	_, err := te.ExportHandHistory(gameCount + 1)
+	assert.ErrorIs(t, err, ErrTableHandHistoryNotFound)
+	hh, err := te.ExportHandHistory(gameCount)
+	assert.NoError(t, err)
+
+	rs, err := LoadHandHistory(hh)
+	assert.NoError(t, err)
+
+	// deal, the raise, 3 preflop calls, 4 checks on every street after, settlement
+	steps := 0
+	var final *Table
+	for {
+		table, ok := rs.NextStep()
+		if !ok {
+			break
+		}
+		steps++
+		final = table
+	}
+	assert.NoError(t, rs.Err())
+	assert.Equal(t, 1+1+3+4*3+1, steps)
+	assert.EqualValues(t, TableStateStatus_TableGameSettled, final.State.Status)
+
+	for _, playerIdx := range final.State.GamePlayerIndexes {
+		replayed := final.State.PlayerStates[playerIdx]
+		played := te.table.State.PlayerStates[te.table.FindPlayerIdx(replayed.PlayerID)]
+		assert.Equal(t, played.Bankroll, replayed.Bankroll, replayed.PlayerID)
+	}
+
+	_, err = LoadHandHistory("{}")
+	assert.ErrorIs(t, err, ErrHandHistoryInvalid)
+}
+
+func TestLoadHandHistory_ShortStackedBlind(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll = 15 // can't cover the BB
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+	gameCount := te.table.State.GameCount
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// the BB posts all-in, everybody folds to it
+	driveTestHand(t, te, foldToBBTestWager(te), nil)
+
+	hh, err := te.ExportHandHistory(gameCount)
+	assert.NoError(t, err)
+	history := &HandHistory{}
+	assert.NoError(t, json.Unmarshal([]byte(hh), history))
+	isPostedAllIn := false
+	for _, action := range history.Actions {
+		if action.PlayerID == "P1" && action.Action == string(WagerAction_AllIn) {
+			isPostedAllIn = action.IsForced
+		}
+	}
+	assert.True(t, isPostedAllIn)
+
+	rs, err := LoadHandHistory(hh)
+	assert.NoError(t, err)
+
+	// deal, 3 folds, settlement
+	steps := 0
+	var final *Table
+	for {
+		table, ok := rs.NextStep()
+		if !ok {
+			break
+		}
+		steps++
+		final = table
+	}
+	assert.NoError(t, rs.Err())
+	assert.Equal(t, 1+3+1, steps)
+	assert.EqualValues(t, TableStateStatus_TableGameSettled, final.State.Status)
+
+	for _, playerIdx := range final.State.GamePlayerIndexes {
+		replayed := final.State.PlayerStates[playerIdx]
+		played := te.table.State.PlayerStates[te.table.FindPlayerIdx(replayed.PlayerID)]
+		assert.Equal(t, played.Bankroll, replayed.Bankroll, replayed.PlayerID)
+	}
+}
@@ -0,0 +1,66 @@
+package pokertable
+
+import "github.com/d-protocol/pokerlib"
+
+// insuranceOffer is an all-in insurance offer made to a player, see OnInsuranceOffer
+type insuranceOffer struct {
+	gameID     string
+	round      string
+	boardCount int // board cards dealt when the offer was made, the offer expires with the next card
+	equity     float64
+	maxPayout  int64
+}
+
+/*
+coverage returns the chips paid to the player for the premium if the player doesn't win the pot outright
+  - The premium is priced at the player's chance of not winning: coverage = amount / (1 - equity)
+  - Capped at the offered max payout
+*/
+func (o *insuranceOffer) coverage(amount int64) int64 {
+	coverage := int64(float64(amount) / (1 - o.equity))
+	if coverage > o.maxPayout {
+		return o.maxPayout
+	}
+	return coverage
+}
+
+// maxAmount returns the premium covering the max payout
+func (o *insuranceOffer) maxAmount() int64 {
+	return int64(float64(o.maxPayout) * (1 - o.equity))
+}
+
+/*
+isAllinRunout returns true when no more betting is possible and cards are still to come
+  - At least two players in the hand, at most one of them has chips behind
+  - Insurance is offered once the flop is dealt
+*/
+func isAllinRunout(gs *pokerlib.GameState) bool {
+	if len(gs.Status.Board) < 3 || len(gs.Status.Board) >= 5 {
+		return false
+	}
+
+	inHandCount := 0
+	withChipsCount := 0
+	for _, p := range gs.Players {
+		if p.Fold {
+			continue
+		}
+
+		inHandCount++
+		if p.StackSize > 0 {
+			withChipsCount++
+		}
+	}
+	return inHandCount >= 2 && withChipsCount <= 1
+}
+
+// potsWinnable returns the chips of every pot the player contributes to
+func potsWinnable(gs *pokerlib.GameState, gamePlayerIdx int) int64 {
+	total := int64(0)
+	for _, pot := range gs.Status.Pots {
+		if pot.ContributorExists(gamePlayerIdx) {
+			total += pot.Total
+		}
+	}
+	return total
+}
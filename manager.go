@@ -3,23 +3,44 @@ package pokertable
 import (
 	"errors"
 	"sync"
+	"time"
 )
 
 var (
 	ErrManagerTableNotFound = errors.New("manager: table not found")
+	ErrManagerAtCapacity    = errors.New("manager: at capacity")
 )
 
+const (
+	DefaultClosedTableGracePeriod = 30 * time.Second
+)
+
+type ManagerOpt func(*manager)
+
 type Manager interface {
+	// Events (aggregated from all managed tables)
+	OnAnyTableUpdated(fn func(tableID string, table *Table))
+	OnAnyTableErrorUpdated(fn func(tableID string, table *Table, err error))
+	OnAnyTableStateUpdated(fn func(tableID string, event string, table *Table))
+	OnAnyGamePlayerActionUpdated(fn func(tableID string, gameAction TablePlayerGameAction))
+
 	// Other Actions
 	Reset()
 	ReleaseTable(tableID string) error
+	ActiveTableCount() int
 
 	// Table Actions
 	GetTableEngine(tableID string) (TableEngine, error)
+	GetTables() []*Table
 	CreateTable(options *TableEngineOptions, callbacks *TableEngineCallbacks, setting TableSetting) (*Table, error)
-	PauseTable(tableID string) error
+	PauseTable(tableID string) (bool, error)
 	CloseTable(tableID string) error
+	ScheduleHardStop(tableID string, at time.Time) error
+	SwapGameBackend(tableID string, gb GameBackend) error
+	AdvanceRound(tableID string) error
+	ApplyDeal(tableID string, payouts map[string]int64) error
 	StartTableGame(tableID string) error
+	StartTableGameIfNotStarted(tableID string) error
 	SetUpTableGame(tableID string, gameCount int, participants map[string]int) error
 	UpdateBlind(tableID string, level int, ante, dealer, sb, bb int64) error
 	UpdateTablePlayers(tableID string, joinPlayers []JoinPlayer, leavePlayerIDs []string) (map[string]int, error)
@@ -29,10 +50,16 @@ type Manager interface {
 	PlayerJoin(tableID, playerID string) error
 	PlayerSettlementFinish(tableID, playerID string) error
 	PlayerRedeemChips(tableID string, joinPlayer JoinPlayer) error
+	PlayerAddOn(tableID string, joinPlayer JoinPlayer) error
+	PlayerReEntry(tableID string, joinPlayer JoinPlayer) error
 	PlayersLeave(tableID string, playerIDs []string) error
+	PlayerChangeSeat(tableID, playerID string, targetSeat int) error
+	PlayerSetAutoRebuy(tableID, playerID string, enabled bool) error
+	PlayerPostMissedBlind(tableID, playerID string) error
 
 	// Player Game Actions
 	PlayerExtendActionDeadline(tableID, playerID string, duration int) (int64, error)
+	PlayerActionTimeout(tableID, playerID string) error
 	PlayerReady(tableID, playerID string) error
 	PlayerPay(tableID, playerID string, chips int64) error
 	PlayerBet(tableID, playerID string, chips int64) error
@@ -42,20 +69,88 @@ type Manager interface {
 	PlayerCheck(tableID, playerID string) error
 	PlayerFold(tableID, playerID string) error
 	PlayerPass(tableID, playerID string) error
+	PlayerBuyInsurance(tableID, playerID string, amount int64) error
 }
 
 type manager struct {
-	tableEngines sync.Map
+	tableEngines           sync.Map
+	maxTables              int
+	closedTableGracePeriod time.Duration
+	mu                     sync.Mutex
+	closedAt               map[string]time.Time
+	isReaping              bool
+	reEntries              *ReEntryLedger // shared by all tables, re-entries count across tables
+
+	hooksMu                      sync.RWMutex
+	onAnyTableUpdated            func(tableID string, table *Table)
+	onAnyTableErrorUpdated       func(tableID string, table *Table, err error)
+	onAnyTableStateUpdated       func(tableID string, event string, table *Table)
+	onAnyGamePlayerActionUpdated func(tableID string, gameAction TablePlayerGameAction)
+}
+
+func NewManager(opts ...ManagerOpt) Manager {
+	m := &manager{
+		tableEngines:                 sync.Map{},
+		closedTableGracePeriod:       DefaultClosedTableGracePeriod,
+		closedAt:                     make(map[string]time.Time),
+		reEntries:                    NewReEntryLedger(),
+		onAnyTableUpdated:            func(tableID string, table *Table) {},
+		onAnyTableErrorUpdated:       func(tableID string, table *Table, err error) {},
+		onAnyTableStateUpdated:       func(tableID string, event string, table *Table) {},
+		onAnyGamePlayerActionUpdated: func(tableID string, gameAction TablePlayerGameAction) {},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// WithMaxTables limits the number of active tables (0 means unlimited)
+func WithMaxTables(n int) ManagerOpt {
+	return func(m *manager) {
+		m.maxTables = n
+	}
 }
 
-func NewManager() Manager {
-	return &manager{
-		tableEngines: sync.Map{},
+// WithClosedTableGracePeriod sets how long a closed table is kept before it is evicted (0 disables eviction)
+func WithClosedTableGracePeriod(d time.Duration) ManagerOpt {
+	return func(m *manager) {
+		m.closedTableGracePeriod = d
 	}
 }
 
+func (m *manager) OnAnyTableUpdated(fn func(tableID string, table *Table)) {
+	m.hooksMu.Lock()
+	defer m.hooksMu.Unlock()
+	m.onAnyTableUpdated = fn
+}
+
+func (m *manager) OnAnyTableErrorUpdated(fn func(tableID string, table *Table, err error)) {
+	m.hooksMu.Lock()
+	defer m.hooksMu.Unlock()
+	m.onAnyTableErrorUpdated = fn
+}
+
+func (m *manager) OnAnyTableStateUpdated(fn func(tableID string, event string, table *Table)) {
+	m.hooksMu.Lock()
+	defer m.hooksMu.Unlock()
+	m.onAnyTableStateUpdated = fn
+}
+
+func (m *manager) OnAnyGamePlayerActionUpdated(fn func(tableID string, gameAction TablePlayerGameAction)) {
+	m.hooksMu.Lock()
+	defer m.hooksMu.Unlock()
+	m.onAnyGamePlayerActionUpdated = fn
+}
+
 func (m *manager) Reset() {
-	m.tableEngines = sync.Map{}
+	m.tableEngines.Range(func(key, value interface{}) bool {
+		value.(TableEngine).ReleaseTable()
+		m.tableEngines.Delete(key)
+		return true
+	})
 }
 
 func (m *manager) ReleaseTable(tableID string) error {
@@ -72,6 +167,18 @@ func (m *manager) ReleaseTable(tableID string) error {
 	return nil
 }
 
+// ActiveTableCount returns the number of managed tables that are not closed
+func (m *manager) ActiveTableCount() int {
+	count := 0
+	m.tableEngines.Range(func(key, value interface{}) bool {
+		if table, err := value.(TableEngine).GetTableSnapshot(); err == nil && table.State.Status != TableStateStatus_TableClosed {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
 func (m *manager) GetTableEngine(tableID string) (TableEngine, error) {
 	tableEngine, exist := m.tableEngines.Load(tableID)
 	if !exist {
@@ -80,6 +187,18 @@ func (m *manager) GetTableEngine(tableID string) (TableEngine, error) {
 	return tableEngine.(TableEngine), nil
 }
 
+// GetTables returns a copy of every managed table as of its latest event, see TableEngine.GetTableSnapshot
+func (m *manager) GetTables() []*Table {
+	tables := make([]*Table, 0)
+	m.tableEngines.Range(func(key, value interface{}) bool {
+		if table, err := value.(TableEngine).GetTableSnapshot(); err == nil {
+			tables = append(tables, table)
+		}
+		return true
+	})
+	return tables
+}
+
 func (m *manager) CreateTable(options *TableEngineOptions, callbacks *TableEngineCallbacks, setting TableSetting) (*Table, error) {
 	var engineOptions *TableEngineOptions
 	if options != nil {
@@ -95,34 +214,179 @@ func (m *manager) CreateTable(options *TableEngineOptions, callbacks *TableEngin
 		engineCallbacks = NewTableEngineCallbacks()
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.maxTables > 0 && m.ActiveTableCount() >= m.maxTables {
+		return nil, ErrManagerAtCapacity
+	}
+
 	gameBackend := NewNativeGameBackend()
-	tableEngine := NewTableEngine(engineOptions, WithGameBackend(gameBackend))
-	tableEngine.OnTableUpdated(engineCallbacks.OnTableUpdated)
-	tableEngine.OnTableErrorUpdated(engineCallbacks.OnTableErrorUpdated)
-	tableEngine.OnTableStateUpdated(engineCallbacks.OnTableStateUpdated)
+	tableEngine := NewTableEngine(engineOptions, WithGameBackend(gameBackend), WithReEntryLedger(m.reEntries))
+	tableEngine.OnTableUpdated(func(table *Table) {
+		engineCallbacks.OnTableUpdated(table)
+		m.hooksMu.RLock()
+		fn := m.onAnyTableUpdated
+		m.hooksMu.RUnlock()
+		fn(table.ID, table)
+	})
+	tableEngine.OnTableErrorUpdated(func(table *Table, err error) {
+		engineCallbacks.OnTableErrorUpdated(table, err)
+		m.hooksMu.RLock()
+		fn := m.onAnyTableErrorUpdated
+		m.hooksMu.RUnlock()
+		fn(table.ID, table, err)
+	})
+	tableEngine.OnTableStateUpdated(func(event string, table *Table) {
+		engineCallbacks.OnTableStateUpdated(event, table)
+		m.hooksMu.RLock()
+		fn := m.onAnyTableStateUpdated
+		m.hooksMu.RUnlock()
+		fn(table.ID, event, table)
+	})
 	tableEngine.OnTablePlayerStateUpdated(engineCallbacks.OnTablePlayerStateUpdated)
 	tableEngine.OnTablePlayerReserved(engineCallbacks.OnTablePlayerReserved)
-	tableEngine.OnGamePlayerActionUpdated(engineCallbacks.OnGamePlayerActionUpdated)
+	tableEngine.OnGamePlayerActionUpdated(func(gameAction TablePlayerGameAction) {
+		engineCallbacks.OnGamePlayerActionUpdated(gameAction)
+		m.hooksMu.RLock()
+		fn := m.onAnyGamePlayerActionUpdated
+		m.hooksMu.RUnlock()
+		fn(gameAction.TableID, gameAction)
+	})
 	tableEngine.OnAutoGameOpenEnd(engineCallbacks.OnAutoGameOpenEnd)
 	tableEngine.OnReadyOpenFirstTableGame(engineCallbacks.OnReadyOpenFirstTableGame)
+	tableEngine.OnSeatOpened(engineCallbacks.OnSeatOpened)
+	tableEngine.OnRoundChanged(engineCallbacks.OnRoundChanged)
+	tableEngine.OnWalk(engineCallbacks.OnWalk)
+	tableEngine.OnDealApplied(engineCallbacks.OnDealApplied)
+	tableEngine.OnPlayerAutoReady(engineCallbacks.OnPlayerAutoReady)
+	tableEngine.OnPlayerAutoSitOut(engineCallbacks.OnPlayerAutoSitOut)
+	tableEngine.OnSeatMapChanged(engineCallbacks.OnSeatMapChanged)
+	tableEngine.OnTableStarved(engineCallbacks.OnTableStarved)
+	tableEngine.OnInsufficientParticipants(engineCallbacks.OnInsufficientParticipants)
+	tableEngine.OnInsuranceOffer(engineCallbacks.OnInsuranceOffer)
+	tableEngine.OnMisdeal(engineCallbacks.OnMisdeal)
+	tableEngine.OnActionRequired(engineCallbacks.OnActionRequired)
+	tableEngine.OnBlindPosted(engineCallbacks.OnBlindPosted)
+	tableEngine.OnReadyProgress(engineCallbacks.OnReadyProgress)
+	tableEngine.OnGameSettled(engineCallbacks.OnGameSettled)
+	tableEngine.OnActionWarning(engineCallbacks.OnActionWarning)
+	tableEngine.OnPlayerAddOn(engineCallbacks.OnPlayerAddOn)
+	tableEngine.OnSettlementTimeout(engineCallbacks.OnSettlementTimeout)
 	table, err := tableEngine.CreateTable(setting)
 	if err != nil {
 		return nil, err
 	}
 
 	m.tableEngines.Store(table.ID, tableEngine)
+
+	if m.closedTableGracePeriod > 0 && !m.isReaping {
+		m.isReaping = true
+		go m.reap()
+	}
+
 	return table, nil
 }
 
-func (m *manager) PauseTable(tableID string) error {
+/*
+reap evicts closed tables periodically
+  - A table is evicted once it has been closed for longer than the grace period
+  - Stops when there is no table left, and restarts on the next CreateTable
+*/
+func (m *manager) reap() {
+	ticker := time.NewTicker(m.closedTableGracePeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !m.reapClosedTables(time.Now()) {
+			return
+		}
+	}
+}
+
+// reapClosedTables returns false if there is no table left
+func (m *manager) reapClosedTables(now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tableCount := 0
+	m.tableEngines.Range(func(key, value interface{}) bool {
+		tableID := key.(string)
+		tableEngine := value.(TableEngine)
+		table, err := tableEngine.GetTableSnapshot()
+		if err != nil || table.State.Status != TableStateStatus_TableClosed {
+			tableCount++
+			return true
+		}
+
+		closedAt, exist := m.closedAt[tableID]
+		if !exist {
+			m.closedAt[tableID] = now
+			tableCount++
+			return true
+		}
+
+		if now.Sub(closedAt) < m.closedTableGracePeriod {
+			tableCount++
+			return true
+		}
+
+		tableEngine.ReleaseTable()
+		m.tableEngines.Delete(tableID)
+		delete(m.closedAt, tableID)
+		return true
+	})
+
+	// remove tables that are already released or closed by the manager
+	for tableID := range m.closedAt {
+		if _, exist := m.tableEngines.Load(tableID); !exist {
+			delete(m.closedAt, tableID)
+		}
+	}
+
+	if tableCount == 0 {
+		m.isReaping = false
+		return false
+	}
+	return true
+}
+
+func (m *manager) PauseTable(tableID string) (bool, error) {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
-		return ErrManagerTableNotFound
+		return false, ErrManagerTableNotFound
 	}
 
 	return tableEngine.PauseTable()
 }
 
+func (m *manager) ScheduleHardStop(tableID string, at time.Time) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.ScheduleHardStop(at)
+}
+
+func (m *manager) SwapGameBackend(tableID string, gb GameBackend) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.SwapGameBackend(gb)
+}
+
+func (m *manager) AdvanceRound(tableID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.AdvanceRound()
+}
+
 func (m *manager) CloseTable(tableID string) error {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
@@ -137,6 +401,15 @@ func (m *manager) CloseTable(tableID string) error {
 	return nil
 }
 
+func (m *manager) ApplyDeal(tableID string, payouts map[string]int64) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.ApplyDeal(payouts)
+}
+
 func (m *manager) StartTableGame(tableID string) error {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
@@ -146,13 +419,21 @@ func (m *manager) StartTableGame(tableID string) error {
 	return tableEngine.StartTableGame()
 }
 
+func (m *manager) StartTableGameIfNotStarted(tableID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.StartTableGameIfNotStarted()
+}
+
 func (m *manager) SetUpTableGame(tableID string, gameCount int, participants map[string]int) error {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
 		return ErrManagerTableNotFound
 	}
-	tableEngine.SetUpTableGame(gameCount, participants)
-	return nil
+	return tableEngine.SetUpTableGame(gameCount, participants)
 }
 
 func (m *manager) UpdateBlind(tableID string, level int, ante, dealer, sb, bb int64) error {
@@ -210,6 +491,24 @@ func (m *manager) PlayerRedeemChips(tableID string, joinPlayer JoinPlayer) error
 	return tableEngine.PlayerRedeemChips(joinPlayer)
 }
 
+func (m *manager) PlayerAddOn(tableID string, joinPlayer JoinPlayer) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerAddOn(joinPlayer)
+}
+
+func (m *manager) PlayerReEntry(tableID string, joinPlayer JoinPlayer) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerReEntry(joinPlayer)
+}
+
 func (m *manager) PlayersLeave(tableID string, playerIDs []string) error {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
@@ -219,6 +518,33 @@ func (m *manager) PlayersLeave(tableID string, playerIDs []string) error {
 	return tableEngine.PlayersLeave(playerIDs)
 }
 
+func (m *manager) PlayerChangeSeat(tableID, playerID string, targetSeat int) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerChangeSeat(playerID, targetSeat)
+}
+
+func (m *manager) PlayerSetAutoRebuy(tableID, playerID string, enabled bool) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerSetAutoRebuy(playerID, enabled)
+}
+
+func (m *manager) PlayerPostMissedBlind(tableID, playerID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerPostMissedBlind(playerID)
+}
+
 func (m *manager) PlayerExtendActionDeadline(tableID, playerID string, duration int) (int64, error) {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
@@ -228,6 +554,15 @@ func (m *manager) PlayerExtendActionDeadline(tableID, playerID string, duration
 	return tableEngine.PlayerExtendActionDeadline(playerID, duration)
 }
 
+func (m *manager) PlayerActionTimeout(tableID, playerID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerActionTimeout(playerID)
+}
+
 func (m *manager) PlayerReady(tableID, playerID string) error {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
@@ -308,3 +643,12 @@ func (m *manager) PlayerPass(tableID, playerID string) error {
 
 	return tableEngine.PlayerPass(playerID)
 }
+
+func (m *manager) PlayerBuyInsurance(tableID, playerID string, amount int64) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerBuyInsurance(playerID, amount)
+}
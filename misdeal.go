@@ -0,0 +1,48 @@
+package pokertable
+
+import (
+	"fmt"
+
+	"github.com/d-protocol/pokerlib"
+)
+
+// maxMisdealRedeals is the number of times a misdealt hand is dealt again before it's voided
+const maxMisdealRedeals = 3
+
+/*
+validateDeal returns why the dealt game state is a misdeal, empty if the deal is valid
+  - Once hole cards are dealt, every player holds holeCardsCount cards (2, 4 for Omaha)
+  - No card shows up twice among the hole cards & the board
+*/
+func validateDeal(gs *pokerlib.GameState, holeCardsCount int) string {
+	isDealt := false
+	for _, p := range gs.Players {
+		if len(p.HoleCards) > 0 {
+			isDealt = true
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range gs.Players {
+		if isDealt && len(p.HoleCards) != holeCardsCount {
+			return fmt.Sprintf("player %d has %d hole cards, expected %d", p.Idx, len(p.HoleCards), holeCardsCount)
+		}
+
+		for _, card := range p.HoleCards {
+			if seen[card] {
+				return fmt.Sprintf("duplicate card %s", card)
+			}
+			seen[card] = true
+		}
+	}
+
+	for _, card := range gs.Status.Board {
+		if seen[card] {
+			return fmt.Sprintf("duplicate card %s", card)
+		}
+		seen[card] = true
+	}
+
+	return ""
+}
@@ -1,13 +1,25 @@
 package pokertable
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"sync"
 
 	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/combination"
+)
+
+var (
+	ErrGameSeedNotFound      = errors.New("game: seed not found")
+	ErrGameEquityUnavailable = errors.New("game: equity needs at least two players in the hand")
+	ErrGameNotEnoughCards    = errors.New("game: not enough cards to deal")
 )
 
 type NativeGameBackend struct {
 	engine pokerlib.PokerFace
+	seeds  sync.Map // key: game_id, value: seed
 }
 
 func NewNativeGameBackend() *NativeGameBackend {
@@ -16,6 +28,14 @@ func NewNativeGameBackend() *NativeGameBackend {
 	}
 }
 
+func newGameSeed() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func cloneGameState(gs *pokerlib.GameState) *pokerlib.GameState {
 	// Note: we must clone a new structure for preventing original data of game engine is modified outside.
 	data, err := json.Marshal(gs)
@@ -37,14 +57,47 @@ func (ngb *NativeGameBackend) getState(g pokerlib.Game) *pokerlib.GameState {
 }
 
 func (ngb *NativeGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
-	g := ngb.engine.NewGame(opts)
-	err := g.Start()
+	seed, err := newGameSeed()
 	if err != nil {
 		return nil, err
 	}
+
+	// the deck is shuffled from the seed before the game starts, so the revealed seed proves the dealing order
+	gs, err := ngb.createGame(opts, ShuffleDeck(seed, opts.Deck))
+	if err != nil {
+		return nil, err
+	}
+
+	ngb.seeds.Store(gs.GameID, seed)
+	return gs, nil
+}
+
+// createGame starts a game dealing the deck in order, opts is left untouched
+func (ngb *NativeGameBackend) createGame(opts *pokerlib.GameOptions, deck []string) (*pokerlib.GameState, error) {
+	gameOpts := *opts
+	gameOpts.Deck = deck
+
+	g := ngb.engine.NewGame(&gameOpts)
+	if err := g.Start(); err != nil {
+		return nil, err
+	}
 	return ngb.getState(g), nil
 }
 
+// GetSeed returns the seed generated for the game created by CreateGame, the deck of the game is ShuffleDeck(seed, opts.Deck)
+func (ngb *NativeGameBackend) GetSeed(gameID string) (string, error) {
+	seed, exist := ngb.seeds.Load(gameID)
+	if !exist {
+		return "", ErrGameSeedNotFound
+	}
+	return seed.(string), nil
+}
+
+// ReleaseSeed forgets the seed of the game, GetSeed returns ErrGameSeedNotFound for it afterwards
+func (ngb *NativeGameBackend) ReleaseSeed(gameID string) {
+	ngb.seeds.Delete(gameID)
+}
+
 func (ngb *NativeGameBackend) ReadyForAll(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
 	g := ngb.engine.NewGameFromState(cloneGameState(gs))
 	err := g.ReadyForAll()
@@ -152,3 +205,89 @@ func (ngb *NativeGameBackend) Pass(gs *pokerlib.GameState) (*pokerlib.GameState,
 	}
 	return ngb.getState(g), nil
 }
+
+/*
+ComputeEquity returns the share of the pot each player in the hand wins over every runout of the board (key: game player index)
+  - Ties split the share evenly, folded players are left out
+  - Every runout is enumerated, ask once the flop is dealt (a preflop board has about 1.7M runouts)
+*/
+func (ngb *NativeGameBackend) ComputeEquity(gs *pokerlib.GameState) (map[int]float64, error) {
+	players := make([]*pokerlib.PlayerState, 0)
+	for _, p := range gs.Players {
+		if !p.Fold {
+			players = append(players, p)
+		}
+	}
+	if len(players) < 2 {
+		return nil, ErrGameEquityUnavailable
+	}
+
+	// Cards left to come are drawn from the cards neither on the board nor dealt to any player
+	dealt := make(map[string]bool)
+	for _, card := range gs.Status.Board {
+		dealt[card] = true
+	}
+	for _, p := range gs.Players {
+		for _, card := range p.HoleCards {
+			dealt[card] = true
+		}
+	}
+	unseen := make([]string, 0)
+	for _, card := range gs.Meta.Deck {
+		if !dealt[card] {
+			unseen = append(unseen, card)
+		}
+	}
+
+	runouts := [][]string{{}}
+	if missing := 5 - len(gs.Status.Board); missing > 0 {
+		runouts = combination.GetPossibleCombinations(unseen, missing)
+	}
+
+	shares := make(map[int]float64)
+	for _, runout := range runouts {
+		board := append(append([]string{}, gs.Status.Board...), runout...)
+
+		bestScore := uint64(0)
+		winners := make([]int, 0)
+		for _, p := range players {
+			score := uint64(0)
+			for _, cards := range combination.GetAllPossibleCombinations(board, p.HoleCards, gs.Meta.RequiredHoleCardsCount) {
+				if ps := combination.CalculatePower(gs.Meta.CombinationPowers, cards); ps.Score > score {
+					score = ps.Score
+				}
+			}
+
+			if score > bestScore {
+				bestScore = score
+				winners = []int{p.Idx}
+			} else if score == bestScore {
+				winners = append(winners, p.Idx)
+			}
+		}
+
+		for _, idx := range winners {
+			shares[idx] += 1 / float64(len(winners))
+		}
+	}
+
+	equity := make(map[int]float64)
+	for _, p := range players {
+		equity[p.Idx] = shares[p.Idx] / float64(len(runouts))
+	}
+	return equity, nil
+}
+
+// DealForButton deals one card of a shuffled standard deck to each player (key: player id)
+func (ngb *NativeGameBackend) DealForButton(playerIDs []string) (map[string]string, error) {
+	deck := pokerlib.ShuffleCards(pokerlib.NewStandardDeckCards())
+	if len(playerIDs) > len(deck) {
+		return nil, ErrGameNotEnoughCards
+	}
+
+	cards := make(map[string]string)
+	for i, playerID := range playerIDs {
+		cards[playerID] = deck[i]
+	}
+	return cards, nil
+}
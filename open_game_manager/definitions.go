@@ -18,14 +18,16 @@ type OpenGameManager interface {
 }
 
 type openGameManager struct {
-	onOpenGameReady func(state OpenGameState)
-	rg              *syncsaga.ReadyGroup
-	state           *OpenGameState
+	onOpenGameReady   func(state OpenGameState)
+	onOpenGameTimeout func(pendingParticipantIDs []string)
+	rg                *syncsaga.ReadyGroup
+	state             *OpenGameState
 }
 
 type OpenGameOption struct {
-	Timeout         int
-	OnOpenGameReady func(state OpenGameState)
+	Timeout           int
+	OnOpenGameReady   func(state OpenGameState)
+	OnOpenGameTimeout func(pendingParticipantIDs []string) // Optional, called before the participants still pending at the timeout are readied
 }
 
 type OpenGameState struct {
@@ -9,16 +9,10 @@ import (
 
 func NewOpenGameManager(options OpenGameOption) OpenGameManager {
 	m := &openGameManager{
-		onOpenGameReady: options.OnOpenGameReady,
-		rg: syncsaga.NewReadyGroup(syncsaga.WithTimeout(options.Timeout, func(rg *syncsaga.ReadyGroup) {
-			// Auto Ready By Default
-			for idx, isReady := range rg.GetParticipantStates() {
-				if !isReady {
-					rg.Ready(idx)
-				}
-			}
-		})),
+		onOpenGameReady:   options.OnOpenGameReady,
+		onOpenGameTimeout: options.OnOpenGameTimeout,
 	}
+	m.rg = syncsaga.NewReadyGroup(syncsaga.WithTimeout(options.Timeout, m.readyGroupOnTimeout))
 	m.state = &OpenGameState{
 		Timeout:      options.Timeout,
 		GameCount:    0,
@@ -30,21 +24,15 @@ func NewOpenGameManager(options OpenGameOption) OpenGameManager {
 
 func NewOpenGameManagerFromState(state OpenGameState, options OpenGameOption) OpenGameManager {
 	m := &openGameManager{
-		onOpenGameReady: options.OnOpenGameReady,
-		rg: syncsaga.NewReadyGroup(syncsaga.WithTimeout(options.Timeout, func(rg *syncsaga.ReadyGroup) {
-			// Auto Ready By Default
-			for idx, isReady := range rg.GetParticipantStates() {
-				if !isReady {
-					rg.Ready(idx)
-				}
-			}
-		})),
+		onOpenGameReady:   options.OnOpenGameReady,
+		onOpenGameTimeout: options.OnOpenGameTimeout,
 		state: &OpenGameState{
 			Timeout:      options.Timeout,
 			GameCount:    state.GameCount,
 			Participants: make(map[string]*OpenGameParticipant),
 		},
 	}
+	m.rg = syncsaga.NewReadyGroup(syncsaga.WithTimeout(options.Timeout, m.readyGroupOnTimeout))
 	m.rg.OnCompleted(func(rg *syncsaga.ReadyGroup) {
 		m.readyGroupOnCompleted()
 	})
@@ -1,5 +1,11 @@
 package open_game_manager
 
+import (
+	"sort"
+
+	"github.com/d-protocol/syncsaga"
+)
+
 func (m *openGameManager) readyGroupResetParticipants() {
 	m.rg.ResetParticipants()
 	m.state.Participants = map[string]*OpenGameParticipant{}
@@ -21,6 +27,33 @@ func (m *openGameManager) readyGroupOnCompleted() {
 	m.onOpenGameReady(m.GetState())
 }
 
+func (m *openGameManager) readyGroupOnTimeout(rg *syncsaga.ReadyGroup) {
+	pendingParticipantIDs := make([]string, 0)
+	for idx, isReady := range rg.GetParticipantStates() {
+		if isReady {
+			continue
+		}
+		for participantID, participant := range m.state.Participants {
+			if int64(participant.Index) == idx {
+				pendingParticipantIDs = append(pendingParticipantIDs, participantID)
+				break
+			}
+		}
+	}
+	sort.Strings(pendingParticipantIDs)
+
+	if len(pendingParticipantIDs) > 0 && m.onOpenGameTimeout != nil {
+		m.onOpenGameTimeout(pendingParticipantIDs)
+	}
+
+	// Auto Ready By Default
+	for idx, isReady := range rg.GetParticipantStates() {
+		if !isReady {
+			rg.Ready(idx)
+		}
+	}
+}
+
 func (m *openGameManager) readyGroupReady(participantID string) error {
 	participant, exist := m.state.Participants[participantID]
 	if !exist {
@@ -1,37 +1,90 @@
 package pokertable
 
 type TableEngineCallbacks struct {
-	OnTableUpdated            func(table *Table)
-	OnTableErrorUpdated       func(table *Table, err error)
-	OnTableStateUpdated       func(event string, table *Table)
-	OnTablePlayerStateUpdated func(competitionID, tableID string, playerState *TablePlayerState)
-	OnTablePlayerReserved     func(competitionID, tableID string, playerState *TablePlayerState)
-	OnGamePlayerActionUpdated func(gameAction TablePlayerGameAction)
-	OnAutoGameOpenEnd         func(competitionID, tableID string)
-	OnReadyOpenFirstTableGame func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState)
+	OnTableUpdated             func(table *Table)
+	OnTableErrorUpdated        func(table *Table, err error)
+	OnTableStateUpdated        func(event string, table *Table)
+	OnTablePlayerStateUpdated  func(competitionID, tableID string, playerState *TablePlayerState)
+	OnTablePlayerReserved      func(competitionID, tableID string, playerState *TablePlayerState)
+	OnGamePlayerActionUpdated  func(gameAction TablePlayerGameAction)
+	OnAutoGameOpenEnd          func(competitionID, tableID string)
+	OnReadyOpenFirstTableGame  func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState)
+	OnSeatOpened               func(tableID string, seat int)
+	OnRoundChanged             func(round string, board []string)
+	OnWalk                     func(bbPlayerID string, amount int64)
+	OnDealApplied              func(payouts map[string]int64)
+	OnPlayerAutoReady          func(playerID string, phase string)
+	OnPlayerAutoSitOut         func(playerID string)
+	OnSeatMapChanged           func(tableID string, seatMap map[int]int)
+	OnTableStarved             func(tableID string)
+	OnInsufficientParticipants func(count int)
+	OnInsuranceOffer           func(playerID string, equity float64, maxPayout int64)
+	OnMisdeal                  func(gameCount int, reason string)
+	OnActionRequired           func(action ActionRequired)
+	OnBlindPosted              func(playerID string, position string, amount int64)
+	OnReadyProgress            func(ready, total int)
+	OnGameSettled              func(gameCount int, results []PlayerHandResult)
+	OnActionWarning            func(playerID string, secondsLeft int)
+	OnPlayerAddOn              func(playerID string, chips int64)
+	OnSettlementTimeout        func(pendingPlayerIDs []string)
 }
 
 func NewTableEngineCallbacks() *TableEngineCallbacks {
 	return &TableEngineCallbacks{
-		OnTableUpdated:            func(table *Table) {},
-		OnTableErrorUpdated:       func(table *Table, err error) {},
-		OnTableStateUpdated:       func(event string, table *Table) {},
-		OnTablePlayerStateUpdated: func(competitionID, tableID string, playerState *TablePlayerState) {},
-		OnTablePlayerReserved:     func(competitionID, tableID string, playerState *TablePlayerState) {},
-		OnGamePlayerActionUpdated: func(gameAction TablePlayerGameAction) {},
-		OnAutoGameOpenEnd:         func(competitionID, tableID string) {},
-		OnReadyOpenFirstTableGame: func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState) {},
+		OnTableUpdated:             func(table *Table) {},
+		OnTableErrorUpdated:        func(table *Table, err error) {},
+		OnTableStateUpdated:        func(event string, table *Table) {},
+		OnTablePlayerStateUpdated:  func(competitionID, tableID string, playerState *TablePlayerState) {},
+		OnTablePlayerReserved:      func(competitionID, tableID string, playerState *TablePlayerState) {},
+		OnGamePlayerActionUpdated:  func(gameAction TablePlayerGameAction) {},
+		OnAutoGameOpenEnd:          func(competitionID, tableID string) {},
+		OnReadyOpenFirstTableGame:  func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState) {},
+		OnSeatOpened:               func(tableID string, seat int) {},
+		OnRoundChanged:             func(round string, board []string) {},
+		OnWalk:                     func(bbPlayerID string, amount int64) {},
+		OnDealApplied:              func(payouts map[string]int64) {},
+		OnPlayerAutoReady:          func(playerID string, phase string) {},
+		OnPlayerAutoSitOut:         func(playerID string) {},
+		OnSeatMapChanged:           func(tableID string, seatMap map[int]int) {},
+		OnTableStarved:             func(tableID string) {},
+		OnInsufficientParticipants: func(count int) {},
+		OnInsuranceOffer:           func(playerID string, equity float64, maxPayout int64) {},
+		OnMisdeal:                  func(gameCount int, reason string) {},
+		OnActionRequired:           func(action ActionRequired) {},
+		OnBlindPosted:              func(playerID string, position string, amount int64) {},
+		OnReadyProgress:            func(ready, total int) {},
+		OnGameSettled:              func(gameCount int, results []PlayerHandResult) {},
+		OnActionWarning:            func(playerID string, secondsLeft int) {},
+		OnPlayerAddOn:              func(playerID string, chips int64) {},
+		OnSettlementTimeout:        func(pendingPlayerIDs []string) {},
 	}
 }
 
 type TableEngineOptions struct {
-	GameContinueInterval int
-	OpenGameTimeout      int
+	GameContinueInterval   int
+	OpenGameTimeout        int    // Seconds players have to finish settlement before the next hand auto-opens
+	LateRegBlindRule       string // How players joining mid-game are dealt in (LateRegBlindRule_*)
+	PreserveSessionStats   bool   // Keep session stats (BuyInTotal, SessionNet) when a player leaves and rejoins the table
+	DisconnectPolicy       string // How a disconnected player acts on their turn (DisconnectPolicy_*)
+	BBCheckIsVPIPChance    bool   // Whether an unraised BB (who may check) has a VPIP chance, true by default
+	MaxConsecutiveTimeouts int    // Action timeouts in a row before the player is sat out automatically, 0 means unlimited
+	MaxSeatHistory         int    // Seat history entries kept, the oldest closed entries are dropped first, 0 means unlimited
+	HealthStallTimeout     int    // Seconds without a table update before a running hand counts as stuck, see HealthCheck
+	ActionRequiredEquity   bool   // Compute the acting player's equity for OnActionRequired from the flop on, it enumerates runouts against every hand still in
+	ManualRoundAdvance     bool   // Wait for AdvanceRound when a round closes instead of dealing the next round automatically
+	ActionWarningSeconds   int    // Seconds before CurrentActionEndAt OnActionWarning fires, 0 disables the warning
+	BackendCallTimeout     int    // Milliseconds a GameBackend call may take before it is abandoned with ErrBackendTimeout, 0 means no limit, see ContextGameBackend
+	HandRetention          int    // Latest hands whose records are kept, 0 keeps every hand, see WithHandRetention
 }
 
 func NewTableEngineOptions() *TableEngineOptions {
 	return &TableEngineOptions{
 		GameContinueInterval: 1, // 1 second by default
 		OpenGameTimeout:      2,
+		LateRegBlindRule:     LateRegBlindRule_None,
+		DisconnectPolicy:     DisconnectPolicy_FastFold,
+		BBCheckIsVPIPChance:  true,
+		HealthStallTimeout:   60,
+		HandRetention:        1000,
 	}
 }
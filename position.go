@@ -21,7 +21,7 @@ func (te *tableEngine) updatePlayerPositions(maxSeat int, players []*TablePlayer
 	playerCount := 0
 	sps := te.sm.ListPlayerSeatsFromDealer()
 	for i, sp := range sps {
-		seatID := (i + dealerSeatID) % maxSeat
+		seatID := te.seatIDFrom(dealerSeatID, i, maxSeat)
 
 		if funk.Contains([]int{dealerSeatID, sbSeatID, bbSeatID}, seatID) {
 			playerCount++
@@ -32,6 +32,18 @@ func (te *tableEngine) updatePlayerPositions(maxSeat int, players []*TablePlayer
 		}
 	}
 
+	// update player positions (players sitting out get no position)
+	playerIdxData := make(map[string]int) // key: player_id, value: player_idx
+	for playerIdx, player := range players {
+		playerIdxData[player.PlayerID] = playerIdx
+		player.Positions = []string{}
+	}
+
+	// a single player gets no position, no hand can be dealt
+	if playerCount < 2 {
+		return
+	}
+
 	positions := newPositions(playerCount) // rotate & start from bb
 	playerPositions := make([][]string, 0) // rotate & start from bb
 	if playerCount == 2 {
@@ -44,14 +56,8 @@ func (te *tableEngine) updatePlayerPositions(maxSeat int, players []*TablePlayer
 		}
 	}
 
-	// update player positions
-	playerIdxData := make(map[string]int) // key: player_id, value: player_idx
-	for playerIdx, player := range players {
-		playerIdxData[player.PlayerID] = playerIdx
-	}
-
-	for i := bbSeatID; i < maxSeat+bbSeatID; i++ {
-		seatID := i % maxSeat
+	for i := 0; i < maxSeat; i++ {
+		seatID := te.seatIDFrom(bbSeatID, i, maxSeat)
 		if seatPlayer, exist := te.sm.Seats()[seatID]; exist {
 			if seatPlayer != nil && seatPlayer.Active() {
 				if playerIdx, exist := playerIdxData[seatPlayer.ID]; exist && playerIdx < len(players) {
@@ -0,0 +1,94 @@
+package pokertable
+
+import (
+	"sync"
+)
+
+type reEntryKey struct {
+	competitionID string
+	playerID      string
+}
+
+type reEntryRecord struct {
+	isEliminated bool
+	count        int // re-entries made, including one in progress
+}
+
+/*
+ReEntryLedger records eliminated players & their re-entries per competition
+  - Use case: Share one ledger between all the tables of a tournament (WithReEntryLedger), the manager does it for its tables
+  - Keyed by the competition id, so a player moved by a rebalance or re-entering at another table keeps the count
+*/
+type ReEntryLedger struct {
+	mu      sync.Mutex
+	records map[reEntryKey]*reEntryRecord
+}
+
+func NewReEntryLedger() *ReEntryLedger {
+	return &ReEntryLedger{
+		records: make(map[reEntryKey]*reEntryRecord),
+	}
+}
+
+// Count returns the re-entries the player has made in the competition
+func (l *ReEntryLedger) Count(competitionID, playerID string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if record, exist := l.records[reEntryKey{competitionID: competitionID, playerID: playerID}]; exist {
+		return record.count
+	}
+	return 0
+}
+
+func (l *ReEntryLedger) record(competitionID, playerID string) *reEntryRecord {
+	key := reEntryKey{competitionID: competitionID, playerID: playerID}
+	record, exist := l.records[key]
+	if !exist {
+		record = &reEntryRecord{}
+		l.records[key] = record
+	}
+	return record
+}
+
+// markEliminated records that the player is out of chips & may re-enter
+func (l *ReEntryLedger) markEliminated(competitionID, playerID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.record(competitionID, playerID).isEliminated = true
+}
+
+/*
+reserve takes a re-entry of an eliminated player, the returned count includes it
+  - ErrTablePlayerNotEliminated if the player is not recorded as eliminated
+  - ErrTableReEntryLimitReached if the player has made maxReEntries re-entries
+  - A failed re-entry must be given back with release
+*/
+func (l *ReEntryLedger) reserve(competitionID, playerID string, maxReEntries int) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	record := l.record(competitionID, playerID)
+	if !record.isEliminated {
+		return record.count, ErrTablePlayerNotEliminated
+	}
+
+	if record.count >= maxReEntries {
+		return record.count, ErrTableReEntryLimitReached
+	}
+
+	record.isEliminated = false
+	record.count++
+	return record.count, nil
+}
+
+// release gives back a re-entry taken by reserve
+func (l *ReEntryLedger) release(competitionID, playerID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	record := l.record(competitionID, playerID)
+	record.isEliminated = true
+	record.count--
+}
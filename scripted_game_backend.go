@@ -0,0 +1,168 @@
+package pokertable
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/d-protocol/pokerlib"
+)
+
+var (
+	ErrGameScriptExhausted = errors.New("game: no scripted hand left")
+	ErrGameInvalidScript   = errors.New("game: invalid scripted hand")
+)
+
+/*
+ScriptedHand describes the cards of a hand dealt by ScriptedGameBackend
+  - Players without scripted hole cards and board cards left unscripted are dealt from the rest of the deck in deck order
+*/
+type ScriptedHand struct {
+	HoleCards  map[int][]string // key: game player index
+	BoardCards []string         // Flop, turn & river in dealing order
+}
+
+/*
+NewScriptedHand creates an empty hand description to build fluently, e.g.
+
+	NewScriptedHand().
+		Deal(0, "SA", "HA").
+		Deal(1, "SK", "HK").
+		Board("D2", "C7", "S9", "C3", "H4")
+*/
+func NewScriptedHand() *ScriptedHand {
+	return &ScriptedHand{
+		HoleCards: make(map[int][]string),
+	}
+}
+
+// Deal sets the hole cards of the game player
+func (h *ScriptedHand) Deal(gamePlayerIdx int, cards ...string) *ScriptedHand {
+	h.HoleCards[gamePlayerIdx] = cards
+	return h
+}
+
+// Board sets the board cards, fewer than five leaves the rest of the runout to the deck
+func (h *ScriptedHand) Board(cards ...string) *ScriptedHand {
+	h.BoardCards = cards
+	return h
+}
+
+/*
+stackDeck returns the deck in dealing order for the scripted hand
+  - pokerlib deals the hole cards player by player, then burns one card before the flop, the turn & the river
+*/
+func (h *ScriptedHand) stackDeck(deck []string, playerCount, holeCardsCount int) ([]string, error) {
+	if len(h.BoardCards) > 5 {
+		return nil, fmt.Errorf("%w: %d board cards", ErrGameInvalidScript, len(h.BoardCards))
+	}
+
+	inDeck := make(map[string]bool)
+	for _, card := range deck {
+		inDeck[card] = true
+	}
+
+	scripted := make(map[string]bool)
+	scriptCard := func(card string) error {
+		if !inDeck[card] {
+			return fmt.Errorf("%w: card %s is not in the deck", ErrGameInvalidScript, card)
+		}
+		if scripted[card] {
+			return fmt.Errorf("%w: duplicate card %s", ErrGameInvalidScript, card)
+		}
+		scripted[card] = true
+		return nil
+	}
+
+	for gamePlayerIdx, cards := range h.HoleCards {
+		if gamePlayerIdx < 0 || gamePlayerIdx >= playerCount {
+			return nil, fmt.Errorf("%w: game player %d not found", ErrGameInvalidScript, gamePlayerIdx)
+		}
+		if len(cards) != holeCardsCount {
+			return nil, fmt.Errorf("%w: player %d has %d hole cards, expected %d", ErrGameInvalidScript, gamePlayerIdx, len(cards), holeCardsCount)
+		}
+		for _, card := range cards {
+			if err := scriptCard(card); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, card := range h.BoardCards {
+		if err := scriptCard(card); err != nil {
+			return nil, err
+		}
+	}
+
+	rest := make([]string, 0, len(deck))
+	for _, card := range deck {
+		if !scripted[card] {
+			rest = append(rest, card)
+		}
+	}
+	draw := func(count int) []string {
+		cards := rest[:count]
+		rest = rest[count:]
+		return cards
+	}
+
+	stacked := make([]string, 0, len(deck))
+	for gamePlayerIdx := 0; gamePlayerIdx < playerCount; gamePlayerIdx++ {
+		if cards, exist := h.HoleCards[gamePlayerIdx]; exist {
+			stacked = append(stacked, cards...)
+		} else {
+			stacked = append(stacked, draw(holeCardsCount)...)
+		}
+	}
+
+	// burn a card before the flop, the turn & the river
+	for _, street := range [][]int{{0, 1, 2}, {3}, {4}} {
+		stacked = append(stacked, draw(1)...)
+		for _, pos := range street {
+			if pos < len(h.BoardCards) {
+				stacked = append(stacked, h.BoardCards[pos])
+			} else {
+				stacked = append(stacked, draw(1)...)
+			}
+		}
+	}
+	return append(stacked, rest...), nil
+}
+
+/*
+ScriptedGameBackend deals predetermined cards without any randomness
+  - Use case: Deterministic tests of showdowns & settlement (ties, side pots, kickers)
+  - Each created game deals the next scripted hand, ErrGameScriptExhausted once all hands are dealt
+  - Everything but the deck is played by NativeGameBackend
+  - Scripted hands are not dealt from a seed & can't be verified, GetSeed returns ErrGameSeedNotFound for them
+*/
+type ScriptedGameBackend struct {
+	*NativeGameBackend
+	mu    sync.Mutex
+	hands []*ScriptedHand
+}
+
+func NewScriptedGameBackend(hands ...*ScriptedHand) *ScriptedGameBackend {
+	return &ScriptedGameBackend{
+		NativeGameBackend: NewNativeGameBackend(),
+		hands:             hands,
+	}
+}
+
+func (sgb *ScriptedGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
+	sgb.mu.Lock()
+	if len(sgb.hands) == 0 {
+		sgb.mu.Unlock()
+		return nil, ErrGameScriptExhausted
+	}
+	hand := sgb.hands[0]
+	sgb.hands = sgb.hands[1:]
+	sgb.mu.Unlock()
+
+	deck, err := hand.stackDeck(opts.Deck, len(opts.Players), opts.HoleCardsCount)
+	if err != nil {
+		return nil, err
+	}
+
+	// dealt without a seed
+	return sgb.createGame(opts, deck)
+}
@@ -8,6 +8,10 @@ const (
 	Rule_ShortDeck = "short_deck" // 短牌
 	Rule_Omaha     = "omaha"      // 奧瑪哈
 
+	// Rotation Directions
+	RotationDirection_Clockwise        = "clockwise"         // 順時針
+	RotationDirection_CounterClockwise = "counter_clockwise" // 逆時針
+
 	// Positions
 	Position_Unknown = "unknown"
 	Position_Dealer  = "dealer"
@@ -10,7 +10,7 @@ func DebugPrintSeats(msg string, sm SeatManager) {
 		if seatPlayer == nil {
 			fmt.Printf("Seat %d is empty\n", i)
 		} else {
-			fmt.Printf("Seat %d is occupied by %s. IsIn: %t, IsBetweenDealerBB: %t, HasChips: %t, Active: %t\n", i, seatPlayer.ID, seatPlayer.IsIn, seatPlayer.IsBetweenDealerBB, seatPlayer.HasChips, seatPlayer.Active())
+			fmt.Printf("Seat %d is occupied by %s. IsIn: %t, IsBetweenDealerBB: %t, HasChips: %t, IsWaitingBB: %t, Active: %t\n", i, seatPlayer.ID, seatPlayer.IsIn, seatPlayer.IsBetweenDealerBB, seatPlayer.HasChips, seatPlayer.IsWaitingBB, seatPlayer.Active())
 		}
 	}
 }
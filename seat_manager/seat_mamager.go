@@ -9,6 +9,7 @@ var (
 	ErrPlayerNotFound          = errors.New("seat manager: player not found")
 	ErrPlayerIsAlreadyExist    = errors.New("seat manager: player is already exist")
 	ErrUnavailableSeat         = errors.New("seat manager: seat is not available")
+	ErrSeatOutOfRange          = errors.New("seat manager: seat is out of range")
 	ErrDuplicatePlayers        = errors.New("seat manager: duplicate players detected")
 	ErrDuplicateSeats          = errors.New("seat manager: duplicate seats detected")
 	ErrSeatAlreadyIsTaken      = errors.New("seat manager: seat is already taken")
@@ -24,11 +25,16 @@ type SeatManager interface {
 	RandomAssignSeats(playerIDs []string) error
 	AssignSeats(playerSeatIDs map[string]int) error
 	RemoveSeats(playerIDs []string) error
+	MoveSeat(playerID string, targetSeatID int) error
 	UpdatePlayerHasChips(playerID string, hasChips bool) error
+	UpdatePlayerWaitingBB(playerID string, isWaitingBB bool) error
 	JoinPlayers(playerIDs []string) error
+	SitOutPlayers(playerIDs []string) error
 	InitPositions(isRandom bool) error
+	InitPositionsFromDealer(dealerSeatID int) error
 	RotatePositions() error
 	IsPlayerBetweenDealerBB(playerID string) bool
+	SetRotationDirection(direction string)
 
 	Seats() map[int]*SeatPlayer
 	CurrentDealerSeatID() int
@@ -37,6 +43,7 @@ type SeatManager interface {
 	IsInitPositions() bool
 	IsPlayerActive(playerID string) (bool, error)
 	ListPlayerSeatsFromDealer() []*SeatPlayer
+	Clone() SeatManager
 }
 
 type SeatPlayer struct {
@@ -44,10 +51,11 @@ type SeatPlayer struct {
 	IsIn              bool   `json:"is_in"`
 	IsBetweenDealerBB bool   `json:"is_between_dealer_bb"`
 	HasChips          bool   `json:"has_chips"`
+	IsWaitingBB       bool   `json:"is_waiting_bb"` // sits out until the BB reaches the seat
 }
 
 func (sp *SeatPlayer) Active() bool {
-	return sp.IsIn && !sp.IsBetweenDealerBB && sp.HasChips
+	return sp.IsIn && !sp.IsBetweenDealerBB && sp.HasChips && !sp.IsWaitingBB
 }
 
 func NewSeatManager(maxSeats int, rule string) SeatManager {
@@ -63,6 +71,7 @@ func NewSeatManager(maxSeats int, rule string) SeatManager {
 		SBSeatID:     UnsetSeatID,
 		BBSeatID:     UnsetSeatID,
 		Rule:         rule,
+		Direction:    RotationDirection_Clockwise,
 		IsInit:       false,
 	}
 }
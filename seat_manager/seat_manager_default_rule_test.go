@@ -367,6 +367,21 @@ func TestDefaultRule_AssignSeats_ErrSeatAlreadyIsTaken(t *testing.T) {
 	assert.ErrorIs(t, err, ErrSeatAlreadyIsTaken)
 }
 
+func TestDefaultRule_AssignSeats_ErrSeatOutOfRange(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+
+	sm := NewSeatManager(maxSeat, rule)
+
+	err := sm.AssignSeats(map[string]int{"P1": maxSeat})
+	assert.ErrorIs(t, err, ErrSeatOutOfRange)
+
+	err = sm.AssignSeats(map[string]int{"P1": -2})
+	assert.ErrorIs(t, err, ErrSeatOutOfRange)
+
+	assert.Equal(t, maxSeat, len(sm.Seats()))
+}
+
 func TestDefaultRule_AssignSeats_ErrDuplicateSeats(t *testing.T) {
 	maxSeat := 9
 	rule := Rule_Default
@@ -663,6 +678,98 @@ func TestDefaultRule_NotRandomInitPositions_MoreThanTwoPlayers(t *testing.T) {
 	verifySeatsAndPlayerPositions(t, expectedSeatPositions, expectedPlayerPositions, sm)
 }
 
+func TestDefaultRule_InitPositionsFromDealer_TwoPlayers(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 1, // bb
+		"P2": 5, // dealer & sb
+	}
+	expectedSeatPositions := map[string]int{
+		Position_Dealer: 5,
+		Position_SB:     5,
+		Position_BB:     1,
+	}
+	expectedPlayerPositions := map[string][]string{
+		"P1": {Position_BB},
+		"P2": {Position_Dealer, Position_SB},
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+
+	// join all players
+	err = sm.JoinPlayers([]string{"P1", "P2"})
+	assert.NoError(t, err)
+
+	err = sm.InitPositionsFromDealer(5)
+	assert.NoError(t, err)
+	assert.True(t, sm.IsInitPositions())
+
+	verifySeatsAndPlayerPositions(t, expectedSeatPositions, expectedPlayerPositions, sm)
+}
+
+func TestDefaultRule_InitPositionsFromDealer_MoreThanTwoPlayers(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0, // sb
+		"P2": 3, // bb
+		"P3": 4, // ug
+		"P4": 7, // dealer
+	}
+	expectedSeatPositions := map[string]int{
+		Position_Dealer: 7,
+		Position_SB:     0,
+		Position_BB:     3,
+	}
+	expectedPlayerPositions := map[string][]string{
+		"P1": {Position_SB},
+		"P2": {Position_BB},
+		"P3": {},
+		"P4": {Position_Dealer},
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+
+	// join all players
+	err = sm.JoinPlayers([]string{"P1", "P2", "P3", "P4"})
+	assert.NoError(t, err)
+
+	err = sm.InitPositionsFromDealer(7)
+	assert.NoError(t, err)
+	assert.True(t, sm.IsInitPositions())
+
+	verifySeatsAndPlayerPositions(t, expectedSeatPositions, expectedPlayerPositions, sm)
+
+	err = sm.InitPositionsFromDealer(7)
+	assert.ErrorIs(t, err, ErrAlreadyInitPositions)
+}
+
+func TestDefaultRule_InitPositionsFromDealer_ErrUnableToInitPositions_EmptySeat(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 3,
+		"P3": 4, // not joined
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+
+	err = sm.JoinPlayers([]string{"P1", "P2"})
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, sm.InitPositionsFromDealer(1), ErrUnableToInitPositions)
+	assert.ErrorIs(t, sm.InitPositionsFromDealer(4), ErrUnableToInitPositions)
+	assert.False(t, sm.IsInitPositions())
+}
+
 func TestDefaultRule_InitPositions_ErrUnableToInitPositions_InvalidRule(t *testing.T) {
 	maxSeat := 9
 	rule := "InvalidRule"
@@ -727,6 +834,111 @@ func TestDefaultRule_InitPositions_ErrUnableToInitPositions_SinglePlayer(t *test
 	assert.ErrorIs(t, err, ErrUnableToInitPositions)
 }
 
+func TestDefaultRule_RotatePositions_WaitingBBPlayerJoinsBehindDealer(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 2,
+		"P3": 4,
+		"P4": 6,
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+
+	err = sm.JoinPlayers([]string{"P1", "P2", "P3", "P4"})
+	assert.NoError(t, err)
+
+	err = sm.InitPositions(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, sm.CurrentDealerSeatID())
+	assert.Equal(t, 6, sm.CurrentSBSeatID())
+	assert.Equal(t, 0, sm.CurrentBBSeatID())
+
+	// P5 joins directly behind the dealer and waits for bb
+	err = sm.AssignSeats(map[string]int{"P5": 3})
+	assert.NoError(t, err)
+	err = sm.JoinPlayers([]string{"P5"})
+	assert.NoError(t, err)
+	err = sm.UpdatePlayerWaitingBB("P5", true)
+	assert.NoError(t, err)
+
+	active, err := sm.IsPlayerActive("P5")
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	// next hand: bb is P2, P5 keeps waiting
+	err = sm.RotatePositions()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sm.CurrentBBSeatID())
+	active, err = sm.IsPlayerActive("P5")
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	// next hand: bb reaches P5
+	err = sm.RotatePositions()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sm.CurrentBBSeatID())
+	active, err = sm.IsPlayerActive("P5")
+	assert.NoError(t, err)
+	assert.True(t, active)
+}
+
+func TestDefaultRule_UpdatePlayerWaitingBB_ErrPlayerNotFound(t *testing.T) {
+	sm := NewSeatManager(9, Rule_Default)
+	err := sm.UpdatePlayerWaitingBB("P1", true)
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+}
+
+func TestDefaultRule_RotatePositions_SitOutPlayerSkipsBB(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 2,
+		"P3": 4,
+		"P4": 6,
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+
+	err = sm.JoinPlayers([]string{"P1", "P2", "P3", "P4"})
+	assert.NoError(t, err)
+
+	err = sm.InitPositions(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sm.CurrentBBSeatID())
+
+	// P2 (next bb) sits out
+	err = sm.SitOutPlayers([]string{"P2"})
+	assert.NoError(t, err)
+	active, err := sm.IsPlayerActive("P2")
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	err = sm.RotatePositions()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, sm.CurrentBBSeatID())
+
+	// P2 comes back, but has to wait since the bb has passed
+	err = sm.JoinPlayers([]string{"P2"})
+	assert.NoError(t, err)
+	assert.True(t, sm.IsPlayerBetweenDealerBB("P2"))
+	active, err = sm.IsPlayerActive("P2")
+	assert.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestDefaultRule_SitOutPlayers_ErrPlayerNotFound(t *testing.T) {
+	sm := NewSeatManager(9, Rule_Default)
+	err := sm.SitOutPlayers([]string{"P1"})
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+}
+
 func TestDefaultRule_RotatePositions_MultipleTimes_TwoPlayers(t *testing.T) {
 	maxSeat := 9
 	rule := Rule_Default
@@ -1700,6 +1912,106 @@ func TestDefaultRule_AfterInitPositions_IsPlayerBetweenDealerBB(t *testing.T) {
 	assert.True(t, sm.IsPlayerBetweenDealerBB("P7"))
 }
 
+func TestDefaultRule_Clone_RotateDoesNotMutateOriginal(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 3,
+		"P3": 6,
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+
+	err = sm.JoinPlayers([]string{"P1", "P2", "P3"})
+	assert.NoError(t, err)
+
+	err = sm.InitPositions(false)
+	assert.NoError(t, err)
+
+	dealerSeatID, sbSeatID, bbSeatID := sm.CurrentDealerSeatID(), sm.CurrentSBSeatID(), sm.CurrentBBSeatID()
+
+	cloned := sm.Clone()
+	err = cloned.UpdatePlayerHasChips("P1", false)
+	assert.NoError(t, err)
+	err = cloned.RotatePositions()
+	assert.NoError(t, err)
+
+	// clone rotated
+	assert.NotEqual(t, dealerSeatID, cloned.CurrentDealerSeatID())
+
+	// original untouched
+	assert.Equal(t, dealerSeatID, sm.CurrentDealerSeatID())
+	assert.Equal(t, sbSeatID, sm.CurrentSBSeatID())
+	assert.Equal(t, bbSeatID, sm.CurrentBBSeatID())
+	active, err := sm.IsPlayerActive("P1")
+	assert.NoError(t, err)
+	assert.True(t, active)
+}
+
+func TestDefaultRule_MoveSeat(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 3,
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+	err = sm.JoinPlayers([]string{"P1", "P2"})
+	assert.NoError(t, err)
+
+	err = sm.MoveSeat("P1", 5)
+	assert.NoError(t, err)
+	assert.Nil(t, sm.Seats()[0])
+	assert.Equal(t, "P1", sm.Seats()[5].ID)
+	assert.True(t, sm.Seats()[5].IsIn)
+
+	err = sm.MoveSeat("P1", 3)
+	assert.ErrorIs(t, err, ErrSeatAlreadyIsTaken)
+
+	err = sm.MoveSeat("P1", maxSeat)
+	assert.ErrorIs(t, err, ErrSeatOutOfRange)
+
+	err = sm.MoveSeat("P3", 1)
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+}
+
+func TestDefaultRule_AfterInitPositions_MoveSeat_BetweenDealerBB(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 3,
+		"P3": 4,
+		"P4": 7,
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+	err = sm.JoinPlayers([]string{"P1", "P2", "P3", "P4"})
+	assert.NoError(t, err)
+	err = sm.InitPositions(false)
+	assert.NoError(t, err)
+
+	// same seats as new players in TestDefaultRule_AfterInitPositions_IsPlayerBetweenDealerBB
+	err = sm.MoveSeat("P4", 2)
+	assert.NoError(t, err)
+	assert.False(t, sm.IsPlayerBetweenDealerBB("P4"))
+
+	err = sm.MoveSeat("P4", 6)
+	assert.NoError(t, err)
+	assert.True(t, sm.IsPlayerBetweenDealerBB("P4"))
+	active, err := sm.IsPlayerActive("P4")
+	assert.NoError(t, err)
+	assert.False(t, active)
+}
+
 func verifySeatsAndPlayerPositions(t *testing.T, expectedSeatPositions map[string]int, expectedPlayerPositions map[string][]string, sm SeatManager) {
 	// check seats
 	assert.Equal(t, expectedSeatPositions[Position_Dealer], sm.CurrentDealerSeatID())
@@ -1719,3 +2031,48 @@ func verifySeatsAndPlayerPositions(t *testing.T, expectedSeatPositions map[strin
 		}
 	}
 }
+
+func TestDefaultRule_RotatePositions_CounterClockwise(t *testing.T) {
+	maxSeat := 9
+	rule := Rule_Default
+	playerSeatIDs := map[string]int{
+		"P1": 0,
+		"P2": 2,
+		"P3": 4,
+		"P4": 6,
+	}
+
+	sm := NewSeatManager(maxSeat, rule)
+	sm.SetRotationDirection(RotationDirection_CounterClockwise)
+	err := sm.AssignSeats(playerSeatIDs)
+	assert.NoError(t, err)
+	err = sm.JoinPlayers([]string{"P1", "P2", "P3", "P4"})
+	assert.NoError(t, err)
+
+	// sb & dealer sit to the bb's left
+	err = sm.InitPositions(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, sm.CurrentDealerSeatID())
+	assert.Equal(t, 2, sm.CurrentSBSeatID())
+	assert.Equal(t, 0, sm.CurrentBBSeatID())
+
+	// bb moves to the next lower seat, wrapping around
+	err = sm.RotatePositions()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sm.CurrentDealerSeatID())
+	assert.Equal(t, 0, sm.CurrentSBSeatID())
+	assert.Equal(t, 6, sm.CurrentBBSeatID())
+
+	err = sm.RotatePositions()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sm.CurrentDealerSeatID())
+	assert.Equal(t, 6, sm.CurrentSBSeatID())
+	assert.Equal(t, 4, sm.CurrentBBSeatID())
+
+	// a new player between dealer & bb waits for the bb
+	err = sm.AssignSeats(map[string]int{"P5": 5})
+	assert.NoError(t, err)
+	err = sm.JoinPlayers([]string{"P5"})
+	assert.NoError(t, err)
+	assert.True(t, sm.IsPlayerBetweenDealerBB("P5"))
+}
@@ -14,6 +14,7 @@ type seatManager struct {
 	SBSeatID     int                 `json:"sb_seat_id"`     // UnsetSeatID by default
 	BBSeatID     int                 `json:"bb_seat_id"`     // UnsetSeatID by default
 	Rule         string              `json:"rule"`           // default, short_deck
+	Direction    string              `json:"direction"`      // clockwise, counter_clockwise
 	IsInit       bool                `json:"is_init"`
 	mu           sync.RWMutex        `json:"-"`
 }
@@ -85,15 +86,22 @@ func (sm *seatManager) AssignSeats(playerSeatIDs map[string]int) error {
 		playerIDs[playerID] = true
 
 		// check seats
-		if _, exist := seats[seatID]; exist {
+		if seatID < 0 || seatID >= sm.MaxSeat {
 			sm.printState(2, func(tag int) {
+				fmt.Printf("[DEBUG#seatManager#AssignSeats#%d] seatID: %d, sm.MaxSeat: %d. Error: %+v\n", tag, seatID, sm.MaxSeat, ErrSeatOutOfRange)
+			})
+			return ErrSeatOutOfRange
+		}
+
+		if _, exist := seats[seatID]; exist {
+			sm.printState(3, func(tag int) {
 				fmt.Printf("[DEBUG#seatManager#AssignSeats#%d] seatID: %d. Error: %+v\n", tag, seatID, ErrDuplicateSeats)
 			})
 			return ErrDuplicateSeats
 		}
 
 		if seatPlayer, exist := sm.SeatData[seatID]; exist && seatPlayer != nil && seatPlayer.ID != playerID {
-			sm.printState(3, func(tag int) {
+			sm.printState(4, func(tag int) {
 				fmt.Printf("[DEBUG#seatManager#AssignSeats#%d] seatID: %d, seatPlayer.ID: %s. playerID: %s, Error: %+v\n", tag, seatID, seatPlayer.ID, playerID, ErrSeatAlreadyIsTaken)
 			})
 			return ErrSeatAlreadyIsTaken
@@ -103,7 +111,7 @@ func (sm *seatManager) AssignSeats(playerSeatIDs map[string]int) error {
 
 	for _, seatPlayer := range sm.SeatData {
 		if seatPlayer != nil && funk.Contains(playerIDs, seatPlayer.ID) {
-			sm.printState(4, func(tag int) {
+			sm.printState(5, func(tag int) {
 				fmt.Printf("[DEBUG#seatManager#AssignSeats#%d] seatPlayer.ID: %s. playerIDs: %+v. Error: %+v\n", tag, seatPlayer.ID, playerIDs, ErrDuplicatePlayers)
 			})
 			return ErrDuplicatePlayers
@@ -151,6 +159,46 @@ func (sm *seatManager) RemoveSeats(playerIDs []string) error {
 	return nil
 }
 
+func (sm *seatManager) MoveSeat(playerID string, targetSeatID int) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	seatPlayer, seatID, err := sm.getSeatPlayer(playerID)
+	if err != nil {
+		sm.printState(1, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#MoveSeat#%d][getSeatPlayer] playerID: %s. Error: %+v\n", tag, playerID, err)
+		})
+		return err
+	}
+
+	if targetSeatID < 0 || targetSeatID >= sm.MaxSeat {
+		sm.printState(2, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#MoveSeat#%d] targetSeatID: %d, sm.MaxSeat: %d. Error: %+v\n", tag, targetSeatID, sm.MaxSeat, ErrSeatOutOfRange)
+		})
+		return ErrSeatOutOfRange
+	}
+
+	if targetSeatID == seatID {
+		return nil
+	}
+
+	if sm.SeatData[targetSeatID] != nil {
+		sm.printState(3, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#MoveSeat#%d] targetSeatID: %d, seatPlayer.ID: %s. Error: %+v\n", tag, targetSeatID, sm.SeatData[targetSeatID].ID, ErrSeatAlreadyIsTaken)
+		})
+		return ErrSeatAlreadyIsTaken
+	}
+
+	// moving between dealer & bb waits for the bb as a new seat does, moving out never skips the wait
+	if sm.IsInit {
+		seatPlayer.IsBetweenDealerBB = seatPlayer.IsBetweenDealerBB || sm.isBetweenDealerBB(sm.CurrentDealerSeatID(), sm.CurrentBBSeatID(), targetSeatID)
+	}
+
+	sm.SeatData[seatID] = nil
+	sm.SeatData[targetSeatID] = seatPlayer
+	return nil
+}
+
 func (sm *seatManager) JoinPlayers(playerIDs []string) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -174,6 +222,29 @@ func (sm *seatManager) JoinPlayers(playerIDs []string) error {
 	return nil
 }
 
+func (sm *seatManager) SitOutPlayers(playerIDs []string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	targetPlayerSeatIDs := make([]int, 0)
+	for _, playerID := range playerIDs {
+		_, seatID, err := sm.getSeatPlayer(playerID)
+		if err != nil {
+			sm.printState(1, func(tag int) {
+				fmt.Printf("[DEBUG#seatManager#SitOutPlayers#%d][getSeatPlayer] playerID: %s, playerIDs: %+v. Error: %+v\n", tag, playerID, playerIDs, err)
+			})
+			return err
+		}
+		targetPlayerSeatIDs = append(targetPlayerSeatIDs, seatID)
+	}
+
+	for _, seatID := range targetPlayerSeatIDs {
+		sm.SeatData[seatID].IsIn = false
+	}
+
+	return nil
+}
+
 func (sm *seatManager) UpdatePlayerHasChips(playerID string, hasChips bool) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -190,6 +261,22 @@ func (sm *seatManager) UpdatePlayerHasChips(playerID string, hasChips bool) erro
 	return nil
 }
 
+func (sm *seatManager) UpdatePlayerWaitingBB(playerID string, isWaitingBB bool) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	_, seatID, err := sm.getSeatPlayer(playerID)
+	if err != nil {
+		sm.printState(1, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#UpdatePlayerWaitingBB#%d][getSeatPlayer] playerID: %s, isWaitingBB: %+v. Error: %+v\n", tag, playerID, isWaitingBB, err)
+		})
+		return err
+	}
+
+	sm.SeatData[seatID].IsWaitingBB = isWaitingBB
+	return nil
+}
+
 func (sm *seatManager) InitPositions(isRandom bool) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -216,6 +303,33 @@ func (sm *seatManager) InitPositions(isRandom bool) error {
 	return nil
 }
 
+// InitPositionsFromDealer places the dealer at dealerSeatID & the blinds after it (e.g. the button is drawn by high card)
+func (sm *seatManager) InitPositionsFromDealer(dealerSeatID int) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if !funk.Contains(SupportedRules, sm.Rule) {
+		sm.printState(1, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#InitPositionsFromDealer#%d] sm.Rule: %s, SupportedRules: %+v. Error: %+v\n", tag, sm.Rule, SupportedRules, ErrUnableToInitPositions)
+		})
+		return ErrUnableToInitPositions
+	}
+
+	if sm.IsInit {
+		sm.printState(2, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#InitPositionsFromDealer#%d] sm.IsInit: %+v. Error: %+v\n", tag, sm.IsInit, ErrAlreadyInitPositions)
+		})
+		return ErrAlreadyInitPositions
+	}
+
+	if err := sm.initPositionsFromDealer(dealerSeatID); err != nil {
+		return err
+	}
+
+	sm.IsInit = true
+	return nil
+}
+
 func (sm *seatManager) RotatePositions() error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -248,6 +362,17 @@ func (sm *seatManager) IsPlayerBetweenDealerBB(playerID string) bool {
 	return false
 }
 
+// SetRotationDirection sets which way positions rotate, unknown directions fall back to clockwise
+func (sm *seatManager) SetRotationDirection(direction string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if direction != RotationDirection_CounterClockwise {
+		direction = RotationDirection_Clockwise
+	}
+	sm.Direction = direction
+}
+
 func (sm *seatManager) Seats() map[int]*SeatPlayer {
 	return sm.SeatData
 }
@@ -288,14 +413,40 @@ func (sm *seatManager) ListPlayerSeatsFromDealer() []*SeatPlayer {
 	defer sm.mu.RUnlock()
 
 	seatPlayers := make([]*SeatPlayer, 0)
-	for i := sm.DealerSeatID; i < sm.MaxSeat+sm.DealerSeatID; i++ {
-		seatID := i % sm.MaxSeat
-		seatPlayers = append(seatPlayers, sm.SeatData[seatID])
+	for i := 0; i < sm.MaxSeat; i++ {
+		seatPlayers = append(seatPlayers, sm.SeatData[sm.seatIDFrom(sm.DealerSeatID, i)])
 	}
 
 	return seatPlayers
 }
 
+// Clone returns a deep copy, rotating the copy never changes the original
+func (sm *seatManager) Clone() SeatManager {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	seatData := make(map[int]*SeatPlayer, len(sm.SeatData))
+	for seatID, seatPlayer := range sm.SeatData {
+		if seatPlayer == nil {
+			seatData[seatID] = nil
+			continue
+		}
+		cloned := *seatPlayer
+		seatData[seatID] = &cloned
+	}
+
+	return &seatManager{
+		MaxSeat:      sm.MaxSeat,
+		SeatData:     seatData,
+		DealerSeatID: sm.DealerSeatID,
+		SBSeatID:     sm.SBSeatID,
+		BBSeatID:     sm.BBSeatID,
+		Rule:         sm.Rule,
+		Direction:    sm.Direction,
+		IsInit:       sm.IsInit,
+	}
+}
+
 func (sm *seatManager) IsHU() bool {
 	/*
 		HU conditions
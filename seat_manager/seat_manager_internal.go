@@ -31,6 +31,23 @@ func (sm *seatManager) isBetweenDealerBB(dealerSeatID, bbSeatID, targetSeatID in
 		return false
 	}
 
+	if sm.Direction == RotationDirection_CounterClockwise {
+		if dealerSeatID == bbSeatID {
+			return false
+		}
+
+		for i := 1; i < sm.MaxSeat; i++ {
+			seatID := sm.seatIDFrom(dealerSeatID, i)
+			if seatID == bbSeatID {
+				return false
+			}
+			if seatID == targetSeatID {
+				return true
+			}
+		}
+		return false
+	}
+
 	if bbSeatID-dealerSeatID < 0 {
 		for i := dealerSeatID + 1; i < (bbSeatID + sm.MaxSeat); i++ {
 			if i%sm.MaxSeat == targetSeatID {
@@ -119,9 +136,17 @@ func (sm *seatManager) newRandom() *rand.Rand {
 	return rand.New(source)
 }
 
+// seatIDFrom returns the seat id offset seats after startSeatID in the rotation direction, a negative offset goes backwards
+func (sm *seatManager) seatIDFrom(startSeatID, offset int) int {
+	if sm.Direction == RotationDirection_CounterClockwise {
+		offset = -offset
+	}
+	return ((startSeatID+offset)%sm.MaxSeat + sm.MaxSeat) % sm.MaxSeat
+}
+
 func (sm *seatManager) nextOccupiedSeatID(startSeatID int) int {
 	for i := 1; i < sm.MaxSeat; i++ {
-		seatID := (startSeatID + i) % 9
+		seatID := sm.seatIDFrom(startSeatID, i)
 		if sp, exist := sm.SeatData[seatID]; exist && sp != nil && sp.Active() {
 			return seatID
 		}
@@ -134,7 +159,7 @@ func (sm *seatManager) nextOccupiedSeatID(startSeatID int) int {
 
 func (sm *seatManager) nextInAndHasChipsSeatID(startSeatID int) int {
 	for i := 1; i < sm.MaxSeat; i++ {
-		seatID := (startSeatID + i) % 9
+		seatID := sm.seatIDFrom(startSeatID, i)
 		if sp, exist := sm.SeatData[seatID]; exist && sp != nil && sp.HasChips && sp.IsIn {
 			return seatID
 		}
@@ -147,7 +172,7 @@ func (sm *seatManager) nextInAndHasChipsSeatID(startSeatID int) int {
 
 func (sm *seatManager) previousOccupiedSeatID(startSeatID int, shouldActive bool) int {
 	for i := 1; i < sm.MaxSeat; i++ {
-		seatID := (startSeatID + 9 - i) % 9
+		seatID := sm.seatIDFrom(startSeatID, -i)
 		if sp, exist := sm.SeatData[seatID]; exist && sp != nil {
 			if shouldActive && sp.Active() {
 				return seatID
@@ -166,7 +191,7 @@ func (sm *seatManager) previousOccupiedSeatID(startSeatID int, shouldActive bool
 
 func (sm *seatManager) previousOccupiedAliveSeatID(startSeatID int) int {
 	for i := 1; i < sm.MaxSeat; i++ {
-		seatID := (startSeatID + 9 - i) % 9
+		seatID := sm.seatIDFrom(startSeatID, -i)
 		if sp, exist := sm.SeatData[seatID]; exist && sp != nil {
 			if sp.IsIn && sp.HasChips {
 				return seatID
@@ -278,6 +303,49 @@ func (sm *seatManager) initPositions(isRandom bool) error {
 	return nil
 }
 
+/*
+- 2 人常牌
+  - 指定的位置當作 Dealer & SB
+  - Dealer 下一個有坐人的玩家當作 BB
+
+- 超過 2 人常牌
+  - 指定的位置當作 Dealer
+  - Dealer 下一個有坐人的玩家當作 SB
+  - SB 下一個有坐人的玩家當作 BB
+
+- 短牌
+  - 指定的位置當作 Dealer
+*/
+func (sm *seatManager) initPositionsFromDealer(dealerSeatID int) error {
+	activeCount := sm.getActivePlayerCount()
+	if sp, exist := sm.SeatData[dealerSeatID]; activeCount < 2 || !exist || sp == nil || !sp.Active() {
+		sm.printState(1, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#initPositionsFromDealer#%d] activeCount: %d, dealerSeatID: %d. Error: %+v\n", tag, activeCount, dealerSeatID, ErrUnableToInitPositions)
+		})
+		return ErrUnableToInitPositions
+	}
+
+	sm.DealerSeatID = dealerSeatID
+	if sm.Rule == Rule_ShortDeck {
+		sm.SBSeatID = UnsetSeatID
+		sm.BBSeatID = UnsetSeatID
+		return nil
+	}
+
+	sm.SBSeatID = dealerSeatID
+	if activeCount > 2 {
+		sm.SBSeatID = sm.nextOccupiedSeatID(dealerSeatID)
+	}
+	sm.BBSeatID = sm.nextOccupiedSeatID(sm.SBSeatID)
+	if sm.SBSeatID == UnsetSeatID || sm.BBSeatID == UnsetSeatID {
+		sm.printState(2, func(tag int) {
+			fmt.Printf("[DEBUG#seatManager#initPositionsFromDealer#%d] sbSeatID: %d, bbSeatID: %d. Error: %+v\n", tag, sm.SBSeatID, sm.BBSeatID, ErrUnableToInitPositions)
+		})
+		return ErrUnableToInitPositions
+	}
+	return nil
+}
+
 /*
 - 2 人常牌
   - 新的 BB 必須要從原本 BB 往後尋找到第一個有籌碼的玩家
@@ -302,6 +370,11 @@ func (sm *seatManager) rotatePositions() error {
 		newBBSeatID := sm.nextInAndHasChipsSeatID(previousBBSeatID)
 		tempNewDealerSeatID := previousSBSeatID
 
+		// player waiting for bb is able to play from now on
+		if sp, exist := sm.SeatData[newBBSeatID]; exist && sp != nil {
+			sp.IsWaitingBB = false
+		}
+
 		// update seat_player.IsBetweenDealerBB before
 		for seatID, sp := range sm.Seats() {
 			if sp != nil && !sp.Active() {
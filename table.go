@@ -31,26 +31,123 @@ const (
 // }
 
 type TableMeta struct {
-	CompetitionID       string `json:"competition_id"`
-	Rule                string `json:"rule"`
-	Mode                string `json:"mode"`
-	MaxDuration         int    `json:"max_duration"`
-	TableMaxSeatCount   int    `json:"table_max_seat_count"`
-	TableMinPlayerCount int    `json:"table_min_player_count"`
-	MinChipUnit         int    `json:"min_chip_unit"`
-	ActionTime          int    `json:"action_time"`
+	CompetitionID          string `json:"competition_id"`
+	Rule                   string `json:"rule"`
+	Mode                   string `json:"mode"`
+	MaxDuration            int    `json:"max_duration"`
+	TableMaxSeatCount      int    `json:"table_max_seat_count"`
+	TableMinPlayerCount    int    `json:"table_min_player_count"`
+	MinChipUnit            int    `json:"min_chip_unit"`
+	ActionTime             int    `json:"action_time"`
+	Role                   string `json:"role"`                      // Table role for balancing (TableRole_*)
+	RotationDirection      string `json:"rotation_direction"`        // Direction the button & blinds move around the table (RotationDirection_*), clockwise by default
+	AutoRebuyToStack       int64  `json:"auto_rebuy_to_stack"`       // Cash mode: stack players with auto-rebuy are topped up to between hands, 0 disables
+	MaxBuyIn               int64  `json:"max_buy_in"`                // Cash mode: maximum stack a top-up may bring a player to, 0 means no limit
+	AutoPostBlinds         bool   `json:"auto_post_blinds"`          // Blinds are posted without waiting for PlayerPay
+	AddOnLevel             int    `json:"add_on_level"`              // Tournaments: add-ons are allowed during the break after this blind level, 0 disables add-ons
+	InitialButtonMode      string `json:"initial_button_mode"`       // How the button of the first hand is placed (InitialButtonMode_*), random by default
+	MaxReEntries           int    `json:"max_re_entries"`            // Tournaments: re-entries allowed per player after being eliminated, 0 disables re-entry
+	ReEntryEndLevel        int    `json:"re_entry_end_level"`        // Tournaments: re-entry closes after this blind level, 0 keeps it open
+	HoleCardsCount         int    `json:"hole_cards_count"`          // Hole cards dealt to each player, 0 uses the rule default
+	RequiredHoleCardsCount int    `json:"required_hole_cards_count"` // Hole cards a player must use in the best hand, 0 uses the rule default
 }
 
 type TableStateStatus string
 
 type TablePlayerState struct {
-	PlayerID       string                    `json:"player_id"`
-	Seat           int                       `json:"seat"`
-	Positions      []string                  `json:"positions"`
-	Bankroll       int64                     `json:"bankroll"`
-	IsIn           bool                      `json:"is_in"`           // Player has joined the table
-	IsParticipated bool                      `json:"is_participated"` // Player is participating in the current game
-	GameStatistics TablePlayerGameStatistics `json:"game_statistics"` // Player's game statistics
+	PlayerID         string                    `json:"player_id"`
+	Seat             int                       `json:"seat"`
+	Positions        []string                  `json:"positions"`
+	Bankroll         int64                     `json:"bankroll"`
+	IsIn             bool                      `json:"is_in"`               // Player has joined the table
+	IsParticipated   bool                      `json:"is_participated"`     // Player is participating in the current game
+	MustPostBB       bool                      `json:"must_post_bb"`        // Player joined mid-game and posts a dead big blind in the next game
+	MissedBB         bool                      `json:"missed_bb"`           // Player sat out while the BB passed their seat, see LateRegBlindRule
+	IsDisconnected   bool                      `json:"is_disconnected"`     // Player's connection is lost
+	TimeoutCount     int                       `json:"timeout_count"`       // Action timeouts in a row, reset on any voluntary action
+	IsAutoRebuy      bool                      `json:"is_auto_rebuy"`       // Player is topped up to AutoRebuyToStack between hands (cash mode)
+	BuyInTotal       int64                     `json:"buy_in_total"`        // Chips the player has bought in during the session (buy-in + rebuys)
+	AddOnCount       int                       `json:"add_on_count"`        // Add-ons the player has bought during the session
+	AddOnTotal       int64                     `json:"add_on_total"`        // Chips the player has bought with add-ons during the session
+	CashOutTotal     int64                     `json:"cash_out_total"`      // Chips the player has taken away when leaving during the session (PreserveSessionStats)
+	ReEntryCount     int                       `json:"re_entry_count"`      // Re-entries the player has made in the competition, see PlayerReEntry
+	SessionNet       int64                     `json:"session_net"`         // Player's profit during the session (Bankroll + CashOutTotal - BuyInTotal - AddOnTotal)
+	StackAtHandStart int64                     `json:"stack_at_hand_start"` // Bankroll when the latest hand was opened, before antes & blinds
+	GameStatistics   TablePlayerGameStatistics `json:"game_statistics"`     // Player's game statistics
+}
+
+type SessionStats struct {
+	PlayerID     string `json:"player_id"`
+	Bankroll     int64  `json:"bankroll"`
+	BuyInTotal   int64  `json:"buy_in_total"`
+	AddOnCount   int    `json:"add_on_count"`
+	AddOnTotal   int64  `json:"add_on_total"`
+	CashOutTotal int64  `json:"cash_out_total"`
+	SessionNet   int64  `json:"session_net"`
+}
+
+type SeatChange struct {
+	PlayerID string `json:"player_id"`
+	Seat     int    `json:"seat"`
+	JoinedAt int64  `json:"joined_at"` // Unix timestamp the player took the seat
+	LeftAt   int64  `json:"left_at"`   // Unix timestamp the player left the seat, 0 while still seated
+}
+
+type TableInsurance struct {
+	PlayerID string  `json:"player_id"`
+	Round    string  `json:"round"`    // Round closed when the insurance was bought
+	Equity   float64 `json:"equity"`   // Player's equity when the insurance was bought
+	Amount   int64   `json:"amount"`   // Premium, charged at settlement
+	Coverage int64   `json:"coverage"` // Paid at settlement if the player doesn't win the pot outright
+	Payout   int64   `json:"payout"`   // Coverage actually paid at settlement
+}
+
+type TableHealth struct {
+	Status                TableStateStatus `json:"status"`
+	IsHealthy             bool             `json:"is_healthy"`               // Engine is alive and no running hand is stuck
+	IsHandRunning         bool             `json:"is_hand_running"`          // A hand is opened, playing or being settled
+	IsHandProgressing     bool             `json:"is_hand_progressing"`      // A hand is running and the table was updated within the stall timeout
+	SecondsSinceUpdate    int64            `json:"seconds_since_update"`     // Seconds since the last table update (UpdateAt)
+	PendingActionPlayerID string           `json:"pending_action_player_id"` // Player to act, empty if nobody is
+	IsEngineAlive         bool             `json:"is_engine_alive"`          // Table isn't released and the game of a playing hand still handles states
+}
+
+type BoardTexture struct {
+	Board               []string `json:"board"`
+	IsPaired            bool     `json:"is_paired"`              // At least two board cards share a rank
+	IsMonotone          bool     `json:"is_monotone"`            // Every board card is of the same suit
+	IsTwoTone           bool     `json:"is_two_tone"`            // No more than two board cards of any suit, at least one suit twice
+	IsRainbow           bool     `json:"is_rainbow"`             // No two board cards share a suit
+	IsFlushPossible     bool     `json:"is_flush_possible"`      // Three or more board cards of the same suit
+	ConnectedCount      int      `json:"connected_count"`        // Most distinct board ranks within a five-rank straight window
+	IsStraightDrawHeavy bool     `json:"is_straight_draw_heavy"` // Three or more distinct ranks fit a straight window, a straight is possible
+}
+
+type RaiseSizing struct {
+	Pot                  int64 `json:"pot"`                     // Chips in the middle, including the wagers of the round
+	MinRaise             int64 `json:"min_raise"`               // Smallest legal bet or raise
+	HalfPotRaise         int64 `json:"half_pot_raise"`          // Raise by half the pot after calling
+	ThreeQuarterPotRaise int64 `json:"three_quarter_pot_raise"` // Raise by three quarters of the pot after calling
+	PotRaise             int64 `json:"pot_raise"`               // Raise by the pot after calling
+	AllIn                int64 `json:"all_in"`                  // Player's wager & stack
+}
+
+type PlayerHandResult struct {
+	PlayerID       string `json:"player_id"`
+	GamePlayerIdx  int    `json:"game_player_idx"`
+	SawFlop        bool   `json:"saw_flop"`         // Player was still in the hand when the flop was dealt
+	WentToShowdown bool   `json:"went_to_showdown"` // Player didn't fold & at least two players were left
+	Won            bool   `json:"won"`              // Player won more chips than put in the pots
+	Changed        int64  `json:"changed"`          // Chips won (positive) or lost (negative) in the pots, insurance excluded
+	Net            int64  `json:"net"`              // Bankroll - StackAtHandStart after the hand, insurance & settlement hook included
+}
+
+type PlayerStanding struct {
+	Rank        int    `json:"rank"` // 1-based, players with equal chips share a rank (1, 1, 3, ...)
+	PlayerID    string `json:"player_id"`
+	Seat        int    `json:"seat"`
+	Bankroll    int64  `json:"bankroll"`
+	ChipAverage int64  `json:"chip_average"` // Average bankroll of all players at the table
 }
 
 type TableState struct {
@@ -69,6 +166,11 @@ type TableState struct {
 	LastPlayerGameAction *TablePlayerGameAction `json:"last_player_game_action"`
 	CurrentActionEndAt   int64                  `json:"current_action_end_at"`
 	GameBlindState       *TableBlindState       `json:"game_blind_state"`
+	HandCommitment       string                 `json:"hand_commitment"` // Hash of the current hand's seed & deck, published when the hand starts
+	HandSeed             string                 `json:"hand_seed"`       // Seed of the current hand, revealed when the hand is settled
+	DealPayouts          map[string]int64       `json:"deal_payouts"`    // Agreed payouts (key: player id) overriding the tournament payout distribution, set by ApplyDeal
+	Insurances           []*TableInsurance      `json:"insurances"`      // All-in insurance bought in the current hand, settled apart from the pots
+	GlobalHandID         string                 `json:"global_hand_id"`  // Unique id of the current hand, unlike GameCount it never repeats across tables, merges & rebalances
 }
 
 type Table struct {
@@ -79,9 +181,9 @@ type Table struct {
 	UpdateSerial int         `json:"update_serial"` // Incremental update counter
 }
 
+// GamePlayerIndex returns the game player index of the player, UnsetValue if the player is not in the game
 func (t *Table) GamePlayerIndex(playerID string) int {
-	// Simplified implementation
-	return 0
+	return t.FindGamePlayerIdx(playerID)
 }
 
 func (t *Table) GetJSON() (string, error) {
@@ -92,6 +194,11 @@ func (t *Table) GetJSON() (string, error) {
 	return string(data), nil
 }
 
+// IsUnset returns true if the seat or index is UnsetValue
+func IsUnset(v int) bool {
+	return v == UnsetValue
+}
+
 // FindPlayerIdx finds player index by playerID
 func (t *Table) FindPlayerIdx(playerID string) int {
 	for i, playerState := range t.State.PlayerStates {
@@ -129,6 +236,17 @@ func (t *Table) PlayerSeatMap() map[string]int {
 	return playerSeatMap
 }
 
+// OpenSeats returns the seat indexes without a player, in ascending order
+func (t *Table) OpenSeats() []int {
+	openSeats := make([]int, 0)
+	for seat := 0; seat < t.Meta.TableMaxSeatCount; seat++ {
+		if playerIdx, exist := t.State.SeatMap[seat]; !exist || IsUnset(playerIdx) {
+			openSeats = append(openSeats, seat)
+		}
+	}
+	return openSeats
+}
+
 // AlivePlayers returns a list of players who have chips
 func (t *Table) AlivePlayers() []*TablePlayerState {
 	alivePlayers := make([]*TablePlayerState, 0)
@@ -156,8 +274,49 @@ func (t *Table) Clone() (*Table, error) {
 	return newTable, nil
 }
 
+// IsHandRunning returns true from opening a hand until the table continues to the next one
+func (t *Table) IsHandRunning() bool {
+	switch t.State.Status {
+	case TableStateStatus_TableGameOpened, TableStateStatus_TableGamePlaying, TableStateStatus_TableGameSettled:
+		return true
+	}
+	return false
+}
+
 // ShouldPause determines if the table should be paused
 func (t *Table) ShouldPause() bool {
 	// A simple implementation - could be enhanced based on actual logic
 	return t.State.BlindState != nil && t.State.BlindState.Level == -1
 }
+
+// addBuyIn adds buy-in or rebuy chips to the session stats
+func (tps *TablePlayerState) addBuyIn(chips int64) {
+	tps.BuyInTotal += chips
+	tps.updateSessionNet()
+}
+
+// addAddOn adds add-on chips to the session stats, apart from the buy-ins
+func (tps *TablePlayerState) addAddOn(chips int64) {
+	tps.AddOnCount++
+	tps.AddOnTotal += chips
+	tps.updateSessionNet()
+}
+
+// updateSessionNet recalculates the session profit from the current bankroll
+func (tps *TablePlayerState) updateSessionNet() {
+	tps.SessionNet = tps.Bankroll + tps.CashOutTotal - tps.BuyInTotal - tps.AddOnTotal
+}
+
+// clone creates a deep copy of the player state, the player state itself is returned if it can't be copied
+func (tps *TablePlayerState) clone() *TablePlayerState {
+	data, err := json.Marshal(tps)
+	if err != nil {
+		return tps
+	}
+
+	var player TablePlayerState
+	if err := json.Unmarshal(data, &player); err != nil {
+		return tps
+	}
+	return &player
+}
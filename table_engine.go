@@ -1,16 +1,22 @@
 package pokertable
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/settlement"
 	"github.com/d-protocol/pokertable/open_game_manager"
 	"github.com/d-protocol/pokertable/seat_manager"
 	"github.com/d-protocol/syncsaga"
 	"github.com/d-protocol/timebank"
+	"github.com/thoas/go-funk"
 )
 
 var (
@@ -20,12 +26,54 @@ var (
 	ErrTablePlayerInvalidGameAction            = errors.New("table: player invalid game action")
 	ErrTablePlayerInvalidAction                = errors.New("table: player invalid action")
 	ErrTablePlayerSeatUnavailable              = errors.New("table: player seat unavailable")
+	ErrTableSeatOccupied                       = errors.New("table: seat is already occupied")
+	ErrTableSeatOutOfRange                     = errors.New("table: seat is out of range")
 	ErrTableOpenGameFailed                     = errors.New("table: failed to open game")
 	ErrTableOpenGameFailedInBlindBreakingLevel = errors.New("table: unable to open game when blind level is breaking")
+	ErrTableOpenGameFailedNotEnoughPlayers     = errors.New("table: unable to open game with fewer than two players")
+	ErrTableHandCommitmentNotFound             = errors.New("table: hand commitment not found")
+	ErrTableHandBlindStateNotFound             = errors.New("table: hand blind state not found")
+	ErrTableHandHistoryNotFound                = errors.New("table: hand history not found")
+	ErrTableNoCurrentActor                     = errors.New("table: no player to act")
+	ErrTablePlayerNotParticipated              = errors.New("table: player is not participating in the current game")
+	ErrTableHoleCardsNotDealt                  = errors.New("table: hole cards are not dealt yet")
+	ErrTablePositionsNotInitialized            = errors.New("table: positions are not initialized yet")
+	ErrTableDealInvalidState                   = errors.New("table: deal can only be applied between hands")
+	ErrTableDealInvalidPlayers                 = errors.New("table: deal payouts must cover exactly the remaining players")
+	ErrTableDealPayoutMismatch                 = errors.New("table: deal payouts do not match the remaining chips")
+	ErrTableInvalidSeatAssignment              = errors.New("table: seat assigner must assign exactly the given players")
+	ErrTableChangeSeatInvalidState             = errors.New("table: seat can only be changed between hands")
+	ErrTableAlreadyStarted                     = errors.New("table: table game is already started")
+	ErrTablePlayerNoMissedBlind                = errors.New("table: player has no missed blind to post")
+	ErrTablePlayerNoInsuranceOffer             = errors.New("table: player has no insurance offer")
+	ErrTableInvalidInsuranceAmount             = errors.New("table: insurance amount is out of range")
+	ErrTableMisdealLimitReached                = errors.New("table: hand is misdealt too many times in a row")
+	ErrTableClosed                             = errors.New("table: table is closed")
+	ErrTableSwapGameBackendInvalidState        = errors.New("table: game backend can only be swapped between hands")
+	ErrTableInvalidRedeemChips                 = errors.New("table: redeem chips must be positive")
+	ErrTableInvalidParticipants                = errors.New("table: participant indexes must be unique & range from 0 to the participant count - 1")
+	ErrTableSeatsLocked                        = errors.New("table: seats are locked while a hand is in progress")
+	ErrTableNoRoundToAdvance                   = errors.New("table: no closed round to advance")
+	ErrTableAddOnNotAllowed                    = errors.New("table: add-on is only allowed during the break after the add-on level")
+	ErrTableInvalidButtonCards                 = errors.New("table: button draw must deal a valid card to every player")
+	ErrTableNotCreated                         = errors.New("table: table is not created yet")
+	ErrTableReEntryNotAllowed                  = errors.New("table: re-entry is not allowed")
+	ErrTableReEntryLimitReached                = errors.New("table: player has no re-entries left")
+	ErrTablePlayerNotEliminated                = errors.New("table: player still has chips")
+	ErrSettlementNoWinner                      = errors.New("table: settlement found no winner among the remaining players")
+	ErrChipConservationViolated                = errors.New("table: settled chips do not match the chips before the hand")
 )
 
 type TableEngineOpt func(*tableEngine)
 
+/*
+TableEngine runs a single table
+  - Concurrency: every action & getter that reads or mutates the table takes the table lock, so they are safe to call from any goroutine
+  - Callbacks fired by an action run while the lock is held, calling a locking method from them synchronously deadlocks (use a goroutine)
+  - Game states are handled on the game goroutine under the lock, their callbacks (e.g. OnTableUpdated with game events) are delivered once it is released
+    and may call locking methods, the tables & player states they receive are copies
+  - GetTable returns the live table, Clone it before reading it outside callbacks
+*/
 type TableEngine interface {
 	// Events
 	OnTableUpdated(fn func(table *Table))
@@ -36,30 +84,91 @@ type TableEngine interface {
 	OnGamePlayerActionUpdated(fn func(gameAction TablePlayerGameAction))
 	OnAutoGameOpenEnd(fn func(competitionID, tableID string))
 	OnReadyOpenFirstTableGame(fn func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState))
+	OnSeatOpened(fn func(tableID string, seat int))
+	OnRoundChanged(fn func(round string, board []string))
+	OnWalk(fn func(bbPlayerID string, amount int64))
+	OnDealApplied(fn func(payouts map[string]int64))
+	OnPlayerAutoReady(fn func(playerID string, phase string))
+	OnPlayerAutoSitOut(fn func(playerID string))
+	OnSeatMapChanged(fn func(tableID string, seatMap map[int]int))
+	OnTableStarved(fn func(tableID string))
+	OnInsufficientParticipants(fn func(count int))
+	OnInsuranceOffer(fn func(playerID string, equity float64, maxPayout int64))
+	OnMisdeal(fn func(gameCount int, reason string))
+	OnActionRequired(fn func(action ActionRequired))
+	OnBlindPosted(fn func(playerID string, position string, amount int64))
+	OnReadyProgress(fn func(ready, total int))
+	OnGameSettled(fn func(gameCount int, results []PlayerHandResult))
+	OnActionWarning(fn func(playerID string, secondsLeft int))
+	OnPlayerAddOn(fn func(playerID string, chips int64))
+	OnSettlementTimeout(fn func(pendingPlayerIDs []string))
 
 	// Other Actions
 	ReleaseTable() error
+	ErrorChannel() <-chan TableError // Stream of async errors, see ErrorChannel for drop behavior
 
 	// Table Actions
-	GetTable() *Table                                                                             // Get table
+	GetTable() *Table                                                                             // Get table (deprecated: live table, use GetTableSnapshot)
+	GetTableSnapshot() (*Table, error)                                                            // Get a copy of the table safe to read from any goroutine
+	WithReadSnapshot(fn func(t *Table)) error                                                     // Read a copy of the table taken under the table lock
 	GetGame() Game                                                                                // Get game engine
 	CreateTable(tableSetting TableSetting) (*Table, error)                                        // Create table
-	PauseTable() error                                                                            // Pause table
+	PauseTable() (bool, error)                                                                    // Pause table, returns true if deferred until the running hand settles
+	ApplyDeal(payouts map[string]int64) error                                                     // Apply a final table deal and close the table
 	CloseTable() error                                                                            // Close table
-	StartTableGame() error                                                                        // Start table game
+	ScheduleHardStop(at time.Time) error                                                          // Stop opening hands from the given time, the running hand finishes
+	SwapGameBackend(gb GameBackend) error                                                         // Replace the game backend from the next hand
+	AdvanceRound() error                                                                          // Deal the next round when ManualRoundAdvance is enabled
+	StartTableGame() error                                                                        // Start table game, returns ErrTableAlreadyStarted if already started
+	StartTableGameIfNotStarted() error                                                            // Start table game unless already started
 	UpdateBlind(level int, ante, dealer, sb, bb int64)                                            // Update current blind info
-	SetUpTableGame(gameCount int, participants map[string]int)                                    // Setup game
+	GetPendingBlindLevel() (int, bool)                                                            // Get blind level deferred until the running hand settles
+	GetLastOpenGameFailure() (reason string, attempts int)                                        // Get why the latest hand failed to open
+	GetSeatHistory() []SeatChange                                                                 // Get which players sat where over time
+	SetUpTableGame(gameCount int, participants map[string]int) error                              // Setup game
 	UpdateTablePlayers(joinPlayers []JoinPlayer, leavePlayerIDs []string) (map[string]int, error) // Update table players
+	GetHandCommitment(gameCount int) (string, error)                                              // Get hand commitment hash
+	GetHandBlindState(gameCount int) (*TableBlindState, error)                                    // Get the blinds a past hand was played at
+	ExportHandHistory(gameCount int) (string, error)                                              // Export a settled hand, see LoadHandHistory
+	GetOpenSeats() []int                                                                          // Get seat indexes without a player
+	GetNextBBOrder() []string                                                                     // Get next BB order player ids
+	PeekNextPositions() (dealer, sb, bb string, err error)                                        // Predict next hand dealer/sb/bb player ids
+	GetNextHandParticipants() []string                                                            // Get player ids to be dealt in the next hand
+	GetPlayerSessionStats(playerID string) (SessionStats, error)                                  // Get player session stats
+	GetStandings() []PlayerStanding                                                               // Get player standings by bankroll
+	IsPlayerTurn(playerID string) bool                                                            // Check if it's the player's turn to act
+	GetCurrentActorPlayerID() (string, error)                                                     // Get the player id to act
+	GetPlayerHoleCards(playerID string) ([]string, error)                                         // Get player hole cards (server-side only)
+	GetPlayerInvestment(playerID string) (int64, error)                                           // Get chips the player has put in this hand
+	GetPlayerButtonDistance(playerID string) (int, error)                                         // Get how many seats the player is from the button (0 = button)
+	GetBettingRound() (round string, bettingOpen bool, err error)                                 // Get the current round and whether betting is open
+	GetEffectiveStacks() (map[string]int64, error)                                                // Get the effective stack of every player in the hand
+	GetCurrentGlobalHandID() (string, error)                                                      // Get the unique id of the current hand
+	GetBoardTexture() (BoardTexture, error)                                                       // Get whether the board is paired, suited & connected
+	GetRaiseSizingOptions(playerID string) (RaiseSizing, error)                                   // Get the bet sizing presets of the player to act
+	GetCallAmount(playerID string) (int64, error)                                                 // Get the chips the player to act needs to call
+	GetReadyState() map[string]bool                                                               // Get who is ready in the running join or settlement ready phase
+	GetActionTimer() (playerID string, startAt, endAt int64, extended bool)                       // Get the action timer of the player to act
+	IsWalkSituation() (bbPlayerID string, isWalk bool)                                            // Check if everyone folded to the BB preflop
+	HealthCheck() TableHealth                                                                     // Get whether the table is alive and its hand progressing
 
 	// Player Table Actions
-	PlayerReserve(joinPlayer JoinPlayer) error     // Player reserve seat
-	PlayerJoin(playerID string) error              // Player join table
-	PlayerSettlementFinish(playerID string) error  // Player settlement complete
-	PlayerRedeemChips(joinPlayer JoinPlayer) error // Player redeem chips
-	PlayersLeave(playerIDs []string) error         // Players leave table
+	PlayerReserve(joinPlayer JoinPlayer) error              // Player reserve seat
+	PlayerJoin(playerID string) error                       // Player join table
+	PlayerSettlementFinish(playerID string) error           // Player settlement complete
+	PlayerRedeemChips(joinPlayer JoinPlayer) error          // Player redeem chips
+	PlayerAddOn(joinPlayer JoinPlayer) error                // Player buys a tournament add-on
+	PlayerReEntry(joinPlayer JoinPlayer) error              // Player re-enters the tournament after being eliminated
+	PlayersLeave(playerIDs []string) error                  // Players leave table
+	PlayerChangeSeat(playerID string, targetSeat int) error // Player moves to an empty seat
+	PlayerSetAutoRebuy(playerID string, enabled bool) error // Player turns auto top-up on or off (cash mode)
+	PlayerPostMissedBlind(playerID string) error            // Player posts the missed BB to be dealt in right away
+	PlayerDisconnect(playerID string) error                 // Player connection lost
+	PlayerReconnect(playerID string) error                  // Player connection restored
 
 	// Player Game Actions
 	PlayerExtendActionDeadline(playerID string, duration int) (int64, error) // Extend player action deadline
+	PlayerActionTimeout(playerID string) error                               // Player action time is up, check or fold
 	PlayerReady(playerID string) error                                       // Player ready
 	PlayerPay(playerID string, chips int64) error                            // Player pay
 	PlayerBet(playerID string, chips int64) error                            // Player bet
@@ -69,49 +178,136 @@ type TableEngine interface {
 	PlayerCheck(playerID string) error                                       // Player check
 	PlayerFold(playerID string) error                                        // Player fold
 	PlayerPass(playerID string) error                                        // Player pass
+	PlayerBuyInsurance(playerID string, amount int64) error                  // Player buys all-in insurance offered by OnInsuranceOffer
 }
 
 type tableEngine struct {
-	lock                      sync.Mutex
-	options                   *TableEngineOptions
-	table                     *Table
-	game                      Game
-	gameBackend               GameBackend
-	rg                        *syncsaga.ReadyGroup
-	tbForOpenGame             *timebank.TimeBank
-	sm                        seat_manager.SeatManager
-	ogm                       open_game_manager.OpenGameManager
-	onTableUpdated            func(table *Table)
-	onTableErrorUpdated       func(table *Table, err error)
-	onTableStateUpdated       func(event string, table *Table)
-	onTablePlayerStateUpdated func(competitionID, tableID string, playerState *TablePlayerState)
-	onTablePlayerReserved     func(competitionID, tableID string, playerState *TablePlayerState)
-	onGamePlayerActionUpdated func(gameAction TablePlayerGameAction)
-	onAutoGameOpenEnd         func(competitionID, tableID string)
-	onReadyOpenFirstTableGame func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState)
-	isReleased                bool
+	lock                       tableLock
+	options                    *TableEngineOptions
+	table                      *Table
+	game                       Game
+	gameBackend                GameBackend
+	rg                         *syncsaga.ReadyGroup
+	tbForOpenGame              *timebank.TimeBank
+	tbForBlind                 *timebank.TimeBank
+	tbForActionWarning         *timebank.TimeBank
+	blindLevels                []TableBlindState // upcoming blind levels
+	pendingBlind               *TableBlindState  // blind level deferred until the running hand settles
+	actionSequence             atomic.Int64      // last TablePlayerGameAction.Sequence
+	hardStopAt                 atomic.Int64      // Unix timestamp no hand opens from, 0 if no hard stop is scheduled, see ScheduleHardStop
+	isClosed                   atomic.Bool       // Table is closed, read by the game goroutine settling the last hand
+	seatsLocked                atomic.Bool       // Seats can't change from openGame until continueGame, see validateSeatsUnlocked
+	sm                         seat_manager.SeatManager
+	ogm                        open_game_manager.OpenGameManager
+	tableSnapshot              atomic.Pointer[Table] // copy of the table as of the latest event, see GetTableSnapshot
+	onTableUpdated             func(table *Table)
+	onTableErrorUpdated        func(table *Table, err error)
+	onTableStateUpdated        func(event string, table *Table)
+	onTablePlayerStateUpdated  func(competitionID, tableID string, playerState *TablePlayerState)
+	onTablePlayerReserved      func(competitionID, tableID string, playerState *TablePlayerState)
+	onGamePlayerActionUpdated  func(gameAction TablePlayerGameAction)
+	onAutoGameOpenEnd          func(competitionID, tableID string)
+	onReadyOpenFirstTableGame  func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState)
+	onSeatOpened               func(tableID string, seat int)
+	onRoundChanged             func(round string, board []string)
+	onWalk                     func(bbPlayerID string, amount int64)
+	onDealApplied              func(payouts map[string]int64)
+	onPlayerAutoReady          func(playerID string, phase string)
+	onPlayerAutoSitOut         func(playerID string)
+	onSeatMapChanged           func(tableID string, seatMap map[int]int)
+	onTableStarved             func(tableID string)
+	onInsufficientParticipants func(count int)
+	onInsuranceOffer           func(playerID string, equity float64, maxPayout int64)
+	onMisdeal                  func(gameCount int, reason string)
+	onActionRequired           func(action ActionRequired)
+	onBlindPosted              func(playerID string, position string, amount int64)
+	onReadyProgress            func(ready, total int)
+	onGameSettled              func(gameCount int, results []PlayerHandResult)
+	onActionWarning            func(playerID string, secondsLeft int)
+	onPlayerAddOn              func(playerID string, chips int64)
+	onSettlementTimeout        func(pendingPlayerIDs []string)
+	isReleased                 atomic.Bool // Table is released, read by the timer & game goroutines
+	handSeed                   string
+	handCommitments            sync.Map                // key: game_count, value: commitment
+	handBlindStates            sync.Map                // key: game_count, value: *TableBlindState the hand was played at
+	handHistories              sync.Map                // key: game_count, value: *HandHistory of the settled hand
+	handRetention              int                     // hands kept in handCommitments, handBlindStates, handHistories & the backend seeds, see WithHandRetention
+	retainedHands              []retainedHand          // retained hands from the oldest, only with a hand retention
+	leftSessionStats           map[string]SessionStats // key: player_id, session stats of players who left the table
+	reEntries                  *ReEntryLedger          // eliminated players & re-entries of the competition, see WithReEntryLedger
+	errCh                      chan TableError
+	roundChangedGameID         string // game id of the last round changed detection
+	lastRound                  string // round of the last round changed detection
+	seatAssigner               func(table *Table, playerIDs []string) (map[string]int, error)
+	settlementHook             func(result *settlement.Result, table *Table) error
+	dealerSelector             DealerSelector
+	statisticsDisabled         bool       // skips live game statistics, see WithStatisticsDisabled
+	chipConservationCheck      bool       // verifies the chips of every settled hand, see WithChipConservationCheck
+	coalescedEvents            bool       // one OnTableUpdated per lock section, see WithCoalescedEvents
+	eventBatchLock             sync.Mutex // guards pendingEventSerial & emittedEventSerial
+	pendingEventSerial         int        // UpdateSerial of the latest emission held back under the lock
+	emittedEventSerial         int        // UpdateSerial of the latest OnTableUpdated
+	openGameFailureLock        sync.Mutex // tableGameOpen holds te.lock while retrying
+	openGameFailureReasons     []string   // distinct reasons the latest hand failed to open
+	openGameFailureAttempts    int        // failed attempts to open the latest hand
+	pauseRequested             bool       // pause deferred until the running hand settles, guarded by te.lock
+	seatHistoryLock            sync.Mutex
+	seatHistory                []SeatChange               // append-only seat occupancy, see GetSeatHistory
+	insuranceOffers            map[string]*insuranceOffer // key: player_id, offers valid until the next card is dealt
+	misdealCount               int                        // misdeals in a row of the current hand
+	actionTimerStartAt         int64                      // Unix timestamp the current action timer started at
+	isActionTimerExtended      bool                       // current action deadline is extended, see PlayerExtendActionDeadline
+	handChips                  int64                      // chips the game players brought into the current hand
+	deadBlinds                 map[int]int64              // key: game player index, dead big blinds posted into the current hand
+	breakAfterBlindLevel       int                        // blind level the latest break follows, see PlayerAddOn
+	handHistory                *HandHistory               // history of the current hand, stored in handHistories when settled
 }
 
 func NewTableEngine(options *TableEngineOptions, opts ...TableEngineOpt) TableEngine {
 	callbacks := NewTableEngineCallbacks()
 	te := &tableEngine{
-		options:                   options,
-		rg:                        syncsaga.NewReadyGroup(),
-		tbForOpenGame:             timebank.NewTimeBank(),
-		onTableUpdated:            callbacks.OnTableUpdated,
-		onTableErrorUpdated:       callbacks.OnTableErrorUpdated,
-		onTableStateUpdated:       callbacks.OnTableStateUpdated,
-		onTablePlayerStateUpdated: callbacks.OnTablePlayerStateUpdated,
-		onTablePlayerReserved:     callbacks.OnTablePlayerReserved,
-		onGamePlayerActionUpdated: callbacks.OnGamePlayerActionUpdated,
-		onAutoGameOpenEnd:         callbacks.OnAutoGameOpenEnd,
-		onReadyOpenFirstTableGame: callbacks.OnReadyOpenFirstTableGame,
-		isReleased:                false,
+		options:                    options,
+		rg:                         syncsaga.NewReadyGroup(),
+		tbForOpenGame:              timebank.NewTimeBank(),
+		tbForBlind:                 timebank.NewTimeBank(),
+		tbForActionWarning:         timebank.NewTimeBank(),
+		onTableUpdated:             callbacks.OnTableUpdated,
+		onTableErrorUpdated:        callbacks.OnTableErrorUpdated,
+		onTableStateUpdated:        callbacks.OnTableStateUpdated,
+		onTablePlayerStateUpdated:  callbacks.OnTablePlayerStateUpdated,
+		onTablePlayerReserved:      callbacks.OnTablePlayerReserved,
+		onGamePlayerActionUpdated:  callbacks.OnGamePlayerActionUpdated,
+		onAutoGameOpenEnd:          callbacks.OnAutoGameOpenEnd,
+		onReadyOpenFirstTableGame:  callbacks.OnReadyOpenFirstTableGame,
+		onSeatOpened:               callbacks.OnSeatOpened,
+		onRoundChanged:             callbacks.OnRoundChanged,
+		onWalk:                     callbacks.OnWalk,
+		onDealApplied:              callbacks.OnDealApplied,
+		onPlayerAutoReady:          callbacks.OnPlayerAutoReady,
+		onPlayerAutoSitOut:         callbacks.OnPlayerAutoSitOut,
+		onSeatMapChanged:           callbacks.OnSeatMapChanged,
+		onTableStarved:             callbacks.OnTableStarved,
+		onInsufficientParticipants: callbacks.OnInsufficientParticipants,
+		onInsuranceOffer:           callbacks.OnInsuranceOffer,
+		onMisdeal:                  callbacks.OnMisdeal,
+		onActionRequired:           callbacks.OnActionRequired,
+		onBlindPosted:              callbacks.OnBlindPosted,
+		onReadyProgress:            callbacks.OnReadyProgress,
+		onGameSettled:              callbacks.OnGameSettled,
+		onActionWarning:            callbacks.OnActionWarning,
+		onPlayerAddOn:              callbacks.OnPlayerAddOn,
+		onSettlementTimeout:        callbacks.OnSettlementTimeout,
+		leftSessionStats:           make(map[string]SessionStats),
+		reEntries:                  NewReEntryLedger(),
+		errCh:                      make(chan TableError, TableErrorChannelSize),
+		dealerSelector:             NewDefaultDealerSelector(),
+		handRetention:              options.HandRetention,
 	}
 
 	for _, opt := range opts {
 		opt(te)
 	}
+	te.gameBackend = withBackendCallTimeout(te.gameBackend, te.backendCallTimeout())
 
 	return te
 }
@@ -122,6 +318,108 @@ func WithGameBackend(gb GameBackend) TableEngineOpt {
 	}
 }
 
+/*
+WithReEntryLedger shares the record of eliminated players & re-entries with other tables
+  - Use case: Multi-table tournaments, MaxReEntries counts across tables & rebalances
+  - Defaults to a ledger of this table only
+*/
+func WithReEntryLedger(l *ReEntryLedger) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.reEntries = l
+	}
+}
+
+/*
+WithSeatAssigner sets a custom seating strategy for players without a requested seat
+  - Use case: Operators balance by stack or keep suspected colluders apart
+  - Replaces the seat manager's random assignment, the returned seats are validated against available seats
+  - Players with a requested seat in the same batch are seated first but are not in the table state passed to fn yet
+*/
+func WithSeatAssigner(fn func(table *Table, playerIDs []string) (map[string]int, error)) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.seatAssigner = fn
+	}
+}
+
+/*
+WithDealerSelector sets a custom dealer seat selection for ordering game players
+  - Use case: Custom variants & auditing how a dead button picks its fake dealer
+  - Defaults to NewDefaultDealerSelector
+*/
+func WithDealerSelector(ds DealerSelector) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.dealerSelector = ds
+	}
+}
+
+/*
+WithStatisticsDisabled skips computing live game statistics (VPIP, PFR, ATS, ...) on every state update
+  - Use case: High-throughput deployments without HUD stats
+  - Players' GameStatistics keep their default values, hands are settled as usual
+*/
+func WithStatisticsDisabled() TableEngineOpt {
+	return func(te *tableEngine) {
+		te.statisticsDisabled = true
+	}
+}
+
+/*
+WithHandRetention keeps the records of the latest hands only
+  - Use case: Long-running tables, overrides TableEngineOptions.HandRetention (tables created by the Manager take the options)
+  - Applies to GetHandCommitment, GetHandBlindState, ExportHandHistory & the seeds kept by the backend (NativeGameBackend.ReleaseSeed)
+  - Not positive hands keep every hand
+*/
+func WithHandRetention(hands int) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.handRetention = hands
+	}
+}
+
+/*
+WithChipConservationCheck verifies that no chips are created or lost by the settlement of every hand
+  - Use case: Catching settlement bugs in testing & staging
+  - The bankrolls of the game players after the settlement (rounding, insurance & settlement hook applied) plus the rake
+    must equal their chips before the hand plus what the insurance paid out net of premiums
+  - The table takes no rake, what the game result keeps from the chips brought in is counted as the backend's rake
+  - A violation is reported as ErrChipConservationViolated through OnTableErrorUpdated & ErrorChannel, the result is still applied
+*/
+func WithChipConservationCheck() TableEngineOpt {
+	return func(te *tableEngine) {
+		te.chipConservationCheck = true
+	}
+}
+
+/*
+WithSettlementHook adjusts the settlement of every hand after the standard settlement
+  - Use case: Custom formats (e.g. bounty tournaments awarding the bounty of eliminated players)
+  - The hook receives the game result & the table with the settled bankrolls, it may change the bankrolls
+  - Runs under the table lock with the rest of the settlement (rounding, dead blinds, insurance & chip conservation check)
+  - The hook must not call locking TableEngine methods, the lock is not reentrant & the call deadlocks
+  - Not called when the settlement keeps the bankrolls before the hand (ErrSettlementNoWinner)
+  - An error is reported through OnTableErrorUpdated & ErrorChannel, changes made by the hook are kept
+*/
+func WithSettlementHook(fn func(result *settlement.Result, table *Table) error) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.settlementHook = fn
+	}
+}
+
+/*
+WithCoalescedEvents emits a single OnTableUpdated for all the table updates made while the table lock is held
+  - Use case: Bandwidth-sensitive clients
+  - Coalescing is per lock section, not per method call: a method taking the lock more than once emits once per section
+  - Game states are handled under the lock, the updates of each game state are coalesced too
+  - The held back updates are delivered with a copy of the table as of the latest update (see GetTableSnapshot), not the live table
+  - UpdateSerial still increases on every update, an update already delivered by a later emission is not delivered again
+  - Updates emitted without the lock (e.g. the pause between hands) are delivered one by one with the live table as usual
+*/
+func WithCoalescedEvents() TableEngineOpt {
+	return func(te *tableEngine) {
+		te.coalescedEvents = true
+		te.lock.onUnlock = te.flushCoalescedEvents
+	}
+}
+
 func (te *tableEngine) OnTableUpdated(fn func(*Table)) {
 	te.onTableUpdated = fn
 }
@@ -154,33 +452,164 @@ func (te *tableEngine) OnReadyOpenFirstTableGame(fn func(competitionID, tableID
 	te.onReadyOpenFirstTableGame = fn
 }
 
+func (te *tableEngine) OnSeatOpened(fn func(tableID string, seat int)) {
+	te.onSeatOpened = fn
+}
+
+func (te *tableEngine) OnRoundChanged(fn func(round string, board []string)) {
+	te.onRoundChanged = fn
+}
+
+func (te *tableEngine) OnWalk(fn func(bbPlayerID string, amount int64)) {
+	te.onWalk = fn
+}
+
+func (te *tableEngine) OnDealApplied(fn func(payouts map[string]int64)) {
+	te.onDealApplied = fn
+}
+
+func (te *tableEngine) OnPlayerAutoReady(fn func(playerID string, phase string)) {
+	te.onPlayerAutoReady = fn
+}
+
+func (te *tableEngine) OnPlayerAutoSitOut(fn func(playerID string)) {
+	te.onPlayerAutoSitOut = fn
+}
+
+func (te *tableEngine) OnSeatMapChanged(fn func(tableID string, seatMap map[int]int)) {
+	te.onSeatMapChanged = fn
+}
+
+func (te *tableEngine) OnTableStarved(fn func(tableID string)) {
+	te.onTableStarved = fn
+}
+
+func (te *tableEngine) OnInsufficientParticipants(fn func(count int)) {
+	te.onInsufficientParticipants = fn
+}
+
+func (te *tableEngine) OnInsuranceOffer(fn func(playerID string, equity float64, maxPayout int64)) {
+	te.onInsuranceOffer = fn
+}
+
+func (te *tableEngine) OnMisdeal(fn func(gameCount int, reason string)) {
+	te.onMisdeal = fn
+}
+
+func (te *tableEngine) OnActionRequired(fn func(action ActionRequired)) {
+	te.onActionRequired = fn
+}
+
+func (te *tableEngine) OnBlindPosted(fn func(playerID string, position string, amount int64)) {
+	te.onBlindPosted = fn
+}
+
+func (te *tableEngine) OnReadyProgress(fn func(ready, total int)) {
+	te.onReadyProgress = fn
+}
+
+func (te *tableEngine) OnGameSettled(fn func(gameCount int, results []PlayerHandResult)) {
+	te.onGameSettled = fn
+}
+
+func (te *tableEngine) OnActionWarning(fn func(playerID string, secondsLeft int)) {
+	te.onActionWarning = fn
+}
+
+func (te *tableEngine) OnPlayerAddOn(fn func(playerID string, chips int64)) {
+	te.onPlayerAddOn = fn
+}
+
+func (te *tableEngine) OnSettlementTimeout(fn func(pendingPlayerIDs []string)) {
+	te.onSettlementTimeout = fn
+}
+
 func (te *tableEngine) ReleaseTable() error {
-	te.isReleased = true
+	te.isReleased.Store(true)
+	te.tbForBlind.Cancel()
+	te.tbForActionWarning.Cancel()
 	return nil
 }
 
+/*
+ErrorChannel returns the stream of async errors (same errors as OnTableErrorUpdated)
+  - Use case: Callers without OnTableErrorUpdated callback can still observe failures
+  - The channel is buffered (TableErrorChannelSize), errors are dropped when the buffer is full
+  - The channel is never closed
+*/
+func (te *tableEngine) ErrorChannel() <-chan TableError {
+	return te.errCh
+}
+
+/*
+GetTable gets the live table the engine mutates
+
+Deprecated: reading the returned table races with the engine, use GetTableSnapshot (callbacks already receive the table)
+*/
 func (te *tableEngine) GetTable() *Table {
 	return te.table
 }
 
+/*
+GetTableSnapshot gets a copy of the table as of the latest event (the table passed to OnTableUpdated)
+  - Use case: Reading the table from any goroutine while hands are played
+  - The copy is taken by the goroutine emitting the event, readers never touch the table the engine mutates
+  - Every call returns a new copy, changes to it don't affect the table
+*/
+func (te *tableEngine) GetTableSnapshot() (*Table, error) {
+	snapshot := te.tableSnapshot.Load()
+	if snapshot == nil {
+		return nil, ErrTableNotCreated
+	}
+	return snapshot.Clone()
+}
+
+/*
+WithReadSnapshot calls fn with a copy of the table taken under the table lock
+  - Use case: Analytics reading a consistent mid-hand table, e.g. between two player actions
+  - Unlike GetTableSnapshot the copy is the table as of now, not as of the latest event
+  - The lock is only held to copy the table, fn runs after it is released & may call locking methods
+*/
+func (te *tableEngine) WithReadSnapshot(fn func(t *Table)) error {
+	te.lock.Lock()
+	if te.table == nil {
+		te.lock.Unlock()
+		return ErrTableNotCreated
+	}
+	snapshot, err := te.table.Clone()
+	te.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	fn(snapshot)
+	return nil
+}
+
 func (te *tableEngine) GetGame() Game {
 	return te.game
 }
 
 func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 	// validate tableSetting
-	if len(tableSetting.JoinPlayers) > tableSetting.Meta.TableMaxSeatCount {
-		return nil, ErrTableInvalidCreateSetting
+	if err := tableSetting.Validate(); err != nil {
+		return nil, err
 	}
 
 	// init seat manager
 	te.sm = seat_manager.NewSeatManager(tableSetting.Meta.TableMaxSeatCount, tableSetting.Meta.Rule)
 
 	// init open game manager
+	openGameTimeout := te.options.OpenGameTimeout
+	if openGameTimeout <= 0 {
+		openGameTimeout = NewTableEngineOptions().OpenGameTimeout
+	}
 	te.ogm = open_game_manager.NewOpenGameManager(open_game_manager.OpenGameOption{
-		Timeout: 2,
+		Timeout: openGameTimeout,
 		OnOpenGameReady: func(state open_game_manager.OpenGameState) {
-			if len(state.Participants) <= 1 {
+			// Not enough participants, wait for more players to join
+			if count := len(state.Participants); count < te.minOpenGameParticipantCount() {
+				te.emitInsufficientParticipantsEvent(count)
 				return
 			}
 
@@ -189,6 +618,10 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 				te.emitErrorEvent("OnOpenGameReady#tableGameOpen", "", err)
 			}
 		},
+		OnOpenGameTimeout: func(pendingParticipantIDs []string) {
+			// players never acknowledged the settlement, they are readied & the next hand opens anyway
+			te.emitSettlementTimeoutEvent(pendingParticipantIDs)
+		},
 	})
 
 	// create table instance
@@ -198,6 +631,13 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 
 	// configure meta
 	table.Meta = tableSetting.Meta
+	if table.Meta.Role == "" {
+		table.Meta.Role = TableRole_Normal
+	}
+	if table.Meta.RotationDirection != RotationDirection_CounterClockwise {
+		table.Meta.RotationDirection = RotationDirection_Clockwise
+	}
+	te.sm.SetRotationDirection(table.Meta.RotationDirection)
 
 	// configure state
 	status := TableStateStatus(TableStateStatus_TableCreated)
@@ -223,6 +663,10 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 	te.emitEvent("CreateTable", "")
 	te.emitTableStateEvent(TableStateEvent_Created)
 
+	// schedule blind level transitions
+	te.blindLevels = append([]TableBlindState{}, tableSetting.BlindLevels...)
+	te.scheduleBlindLevelEnd()
+
 	// handle auto join players
 	if len(tableSetting.JoinPlayers) > 0 {
 		if err := te.batchAddPlayers(tableSetting.JoinPlayers); err != nil {
@@ -244,11 +688,22 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 /*
 PauseTable pauses the table
   - Use case: External pausing of auto game opening
+  - Pauses immediately between hands
+  - While a hand is running, the pause is deferred until the hand settles (returns true)
+  - A deferred pause doesn't change the table, it is only seen as the status update once the hand settles
 */
-func (te *tableEngine) PauseTable() error {
+func (te *tableEngine) PauseTable() (bool, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.IsHandRunning() {
+		te.pauseRequested = true
+		return true, nil
+	}
+
 	te.table.State.Status = TableStateStatus_TablePausing
 	te.emitTableStateEvent(TableStateEvent_StatusUpdated)
-	return nil
+	return false, nil
 }
 
 /*
@@ -256,58 +711,831 @@ CloseTable closes the table
   - Use cases: Forced close, auto close due to timeout, normal close
 */
 func (te *tableEngine) CloseTable() error {
-	te.table.State.Status = TableStateStatus_TableClosed
-	te.ReleaseTable()
+	te.lock.Lock()
+	defer te.lock.Unlock()
 
-	te.emitEvent("CloseTable", "")
-	te.emitTableStateEvent(TableStateEvent_StatusUpdated)
+	return te.closeTable()
+}
+
+/*
+ApplyDeal applies a final table deal (e.g. chip-chop) and closes the table
+  - Use case: Remaining players agree to split the prize instead of playing on
+  - Only between hands (settled/standby), payouts must cover exactly the players with chips
+  - Payouts must sum to the remaining chips
+*/
+func (te *tableEngine) ApplyDeal(payouts map[string]int64) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.Status != TableStateStatus_TableGameSettled && te.table.State.Status != TableStateStatus_TableGameStandby {
+		return ErrTableDealInvalidState
+	}
+
+	alivePlayers := te.table.AlivePlayers()
+	if len(payouts) != len(alivePlayers) {
+		return ErrTableDealInvalidPlayers
+	}
+
+	totalChips, totalPayouts := int64(0), int64(0)
+	for _, player := range alivePlayers {
+		payout, exist := payouts[player.PlayerID]
+		if !exist || payout < 0 {
+			return ErrTableDealInvalidPlayers
+		}
+		totalChips += player.Bankroll
+		totalPayouts += payout
+	}
+	if totalPayouts != totalChips {
+		return ErrTableDealPayoutMismatch
+	}
+
+	dealPayouts := make(map[string]int64, len(payouts))
+	for playerID, payout := range payouts {
+		dealPayouts[playerID] = payout
+	}
+	te.table.State.DealPayouts = dealPayouts
+	te.emitDealAppliedEvent(dealPayouts)
+
+	return te.closeTable()
+}
+
+/*
+ScheduleHardStop stops the table from opening new hands from the given time
+  - Use case: Venue closing, unlike CloseTable the running hand is played to the end
+  - The first hand settled at or after the time is the last one, then OnAutoGameOpenEnd fires
+  - A time in the past stops the table after the running hand
+*/
+func (te *tableEngine) ScheduleHardStop(at time.Time) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.Status == TableStateStatus_TableClosed || te.isReleased.Load() {
+		return ErrTableClosed
+	}
+
+	te.hardStopAt.Store(at.Unix())
+	te.emitEvent("ScheduleHardStop", at.String())
+	return nil
+}
+
+/*
+SwapGameBackend replaces the game backend, the next dealt hand uses it
+  - Use case: Failing over from a remote backend that is down to NativeGameBackend
+  - Rejected while a hand is played or settled, a hand that failed to start may be dealt again with the new backend
+*/
+func (te *tableEngine) SwapGameBackend(gb GameBackend) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	switch te.table.State.Status {
+	case TableStateStatus_TableGamePlaying, TableStateStatus_TableGameSettled:
+		return ErrTableSwapGameBackendInvalidState
+	}
+
+	te.gameBackend = withBackendCallTimeout(gb, te.backendCallTimeout())
+	te.emitEvent("SwapGameBackend", "")
+	return nil
+}
+
+/*
+AdvanceRound deals the next round of the running hand
+  - Use case: Clients pacing the reveal of the board with TableEngineOptions.ManualRoundAdvance
+  - Returns ErrTableNoRoundToAdvance unless the hand waits at a closed round
+*/
+func (te *tableEngine) AdvanceRound() error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || te.game == nil {
+		return ErrTableNoRoundToAdvance
+	}
+
+	gs := te.game.GetGameState()
+	if gs == nil || gs.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundClosed] {
+		return ErrTableNoRoundToAdvance
+	}
+
+	if _, err := te.game.Next(); err != nil {
+		return err
+	}
+
+	te.emitEvent("AdvanceRound", "")
+	return nil
+}
+
+/*
+StartTableGame starts the table game
+  - Returns ErrTableAlreadyStarted if the table game is already started, see StartTableGameIfNotStarted
+*/
+func (te *tableEngine) StartTableGame() error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.StartAt != UnsetValue {
+		return ErrTableAlreadyStarted
+	}
+	te.startTableGame()
+	return nil
+}
+
+/*
+StartTableGameIfNotStarted starts the table game unless it is already started
+  - Use case: callers that may start the same table more than once, e.g. retries
+*/
+func (te *tableEngine) StartTableGameIfNotStarted() error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.StartAt != UnsetValue {
+		return nil
+	}
+	te.startTableGame()
 	return nil
 }
 
-func (te *tableEngine) StartTableGame() error {
-	if te.table.State.StartAt != UnsetValue {
-		fmt.Println("[DEBUG#StartTableGame] Table game is already started.")
-		return nil
+/*
+UpdateBlind updates the blind info
+  - Applied immediately between hands
+  - Deferred until the running hand settles otherwise, see GetPendingBlindLevel
+*/
+func (te *tableEngine) UpdateBlind(level int, ante, dealer, sb, bb int64) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	blind := *te.latestBlindState()
+	blind.Level = level
+	blind.Ante = ante
+	blind.Dealer = dealer
+	blind.SB = sb
+	blind.BB = bb
+	te.setBlindState(blind)
+}
+
+/*
+GetPendingBlindLevel gets the blind level waiting for the running hand to settle
+  - Returns false if there is no pending blind level
+*/
+func (te *tableEngine) GetPendingBlindLevel() (int, bool) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.pendingBlind == nil {
+		return 0, false
+	}
+	return te.pendingBlind.Level, true
+}
+
+/*
+GetLastOpenGameFailure gets why the latest hand failed to open
+  - Use case: Operators diagnose a stuck table
+  - reason: distinct failure reasons across retries, joined by "; "
+  - attempts: failed attempts, 0 if the latest hand opened at the first attempt
+*/
+func (te *tableEngine) GetLastOpenGameFailure() (reason string, attempts int) {
+	te.openGameFailureLock.Lock()
+	defer te.openGameFailureLock.Unlock()
+
+	return strings.Join(te.openGameFailureReasons, "; "), te.openGameFailureAttempts
+}
+
+/*
+GetSeatHistory gets a copy of the seat occupancy history, oldest first
+  - Use case: Security teams analyze who sat next to whom for collusion
+  - Entries are added on join & seat change, and closed (LeftAt) on leave & seat change
+*/
+func (te *tableEngine) GetSeatHistory() []SeatChange {
+	te.seatHistoryLock.Lock()
+	defer te.seatHistoryLock.Unlock()
+
+	seatHistory := make([]SeatChange, len(te.seatHistory))
+	copy(seatHistory, te.seatHistory)
+	return seatHistory
+}
+
+/*
+SetUpTableGame sets up a specific hand
+  - Use cases:
+    1. After game start, preparing the first hand
+    2. At the end of each hand, in the Continue phase, preparing the next hand
+  - The participant indexes must be a permutation of 0 to the participant count - 1, otherwise ErrTableInvalidParticipants
+*/
+func (te *tableEngine) SetUpTableGame(gameCount int, participants map[string]int) error {
+	indexed := make([]bool, len(participants))
+	for _, idx := range participants {
+		if idx < 0 || idx >= len(participants) || indexed[idx] {
+			return ErrTableInvalidParticipants
+		}
+		indexed[idx] = true
+	}
+
+	te.ogm.Setup(gameCount, participants)
+	te.emitReadyProgressEvent(te.openGameProgress())
+	return nil
+}
+
+/*
+GetPlayerSessionStats gets the session profit of a player at the table
+  - Use case: Cash game players check their running session profit
+*/
+func (te *tableEngine) GetPlayerSessionStats(playerID string) (SessionStats, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) {
+		return SessionStats{}, ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	return SessionStats{
+		PlayerID:     playerState.PlayerID,
+		Bankroll:     playerState.Bankroll,
+		BuyInTotal:   playerState.BuyInTotal,
+		AddOnCount:   playerState.AddOnCount,
+		AddOnTotal:   playerState.AddOnTotal,
+		CashOutTotal: playerState.CashOutTotal,
+		SessionNet:   playerState.SessionNet,
+	}, nil
+}
+
+/*
+GetStandings gets the players sorted by bankroll in descending order
+  - Use case: Leaderboards & tournament displays
+  - Uses committed bankrolls, chips in the pot of a running hand are not counted
+*/
+func (te *tableEngine) GetStandings() []PlayerStanding {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	standings := make([]PlayerStanding, 0, len(te.table.State.PlayerStates))
+	totalChips := int64(0)
+	for _, playerState := range te.table.State.PlayerStates {
+		standings = append(standings, PlayerStanding{
+			PlayerID: playerState.PlayerID,
+			Seat:     playerState.Seat,
+			Bankroll: playerState.Bankroll,
+		})
+		totalChips += playerState.Bankroll
+	}
+	if len(standings) == 0 {
+		return standings
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Bankroll != standings[j].Bankroll {
+			return standings[i].Bankroll > standings[j].Bankroll
+		}
+		return standings[i].Seat < standings[j].Seat
+	})
+
+	chipAverage := totalChips / int64(len(standings))
+	for i := range standings {
+		standings[i].ChipAverage = chipAverage
+		if i > 0 && standings[i].Bankroll == standings[i-1].Bankroll {
+			standings[i].Rank = standings[i-1].Rank
+		} else {
+			standings[i].Rank = i + 1
+		}
+	}
+	return standings
+}
+
+/*
+IsPlayerTurn checks if it's the player's turn to act
+  - Returns false when no betting round is active
+  - Acquires the table lock, do not call it from callbacks fired under the lock (e.g. OnGamePlayerActionUpdated)
+*/
+func (te *tableEngine) IsPlayerTurn(playerID string) bool {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	actorPlayerID, err := te.currentActorPlayerID()
+	return err == nil && actorPlayerID == playerID
+}
+
+/*
+GetCurrentActorPlayerID gets the player id to act
+  - Returns ErrTableNoCurrentActor when no betting round is active
+*/
+func (te *tableEngine) GetCurrentActorPlayerID() (string, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	return te.currentActorPlayerID()
+}
+
+/*
+GetPlayerHoleCards gets the hole cards of a player in the current game
+  - Use case: Trusted server-side tools (e.g. bots), never expose the result to other players
+  - Cards of a folded player are still returned
+*/
+func (te *tableEngine) GetPlayerHoleCards(playerID string) ([]string, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if IsUnset(te.table.FindPlayerIdx(playerID)) {
+		return nil, ErrTablePlayerNotFound
+	}
+
+	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
+	gs := te.table.State.GameState
+	if IsUnset(gamePlayerIdx) || gs == nil {
+		return nil, ErrTablePlayerNotParticipated
+	}
+
+	p := gs.GetPlayer(gamePlayerIdx)
+	if p == nil {
+		return nil, ErrTablePlayerNotParticipated
+	}
+
+	if len(p.HoleCards) == 0 {
+		return nil, ErrTableHoleCardsNotDealt
+	}
+
+	return append([]string{}, p.HoleCards...), nil
+}
+
+/*
+GetPlayerInvestment gets the chips the player has put in the current hand
+  - Use case: Pot-odds displays
+  - Completed betting rounds (Pot) plus the current round (Wager), blinds & antes included
+  - Returns ErrGameNotStarted outside a hand
+*/
+func (te *tableEngine) GetPlayerInvestment(playerID string) (int64, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if IsUnset(te.table.FindPlayerIdx(playerID)) {
+		return 0, ErrTablePlayerNotFound
+	}
+
+	gs := te.table.State.GameState
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || gs == nil {
+		return 0, ErrGameNotStarted
+	}
+
+	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
+	if IsUnset(gamePlayerIdx) {
+		return 0, ErrTablePlayerNotParticipated
+	}
+
+	p := gs.GetPlayer(gamePlayerIdx)
+	if p == nil {
+		return 0, ErrTablePlayerNotParticipated
+	}
+
+	return p.Pot + p.Wager, nil
+}
+
+/*
+GetPlayerButtonDistance gets how many seats the player is from the button in the rotation direction (0 = button)
+  - Use case: Strategy tools weighing the distance from the button rather than the position label
+  - Only occupied seats are counted, the button seat may be empty (dead button)
+  - Returns ErrTablePositionsNotInitialized before the first button is placed
+*/
+func (te *tableEngine) GetPlayerButtonDistance(playerID string) (int, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) || IsUnset(te.table.State.PlayerStates[playerIdx].Seat) {
+		return 0, ErrTablePlayerNotFound
+	}
+
+	dealerSeat := te.table.State.CurrentDealerSeat
+	if IsUnset(dealerSeat) {
+		return 0, ErrTablePositionsNotInitialized
+	}
+
+	seat := te.table.State.PlayerStates[playerIdx].Seat
+	distance := 0
+	for seatID := dealerSeat; seatID != seat; {
+		seatID = seatIDFrom(seatID, 1, te.table.Meta.TableMaxSeatCount, te.table.Meta.RotationDirection)
+		if seatPlayerIdx, exist := te.table.State.SeatMap[seatID]; exist && !IsUnset(seatPlayerIdx) {
+			distance++
+		}
+	}
+	return distance, nil
+}
+
+/*
+GetBettingRound gets the current round and whether betting is open
+  - Use case: Clients enable the betting controls without parsing raw game events
+  - Betting is open when the round has started and the current player has actions other than pass
+  - Returns ErrGameNotStarted outside a hand
+*/
+func (te *tableEngine) GetBettingRound() (round string, bettingOpen bool, err error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	gs := te.table.State.GameState
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || gs == nil {
+		return "", false, ErrGameNotStarted
+	}
+
+	if gs.Status.CurrentEvent == pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted] {
+		if p := gs.GetPlayer(gs.Status.CurrentPlayer); p != nil {
+			for _, action := range p.AllowedActions {
+				if PlayerActionType(action) != Action_Pass {
+					bettingOpen = true
+					break
+				}
+			}
+		}
+	}
+	return gs.Status.Round, bettingOpen, nil
+}
+
+/*
+GetCurrentGlobalHandID gets the unique id of the current hand
+  - Use case: Hand histories that stay linked across table merges & rebalances, where GameCount starts over
+  - Returns ErrGameNotStarted outside a hand
+*/
+func (te *tableEngine) GetCurrentGlobalHandID() (string, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || te.table.State.GlobalHandID == "" {
+		return "", ErrGameNotStarted
+	}
+	return te.table.State.GlobalHandID, nil
+}
+
+/*
+GetBoardTexture gets the texture of the current board
+  - Use case: HUD tools
+  - Preflop the texture is empty
+  - Returns ErrGameNotStarted outside a hand
+*/
+func (te *tableEngine) GetBoardTexture() (BoardTexture, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	gs := te.table.State.GameState
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || gs == nil {
+		return BoardTexture{}, ErrGameNotStarted
+	}
+	return newBoardTexture(gs.Status.Board), nil
+}
+
+/*
+GetRaiseSizingOptions gets the bet sizing presets of the player to act
+  - Use case: Clients offering preset bet sizes (1/2 pot, 3/4 pot, pot, all-in)
+  - Sizes are chip levels of the round as PlayerBet & PlayerRaise take them, table games are no limit
+  - Presets are kept between the min raise & all-in, all of them are all-in when the player can't raise
+  - Returns ErrNotPlayersTurn when another player is to act, ErrGameInvalidAction when nobody is
+*/
+func (te *tableEngine) GetRaiseSizingOptions(playerID string) (RaiseSizing, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if IsUnset(te.table.FindPlayerIdx(playerID)) {
+		return RaiseSizing{}, ErrTablePlayerNotFound
+	}
+
+	actorPlayerID, err := te.currentActorPlayerID()
+	if err != nil {
+		return RaiseSizing{}, ErrGameInvalidAction
+	}
+	if actorPlayerID != playerID {
+		return RaiseSizing{}, ErrNotPlayersTurn
+	}
+
+	gs := te.table.State.GameState
+	return raiseSizing(gs, gs.Status.CurrentPlayer), nil
+}
+
+/*
+GetCallAmount gets the chips the player to act needs to call
+  - Use case: Clients showing "call 40" on the call button
+  - Capped at the player's stack, a short stack calls all-in for less
+  - Returns ErrNotPlayersTurn when it's not the player's turn, ErrActionNotAllowed when the player can't call (both are ErrGameInvalidAction)
+*/
+func (te *tableEngine) GetCallAmount(playerID string) (int64, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
+	if err := te.validateGameMove(gamePlayerIdx); err != nil {
+		return 0, err
+	}
+
+	return te.callAmount(gamePlayerIdx)
+}
+
+/*
+GetReadyState gets who is ready in the running ready phases, key: player id
+  - Use case: UIs showing "waiting for 2 of 5 players"
+  - Covers newly seated players joining the table & players finishing the settlement before the next hand opens
+  - Empty when no ready phase is running
+*/
+func (te *tableEngine) GetReadyState() map[string]bool {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	readyState := make(map[string]bool)
+
+	// players joining the table
+	joinStates := te.rg.GetParticipantStates()
+	if ready, total := readyProgress(joinStates); ready < total {
+		for playerIdx, isReady := range joinStates {
+			if int(playerIdx) < len(te.table.State.PlayerStates) {
+				readyState[te.table.State.PlayerStates[playerIdx].PlayerID] = isReady
+			}
+		}
+	}
+
+	// players finishing the settlement
+	if ready, total := te.openGameProgress(); ready < total {
+		for playerID, participant := range te.ogm.GetState().Participants {
+			if isReady, exist := readyState[playerID]; !exist || isReady {
+				readyState[playerID] = participant.IsReady
+			}
+		}
+	}
+	return readyState
+}
+
+/*
+GetActionTimer gets the action timer of the player to act as a consistent snapshot
+  - Use case: Clients rendering the countdown of the player to act
+  - extended is true once the deadline is extended by PlayerExtendActionDeadline
+  - All values are zero when no action is pending
+*/
+func (te *tableEngine) GetActionTimer() (playerID string, startAt, endAt int64, extended bool) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	actorPlayerID, err := te.currentActorPlayerID()
+	if err != nil || te.table.State.CurrentActionEndAt == 0 {
+		return "", 0, 0, false
+	}
+	return actorPlayerID, te.actionTimerStartAt, te.table.State.CurrentActionEndAt, te.isActionTimerExtended
+}
+
+/*
+IsWalkSituation checks if all players but the BB have folded preflop
+  - Use case: Clients prompting the BB that everyone folded to them
+  - Computed from the fold flags & positions of the game state, false outside preflop
+*/
+func (te *tableEngine) IsWalkSituation() (bbPlayerID string, isWalk bool) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	gs := te.table.State.GameState
+	if !te.table.IsHandRunning() || gs == nil || gs.Status.Round != GameRound_Preflop {
+		return "", false
+	}
+
+	var bbPlayer *pokerlib.PlayerState
+	for _, p := range gs.Players {
+		if p.Fold {
+			continue
+		}
+		if bbPlayer != nil || !funk.Contains(p.Positions, Position_BB) {
+			return "", false
+		}
+		bbPlayer = p
+	}
+	if bbPlayer == nil {
+		return "", false
+	}
+
+	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(bbPlayer.Idx)
+	if IsUnset(playerIdx) {
+		return "", false
+	}
+	return te.table.State.PlayerStates[playerIdx].PlayerID, true
+}
+
+/*
+GetEffectiveStacks gets the effective stack of every player in the hand, key: player id
+  - Use case: Solver integrations
+  - The smaller of the player's stack and the largest stack among the opponents still in the hand
+  - Folded players are left out, all-in players have an effective stack of 0
+  - Returns ErrGameNotStarted outside a hand
+*/
+func (te *tableEngine) GetEffectiveStacks() (map[string]int64, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	gs := te.table.State.GameState
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || gs == nil {
+		return nil, ErrGameNotStarted
+	}
+
+	stacks := make(map[string]int64)
+	for _, p := range gs.Players {
+		if p.Fold {
+			continue
+		}
+
+		playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(p.Idx)
+		if IsUnset(playerIdx) {
+			continue
+		}
+
+		maxOpponentStack := int64(0)
+		for _, opponent := range gs.Players {
+			if opponent.Idx != p.Idx && !opponent.Fold && opponent.StackSize > maxOpponentStack {
+				maxOpponentStack = opponent.StackSize
+			}
+		}
+
+		effectiveStack := p.StackSize
+		if maxOpponentStack < effectiveStack {
+			effectiveStack = maxOpponentStack
+		}
+		stacks[te.table.State.PlayerStates[playerIdx].PlayerID] = effectiveStack
+	}
+	return stacks, nil
+}
+
+/*
+HealthCheck reports whether the table is alive and its running hand progressing
+  - Use case: Operators probe tables to detect stuck ones (e.g. a backend that stopped responding)
+  - A running hand is progressing while the table was updated within HealthStallTimeout seconds
+  - Unhealthy when the engine is released/its playing game is closed, or the running hand stalls
+*/
+func (te *tableEngine) HealthCheck() TableHealth {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	stallTimeout := te.options.HealthStallTimeout
+	if stallTimeout <= 0 {
+		stallTimeout = NewTableEngineOptions().HealthStallTimeout
+	}
+
+	health := TableHealth{
+		Status:             te.table.State.Status,
+		IsHandRunning:      te.table.IsHandRunning(),
+		SecondsSinceUpdate: time.Now().Unix() - te.table.UpdateAt,
+		IsEngineAlive:      !te.isReleased.Load(),
+	}
+	health.IsHandProgressing = health.IsHandRunning && health.SecondsSinceUpdate <= int64(stallTimeout)
+	if te.table.State.Status == TableStateStatus_TableGamePlaying && (te.game == nil || te.game.IsClosed()) {
+		health.IsEngineAlive = false
+	}
+	if playerID, err := te.currentActorPlayerID(); err == nil {
+		health.PendingActionPlayerID = playerID
+	}
+	health.IsHealthy = health.IsEngineAlive && (!health.IsHandRunning || health.IsHandProgressing)
+	return health
+}
+
+/*
+GetHandCommitment gets the commitment hash of a specific hand
+  - Use case: Provably-fair verification (see NewHandCommitment)
+*/
+func (te *tableEngine) GetHandCommitment(gameCount int) (string, error) {
+	commitment, exist := te.handCommitments.Load(gameCount)
+	if !exist {
+		return "", ErrTableHandCommitmentNotFound
+	}
+	return commitment.(string), nil
+}
+
+/*
+GetHandBlindState gets the blinds a specific hand was played at
+  - Use case: Hand histories keep showing the level of the hand after the table moves on
+  - The returned blind state is a copy
+*/
+func (te *tableEngine) GetHandBlindState(gameCount int) (*TableBlindState, error) {
+	blind, exist := te.handBlindStates.Load(gameCount)
+	if !exist {
+		return nil, ErrTableHandBlindStateNotFound
+	}
+
+	blindState := *blind.(*TableBlindState)
+	return &blindState, nil
+}
+
+/*
+ExportHandHistory exports a settled hand as JSON
+  - Use case: Support teams reproducing disputes (see LoadHandHistory)
+*/
+func (te *tableEngine) ExportHandHistory(gameCount int) (string, error) {
+	history, exist := te.handHistories.Load(gameCount)
+	if !exist {
+		return "", ErrTableHandHistoryNotFound
+	}
+
+	encoded, err := json.Marshal(history)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+/*
+GetOpenSeats gets the seat indexes without a player
+  - Use case: Seat selection UI
+*/
+func (te *tableEngine) GetOpenSeats() []int {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	return te.table.OpenSeats()
+}
+
+/*
+GetNextBBOrder gets a copy of the player ids in next BB order
+  - Use case: MTT decides where new players may sit (not between SB and BB)
+*/
+func (te *tableEngine) GetNextBBOrder() []string {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	nextBBOrder := make([]string, len(te.table.State.NextBBOrderPlayerIDs))
+	copy(nextBBOrder, te.table.State.NextBBOrderPlayerIDs)
+	return nextBBOrder
+}
+
+/*
+PeekNextPositions predicts the dealer/sb/bb player ids of the next hand
+  - Use case: Tournament directors preview the next button before the hand opens
+  - Rotates a cloned seat manager, the real button never moves
+  - Players with zero bankroll are treated as busted
+  - Returns ErrTablePositionsNotInitialized before the first hand (the first button is random)
+*/
+func (te *tableEngine) PeekNextPositions() (dealer, sb, bb string, err error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if !te.sm.IsInitPositions() {
+		return "", "", "", ErrTablePositionsNotInitialized
 	}
 
-	// Update start time
-	te.table.State.StartAt = time.Now().Unix()
-	te.emitEvent("StartTableGame", "")
-
-	// Start the game
-	te.emitReadyOpenFirstTableGame(te.table.State.GameCount, te.table.State.PlayerStates)
-	return nil
+	sm := te.sm.Clone()
+	for _, playerState := range te.table.State.PlayerStates {
+		if err := sm.UpdatePlayerHasChips(playerState.PlayerID, playerState.Bankroll > 0); err != nil {
+			return "", "", "", err
+		}
+	}
 
-}
+	if err := sm.RotatePositions(); err != nil {
+		return "", "", "", err
+	}
 
-func (te *tableEngine) UpdateBlind(level int, ante, dealer, sb, bb int64) {
-	te.table.State.BlindState.Level = level
-	te.table.State.BlindState.Ante = ante
-	te.table.State.BlindState.Dealer = dealer
-	te.table.State.BlindState.SB = sb
-	te.table.State.BlindState.BB = bb
+	seatPlayerID := func(seatID int) string {
+		if seatPlayer := sm.Seats()[seatID]; seatPlayer != nil {
+			return seatPlayer.ID
+		}
+		return ""
+	}
+	return seatPlayerID(sm.CurrentDealerSeatID()), seatPlayerID(sm.CurrentSBSeatID()), seatPlayerID(sm.CurrentBBSeatID()), nil
 }
 
 /*
-SetUpTableGame sets up a specific hand
-  - Use cases:
-    1. After game start, preparing the first hand
-    2. At the end of each hand, in the Continue phase, preparing the next hand
+GetNextHandParticipants returns the player ids to be dealt in the next hand
+  - Use case: MTT coordinator decides whether to open a hand or break the table
+  - Applies the same filters as openGame (IsParticipated) on a cloned seat manager, nothing is mutated
+  - Excludes players sitting out and players with zero bankroll
 */
-func (te *tableEngine) SetUpTableGame(gameCount int, participants map[string]int) {
-	te.ogm.Setup(gameCount, participants)
+func (te *tableEngine) GetNextHandParticipants() []string {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	participants := make([]string, 0)
+	sm := te.sm.Clone()
+	for _, playerState := range te.table.State.PlayerStates {
+		if err := sm.UpdatePlayerHasChips(playerState.PlayerID, playerState.Bankroll > 0); err != nil {
+			return participants
+		}
+	}
+
+	if !sm.IsInitPositions() {
+		if err := sm.InitPositions(false); err != nil {
+			return participants
+		}
+	} else if err := sm.RotatePositions(); err != nil {
+		return participants
+	}
+
+	for _, playerState := range te.table.State.PlayerStates {
+		if active, err := sm.IsPlayerActive(playerState.PlayerID); err == nil && active {
+			participants = append(participants, playerState.PlayerID)
+		}
+	}
+	return participants
 }
 
 /*
 UpdateTablePlayers updates the number of players at the table
   - Use case: After each hand ends
+  - Returns ErrTableSeatsLocked while a hand is in progress
 */
 func (te *tableEngine) UpdateTablePlayers(joinPlayers []JoinPlayer, leavePlayerIDs []string) (map[string]int, error) {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
+	if err := te.validateSeatsUnlocked(); err != nil {
+		return nil, err
+	}
+
 	// remove players
 	if len(leavePlayerIDs) > 0 {
+		te.markEliminatedPlayers(leavePlayerIDs)
 		if err := te.batchRemovePlayers(leavePlayerIDs); err != nil {
 			return nil, err
 		}
@@ -341,7 +1569,7 @@ func (te *tableEngine) PlayerReserve(joinPlayer JoinPlayer) error {
 	// find player index in PlayerStates
 	targetPlayerIdx := te.table.FindPlayerIdx(joinPlayer.PlayerID)
 
-	if targetPlayerIdx == UnsetValue {
+	if IsUnset(targetPlayerIdx) {
 		if len(te.table.State.PlayerStates) == te.table.Meta.TableMaxSeatCount {
 			return ErrTableNoEmptySeats
 		}
@@ -352,11 +1580,17 @@ func (te *tableEngine) PlayerReserve(joinPlayer JoinPlayer) error {
 		}
 	} else {
 		// ReBuy
+		if err := validateRedeemChips([]JoinPlayer{joinPlayer}); err != nil {
+			return err
+		}
+
 		playerState := te.table.State.PlayerStates[targetPlayerIdx]
 		playerState.Bankroll += joinPlayer.RedeemChips
+		playerState.addBuyIn(joinPlayer.RedeemChips)
 		if err := te.sm.UpdatePlayerHasChips(playerState.PlayerID, true); err != nil {
 			return err
 		}
+		te.updateNextBBOrderPlayerIDs()
 
 		te.emitTablePlayerStateEvent(playerState)
 		te.emitTablePlayerReservedEvent(playerState)
@@ -372,69 +1606,330 @@ PlayerJoin player joins the table
   - Use case: When a player has confirmed a seat and joins the table
 */
 func (te *tableEngine) PlayerJoin(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	return te.playerJoin(playerID)
+}
+
+/*
+PlayerSettlementFinish player settlement completed
+  - Use case: Player has watched the settlement animation
+*/
+func (te *tableEngine) PlayerSettlementFinish(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
 	playerIdx := te.table.FindPlayerIdx(playerID)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrTablePlayerNotFound
 	}
 
-	if te.table.State.PlayerStates[playerIdx].Seat == UnsetValue {
+	if !te.table.State.PlayerStates[playerIdx].IsIn {
 		return ErrTablePlayerInvalidAction
 	}
 
-	if te.table.State.PlayerStates[playerIdx].IsIn {
-		return nil
+	if err := te.ogm.Ready(playerID); err == nil {
+		te.emitReadyProgressEvent(te.openGameProgress())
+	}
+
+	return nil
+}
+
+/*
+PlayerRedeemChips buy-in additional chips
+  - Use case: Rebuy
+*/
+func (te *tableEngine) PlayerRedeemChips(joinPlayer JoinPlayer) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	return te.redeemChips(joinPlayer)
+}
+
+// redeemChips is PlayerRedeemChips, the caller must hold te.lock
+func (te *tableEngine) redeemChips(joinPlayer JoinPlayer) error {
+	// find player index in PlayerStates
+	playerIdx := te.table.FindPlayerIdx(joinPlayer.PlayerID)
+	if IsUnset(playerIdx) {
+		return ErrTablePlayerNotFound
+	}
+
+	if err := validateRedeemChips([]JoinPlayer{joinPlayer}); err != nil {
+		return err
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	playerState.Bankroll += joinPlayer.RedeemChips
+	playerState.addBuyIn(joinPlayer.RedeemChips)
+
+	te.emitEvent("PlayerRedeemChips", joinPlayer.PlayerID)
+	te.emitTablePlayerStateEvent(playerState)
+	return nil
+}
+
+/*
+PlayerAddOn buys the player a tournament add-on
+  - Use case: Add-on offered once during the break after TableMeta.AddOnLevel
+  - Unlike rebuys, add-ons are tracked in AddOnCount & AddOnTotal instead of BuyInTotal
+  - Returns ErrTableAddOnNotAllowed outside of the add-on window
+*/
+func (te *tableEngine) PlayerAddOn(joinPlayer JoinPlayer) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(joinPlayer.PlayerID)
+	if IsUnset(playerIdx) {
+		return ErrTablePlayerNotFound
 	}
 
-	te.table.State.PlayerStates[playerIdx].IsIn = true
+	if err := validateRedeemChips([]JoinPlayer{joinPlayer}); err != nil {
+		return err
+	}
 
-	// If ReadyGroup is set and player is not ready, mark as ready
-	if isReady, exist := te.rg.GetParticipantStates()[int64(playerIdx)]; exist && !isReady {
-		te.rg.Ready(int64(playerIdx))
+	if !te.isAddOnWindowOpen() {
+		return ErrTableAddOnNotAllowed
 	}
 
-	// Update seat manager
-	if err := te.sm.JoinPlayers([]string{playerID}); err != nil {
+	playerState := te.table.State.PlayerStates[playerIdx]
+	playerState.Bankroll += joinPlayer.RedeemChips
+	playerState.addAddOn(joinPlayer.RedeemChips)
+	if err := te.sm.UpdatePlayerHasChips(playerState.PlayerID, true); err != nil {
 		return err
 	}
 
-	te.emitEvent("PlayerJoin", playerID)
+	te.emitEvent("PlayerAddOn", joinPlayer.PlayerID)
+	te.emitTablePlayerStateEvent(playerState)
+	te.emitPlayerAddOnEvent(joinPlayer.PlayerID, joinPlayer.RedeemChips)
 	return nil
 }
 
 /*
-PlayerSettlementFinish player settlement completed
-  - Use case: Player has watched the settlement animation
+PlayerReEntry seats an eliminated player again with fresh chips
+  - Use case: Tournaments allowing players to re-enter after busting
+  - The player is either at the table without chips or has left it, a busted seat is given up for a new one (joinPlayer.Seat, random if unset)
+  - A player not at the table must be recorded as eliminated by the ReEntryLedger (busted in a hand or left without chips)
+  - Allowed up to TableMeta.MaxReEntries times per player & competition, until the blind level passes TableMeta.ReEntryEndLevel
+  - Game statistics start over, session stats follow PreserveSessionStats like any rejoin
+  - The player joins with PlayerJoin as after PlayerReserve
+  - Returns ErrTableSeatsLocked while a hand is in progress
 */
-func (te *tableEngine) PlayerSettlementFinish(playerID string) error {
+func (te *tableEngine) PlayerReEntry(joinPlayer JoinPlayer) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if err := te.validateSeatsUnlocked(); err != nil {
+		return err
+	}
+
+	if err := validateRedeemChips([]JoinPlayer{joinPlayer}); err != nil {
+		return err
+	}
+
+	endLevel := te.table.Meta.ReEntryEndLevel
+	if te.table.Meta.MaxReEntries <= 0 || (endLevel > 0 && te.table.State.BlindState.Level > endLevel) {
+		return ErrTableReEntryNotAllowed
+	}
+
+	competitionID := te.table.Meta.CompetitionID
+	playerIdx := te.table.FindPlayerIdx(joinPlayer.PlayerID)
+	if !IsUnset(playerIdx) {
+		if te.table.State.PlayerStates[playerIdx].Bankroll > 0 {
+			return ErrTablePlayerNotEliminated
+		}
+		te.reEntries.markEliminated(competitionID, joinPlayer.PlayerID)
+	} else if len(te.table.State.PlayerStates) == te.table.Meta.TableMaxSeatCount {
+		return ErrTableNoEmptySeats
+	}
+
+	reEntryCount, err := te.reEntries.reserve(competitionID, joinPlayer.PlayerID, te.table.Meta.MaxReEntries)
+	if err != nil {
+		return err
+	}
+
+	if !IsUnset(playerIdx) {
+		if err := te.batchRemovePlayers([]string{joinPlayer.PlayerID}); err != nil {
+			te.reEntries.release(competitionID, joinPlayer.PlayerID)
+			return err
+		}
+	}
+
+	if err := te.batchAddPlayers([]JoinPlayer{joinPlayer}); err != nil {
+		te.reEntries.release(competitionID, joinPlayer.PlayerID)
+		return err
+	}
+
+	playerState := te.table.State.PlayerStates[te.table.FindPlayerIdx(joinPlayer.PlayerID)]
+	playerState.ReEntryCount = reEntryCount
+
+	te.emitEvent("PlayerReEntry", joinPlayer.PlayerID)
+	te.emitTablePlayerStateEvent(playerState)
+	return nil
+}
+
+/*
+PlayerSetAutoRebuy turns the player's auto top-up on or off
+  - Use case: Cash game players keep their stack at AutoRebuyToStack
+  - The top-up happens between hands, never mid-hand
+*/
+func (te *tableEngine) PlayerSetAutoRebuy(playerID string, enabled bool) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
 	playerIdx := te.table.FindPlayerIdx(playerID)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrTablePlayerNotFound
 	}
 
-	if !te.table.State.PlayerStates[playerIdx].IsIn {
+	playerState := te.table.State.PlayerStates[playerIdx]
+	playerState.IsAutoRebuy = enabled
+
+	te.emitEvent("PlayerSetAutoRebuy", playerID)
+	te.emitTablePlayerStateEvent(playerState)
+	return nil
+}
+
+/*
+PlayerPostMissedBlind posts the big blind the player missed while sitting out
+  - Use case: A returning player doesn't want to wait for the BB (LateRegBlindRule_PostDead only)
+  - The player is dealt in from the next game and posts a big blind there
+*/
+func (te *tableEngine) PlayerPostMissedBlind(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) {
+		return ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if !playerState.MissedBB {
+		return ErrTablePlayerNoMissedBlind
+	}
+
+	if te.options.LateRegBlindRule != LateRegBlindRule_PostDead || !playerState.IsIn {
 		return ErrTablePlayerInvalidAction
 	}
 
-	te.ogm.Ready(playerID)
+	if err := te.sm.UpdatePlayerWaitingBB(playerID, false); err != nil {
+		return err
+	}
+	playerState.MissedBB = false
+	playerState.MustPostBB = true
 
+	te.emitEvent("PlayerPostMissedBlind", playerID)
+	te.emitTablePlayerStateEvent(playerState)
 	return nil
 }
 
 /*
-PlayerRedeemChips buy-in additional chips
-  - Use case: Rebuy
+PlayerChangeSeat moves the player to an empty seat
+  - Use case: Player requests a seat change
+  - Only before the first hand or between hands (standby or pausing), the seat map is rebuilt
+  - Returns ErrTableSeatsLocked while a hand is in progress
 */
-func (te *tableEngine) PlayerRedeemChips(joinPlayer JoinPlayer) error {
-	// find player index in PlayerStates
-	playerIdx := te.table.FindPlayerIdx(joinPlayer.PlayerID)
-	if playerIdx == UnsetValue {
+func (te *tableEngine) PlayerChangeSeat(playerID string, targetSeat int) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if err := te.validateSeatsUnlocked(); err != nil {
+		return err
+	}
+
+	switch te.table.State.Status {
+	case TableStateStatus_TableCreated, TableStateStatus_TableGameStandby, TableStateStatus_TablePausing:
+	default:
+		return ErrTableChangeSeatInvalidState
+	}
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) {
+		return ErrTablePlayerNotFound
+	}
+
+	if targetSeat < 0 || targetSeat >= te.table.Meta.TableMaxSeatCount {
+		return ErrTableSeatOutOfRange
+	}
+
+	if seatPlayerIdx, exist := te.table.State.SeatMap[targetSeat]; exist && !IsUnset(seatPlayerIdx) && seatPlayerIdx != playerIdx {
+		return ErrTableSeatOccupied
+	}
+
+	if err := te.sm.MoveSeat(playerID, targetSeat); err != nil {
+		return err
+	}
+	te.table.State.PlayerStates[playerIdx].Seat = targetSeat
+	te.recordSeatLeave(playerID)
+	te.recordSeatJoin(playerID, targetSeat)
+
+	// rebuild seat map
+	seatMap := NewDefaultSeatMap(te.table.Meta.TableMaxSeatCount)
+	for idx, player := range te.table.State.PlayerStates {
+		if !IsUnset(player.Seat) {
+			seatMap[player.Seat] = idx
+		}
+	}
+	te.table.State.SeatMap = seatMap
+	te.updateNextBBOrderPlayerIDs()
+
+	te.emitEvent("PlayerChangeSeat", playerID)
+	te.emitSeatMapChangedEvent(seatMap)
+	return nil
+}
+
+/*
+PlayerDisconnect marks the player as disconnected
+  - DisconnectPolicy_FastFold: checks or folds immediately on the player's turn
+  - DisconnectPolicy_UseTimer: waits for the action timer as usual
+  - DisconnectPolicy_SitOut: same as FastFold, and sits out of the following games until reconnected
+*/
+func (te *tableEngine) PlayerDisconnect(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	isDisconnected, err := te.disconnectPlayer(playerID)
+	if err != nil {
+		return err
+	}
+
+	// It may already be the player's turn
+	if isDisconnected {
+		te.autoActDisconnectedPlayer()
+	}
+	return nil
+}
+
+/*
+PlayerReconnect marks the player as connected again
+  - DisconnectPolicy_SitOut: the player is dealt in again from the following games
+*/
+func (te *tableEngine) PlayerReconnect(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) {
 		return ErrTablePlayerNotFound
 	}
 
 	playerState := te.table.State.PlayerStates[playerIdx]
-	playerState.Bankroll += joinPlayer.RedeemChips
+	if !playerState.IsDisconnected {
+		return nil
+	}
+	playerState.IsDisconnected = false
 
-	te.emitEvent("PlayerRedeemChips", joinPlayer.PlayerID)
+	if te.options.DisconnectPolicy == DisconnectPolicy_SitOut && playerState.IsIn {
+		if err := te.sm.JoinPlayers([]string{playerID}); err != nil {
+			return err
+		}
+
+		if err := te.applyMissedBlindRule(playerState); err != nil {
+			return err
+		}
+	}
+
+	te.emitEvent("PlayerReconnect", playerID)
 	te.emitTablePlayerStateEvent(playerState)
 	return nil
 }
@@ -445,11 +1940,17 @@ PlayersLeave players leave the table
   - CT: leaving table (player has chips)
   - CT: giving up rebuy (player has no chips)
   - CT: eliminated after stopping buy-in
+  - Returns ErrTableSeatsLocked while a hand is in progress
 */
 func (te *tableEngine) PlayersLeave(playerIDs []string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
+	if err := te.validateSeatsUnlocked(); err != nil {
+		return err
+	}
+
+	te.markEliminatedPlayers(playerIDs)
 	if err := te.batchRemovePlayers(playerIDs); err != nil {
 		return err
 	}
@@ -460,14 +1961,40 @@ func (te *tableEngine) PlayersLeave(playerIDs []string) error {
 	return nil
 }
 
+/*
+PlayerActionTimeout checks (or folds if check is not allowed) for the player whose action time is up
+  - Use case: When the action timer (CurrentActionEndAt) expires
+  - The player sits out after MaxConsecutiveTimeouts timeouts in a row, until PlayerJoin
+*/
+func (te *tableEngine) PlayerActionTimeout(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	actorPlayerID, err := te.currentActorPlayerID()
+	if err != nil {
+		return err
+	}
+
+	if actorPlayerID != playerID {
+		return ErrTablePlayerInvalidGameAction
+	}
+
+	return te.autoAct(playerID, true)
+}
+
 /*
 PlayerExtendActionDeadline extends the player's action deadline
   - Use case: When player action timer starts
 */
 func (te *tableEngine) PlayerExtendActionDeadline(playerID string, duration int) (int64, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
 	endAt := time.Unix(te.table.State.CurrentActionEndAt, 0)
 	currentActionEndAt := endAt.Add(time.Duration(duration) * time.Second).Unix()
 	te.table.State.CurrentActionEndAt = currentActionEndAt
+	te.isActionTimerExtended = true
+	te.scheduleActionWarning(playerID, time.Unix(currentActionEndAt, 0))
 	te.emitEvent("PlayerExtendActionDeadline", "")
 	return currentActionEndAt, nil
 }
@@ -482,13 +2009,13 @@ func (te *tableEngine) PlayerReady(playerID string) error {
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
 	gs, err := te.game.Ready(gamePlayerIdx)
 	if err == nil {
-		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, "ready", 0, gs.GetPlayer(gamePlayerIdx))
+		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, Action_Ready, 0, gs.GetPlayer(gamePlayerIdx))
 	}
 
 	return err
@@ -504,13 +2031,19 @@ func (te *tableEngine) PlayerPay(playerID string, chips int64) error {
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
+	// ante & blinds are only taken once everyone pays, what the player posts is known up front
+	position, amount := blindPosting(te.table.State.GameState, gamePlayerIdx)
+
 	gs, err := te.game.Pay(gamePlayerIdx, chips)
 	if err == nil {
-		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, "pay", chips, gs.GetPlayer(gamePlayerIdx))
+		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, Action_Pay, chips, gs.GetPlayer(gamePlayerIdx))
+		if position != "" {
+			te.emitBlindPostedEvent(playerID, position, amount)
+		}
 	}
 
 	return err
@@ -526,7 +2059,7 @@ func (te *tableEngine) PlayerBet(playerID string, chips int64) error {
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
@@ -536,6 +2069,7 @@ func (te *tableEngine) PlayerBet(playerID string, chips int64) error {
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 
 		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.TimeoutCount = 0
 		playerState.GameStatistics.ActionTimes++
 		if te.game.GetGameState().Status.CurrentRaiser == gamePlayerIdx {
 			playerState.GameStatistics.RaiseTimes++
@@ -563,13 +2097,14 @@ func (te *tableEngine) PlayerRaise(playerID string, chipLevel int64) error {
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
 	gs, err := te.game.Raise(gamePlayerIdx, chipLevel)
 	if err == nil {
 		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.TimeoutCount = 0
 		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, WagerAction_Raise, chipLevel, gs.GetPlayer(gamePlayerIdx))
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 
@@ -612,13 +2147,13 @@ func (te *tableEngine) PlayerCall(playerID string) error {
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
-	wager := int64(0)
-	if te.table.State.GameState != nil && gamePlayerIdx < len(te.table.State.GameState.Players) {
-		wager = te.table.State.GameState.Status.CurrentWager - te.table.State.GameState.GetPlayer(gamePlayerIdx).Wager
+	wager, err := te.callAmount(gamePlayerIdx)
+	if err != nil {
+		return err
 	}
 
 	gs, err := te.game.Call(gamePlayerIdx)
@@ -627,6 +2162,7 @@ func (te *tableEngine) PlayerCall(playerID string) error {
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 
 		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.TimeoutCount = 0
 		playerState.GameStatistics.ActionTimes++
 		playerState.GameStatistics.CallTimes++
 
@@ -648,7 +2184,7 @@ func (te *tableEngine) PlayerAllin(playerID string) error {
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
@@ -657,14 +2193,18 @@ func (te *tableEngine) PlayerAllin(playerID string) error {
 		wager = te.table.State.GameState.GetPlayer(gamePlayerIdx).StackSize
 	}
 
+	prevGS := te.game.GetGameState()
 	gs, err := te.game.Allin(gamePlayerIdx)
 	if err == nil {
 		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, WagerAction_AllIn, wager, gs.GetPlayer(gamePlayerIdx))
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 
 		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.TimeoutCount = 0
 		playerState.GameStatistics.ActionTimes++
-		if te.game.GetGameState().Status.CurrentRaiser == gamePlayerIdx {
+
+		// an incomplete all-in (less than a full raise) is not counted as a raise
+		if isFullRaise(prevGS, gs) {
 			playerState.GameStatistics.RaiseTimes++
 			if playerState.GameStatistics.IsPFRChance {
 				playerState.GameStatistics.IsPFR = true
@@ -697,13 +2237,18 @@ func (te *tableEngine) PlayerCheck(playerID string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
+	return te.playerCheck(playerID)
+}
+
+// playerCheck is PlayerCheck, the caller must hold te.lock
+func (te *tableEngine) playerCheck(playerID string) error {
 	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
 	if err := te.validateGameMove(gamePlayerIdx); err != nil {
 		return err
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
@@ -713,6 +2258,7 @@ func (te *tableEngine) PlayerCheck(playerID string) error {
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 
 		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.TimeoutCount = 0
 		playerState.GameStatistics.ActionTimes++
 		playerState.GameStatistics.CheckTimes++
 	}
@@ -724,13 +2270,18 @@ func (te *tableEngine) PlayerFold(playerID string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
+	return te.playerFold(playerID)
+}
+
+// playerFold is PlayerFold, the caller must hold te.lock
+func (te *tableEngine) playerFold(playerID string) error {
 	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
 	if err := te.validateGameMove(gamePlayerIdx); err != nil {
 		return err
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
@@ -740,6 +2291,7 @@ func (te *tableEngine) PlayerFold(playerID string) error {
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 
 		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.TimeoutCount = 0
 		playerState.GameStatistics.ActionTimes++
 		playerState.GameStatistics.IsFold = true
 		playerState.GameStatistics.FoldRound = te.game.GetGameState().Status.Round
@@ -760,21 +2312,60 @@ func (te *tableEngine) PlayerPass(playerID string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
+	return te.playerPass(playerID)
+}
+
+// playerPass is PlayerPass, the caller must hold te.lock
+func (te *tableEngine) playerPass(playerID string) error {
 	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
 	if err := te.validateGameMove(gamePlayerIdx); err != nil {
 		return err
 	}
 
 	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
-	if playerIdx == UnsetValue {
+	if IsUnset(playerIdx) {
 		return ErrGamePlayerNotFound
 	}
 
 	gs, err := te.game.Pass(gamePlayerIdx)
 	if err == nil {
-		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, "pass", 0, gs.GetPlayer(gamePlayerIdx))
+		te.table.State.LastPlayerGameAction = te.createPlayerGameAction(playerID, playerIdx, Action_Pass, 0, gs.GetPlayer(gamePlayerIdx))
 		te.emitGamePlayerActionEvent(*te.table.State.LastPlayerGameAction)
 	}
 
 	return err
 }
+
+/*
+PlayerBuyInsurance buys all-in insurance against the player's current offer, see OnInsuranceOffer
+  - Use case: A player all-in ahead insures the pot against being outdrawn
+  - amount is the premium, the coverage (amount / (1 - equity), up to the max payout) is paid if the player doesn't win the pot outright
+  - The offer expires once the next card is dealt, buy from the OnInsuranceOffer callback to be on time
+  - The premium & coverage are settled apart from the pots, see TableState.Insurances
+*/
+func (te *tableEngine) PlayerBuyInsurance(playerID string, amount int64) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	offer, exist := te.insuranceOffers[playerID]
+	if !exist {
+		return ErrTablePlayerNoInsuranceOffer
+	}
+
+	if amount <= 0 || amount > offer.maxAmount() {
+		return ErrTableInvalidInsuranceAmount
+	}
+
+	// One purchase per offer
+	delete(te.insuranceOffers, playerID)
+	te.table.State.Insurances = append(te.table.State.Insurances, &TableInsurance{
+		PlayerID: playerID,
+		Round:    offer.round,
+		Equity:   offer.equity,
+		Amount:   amount,
+		Coverage: offer.coverage(amount),
+	})
+
+	te.emitEvent("PlayerBuyInsurance", playerID)
+	return nil
+}
@@ -1,10 +1,13 @@
 package pokertable
 
 import (
-	"sync"
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/settlement"
 	"github.com/d-protocol/pokertable/seat_manager"
 	"github.com/d-protocol/syncsaga"
 	"github.com/thoas/go-funk"
@@ -17,78 +20,1025 @@ func (te *tableEngine) validateGameMove(gamePlayerIdx int) error {
 	}
 
 	// check game player index
-	if gamePlayerIdx == UnsetValue {
+	if IsUnset(gamePlayerIdx) {
 		return ErrTablePlayerNotFound
 	}
 
 	return nil
 }
 
-func (te *tableEngine) delay(interval int, fn func() error) error {
+/*
+validateSeatsUnlocked rejects seat-mutating operations while a hand is in progress
+  - Seats are locked from openGame until continueGame, moving a player mid-hand corrupts GamePlayerIndexes
+*/
+func (te *tableEngine) validateSeatsUnlocked() error {
+	if te.seatsLocked.Load() {
+		return ErrTableSeatsLocked
+	}
+	return nil
+}
+
+/*
+scheduleBlindLevelEnd schedules the transition to the next blind level (or a break) at the current level's EndTime
+  - Does nothing if EndTime is not set
+  - The scheduled transition is cancelled when the table is released or closed
+*/
+func (te *tableEngine) scheduleBlindLevelEnd() {
+	endTime := te.latestBlindState().EndTime
+	if endTime <= 0 || len(te.blindLevels) == 0 {
+		return
+	}
+
+	duration := time.Until(time.Unix(endTime, 0))
+	if duration < 0 {
+		duration = 0
+	}
+
+	te.tbForBlind.NewTask(duration, func(isCancelled bool) {
+		if isCancelled || te.isReleased.Load() {
+			return
+		}
+
+		if te.nextBlindLevel() {
+			te.scheduleBlindLevelEnd()
+		}
+	})
+}
+
+// nextBlindLevel switches to the next blind level, returns false if there is no upcoming level
+func (te *tableEngine) nextBlindLevel() bool {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if len(te.blindLevels) == 0 {
+		return false
+	}
+
+	blind := te.blindLevels[0]
+	te.blindLevels = te.blindLevels[1:]
+	if te.setBlindState(blind) {
+		te.emitEvent("BlindUpdated", "")
+		te.emitTableStateEvent(TableStateEvent_BlindUpdated)
+	}
+	return true
+}
+
+// latestBlindState returns the pending blind level if any, otherwise the current one
+func (te *tableEngine) latestBlindState() *TableBlindState {
+	if te.pendingBlind != nil {
+		return te.pendingBlind
+	}
+	return te.table.State.BlindState
+}
+
+// setBlindState applies the blind level, or defers it while a hand is running. Returns true if applied
+func (te *tableEngine) setBlindState(blind TableBlindState) bool {
+	if te.table.IsHandRunning() {
+		te.pendingBlind = &blind
+		return false
+	}
+
+	// remember which level the break follows, see PlayerAddOn
+	if blind.IsBreaking() && te.table.State.BlindState != nil && !te.table.State.BlindState.IsBreaking() {
+		te.breakAfterBlindLevel = te.table.State.BlindState.Level
+	}
+
+	te.pendingBlind = nil
+	te.table.State.BlindState = &blind
+	return true
+}
+
+// isAddOnWindowOpen returns true during the break following the add-on level, the caller holds the lock
+func (te *tableEngine) isAddOnWindowOpen() bool {
+	addOnLevel := te.table.Meta.AddOnLevel
+	return addOnLevel > 0 && te.table.State.BlindState.IsBreaking() && te.breakAfterBlindLevel == addOnLevel
+}
+
+// applyPendingBlind applies the blind level deferred during the hand
+func (te *tableEngine) applyPendingBlind() {
+	if te.pendingBlind == nil || !te.setBlindState(*te.pendingBlind) {
+		return
+	}
+
+	te.emitEvent("BlindUpdated", "")
+	te.emitTableStateEvent(TableStateEvent_BlindUpdated)
+}
+
+/*
+closeTable closes the table & cancels the scheduled blind levels, the caller holds the lock
+  - Game states queued before the close are still handled, see emitFinalCloseTable
+*/
+func (te *tableEngine) closeTable() error {
+	te.isClosed.Store(true)
+	te.table.State.Status = TableStateStatus_TableClosed
+	te.ReleaseTable()
+
+	te.emitEvent("CloseTable", "")
+	te.emitTableStateEvent(TableStateEvent_StatusUpdated)
+	return nil
+}
+
+/*
+emitFinalCloseTable emits the closed status again once the hand running at the close is settled
+  - Clients receive the result of the last hand, then the closed status as the final state
+*/
+func (te *tableEngine) emitFinalCloseTable() {
+	if !te.isClosed.Load() {
+		return
+	}
+
+	te.table.State.Status = TableStateStatus_TableClosed
+	te.emitEvent("CloseTable", "")
+	te.emitTableStateEvent(TableStateEvent_StatusUpdated)
+}
+
+// backendCallTimeout returns TableEngineOptions.BackendCallTimeout as a duration
+func (te *tableEngine) backendCallTimeout() time.Duration {
+	return time.Duration(te.options.BackendCallTimeout) * time.Millisecond
+}
+
+// minOpenGameParticipantCount is the participants required to open a game, TableMinPlayerCount but at least 2
+func (te *tableEngine) minOpenGameParticipantCount() int {
+	if te.table.Meta.TableMinPlayerCount < 2 {
+		return 2
+	}
+	return te.table.Meta.TableMinPlayerCount
+}
+
+// startTableGame records the start time and asks for the first hand to be set up, the caller holds the lock
+func (te *tableEngine) startTableGame() {
+	// Update start time
+	te.table.State.StartAt = time.Now().Unix()
+	te.emitEvent("StartTableGame", "")
+
+	// Start the game
+	te.emitReadyOpenFirstTableGame(te.table.State.GameCount, te.table.State.PlayerStates)
+}
+
+// playerJoin marks the seated player as in, the caller holds the lock
+func (te *tableEngine) playerJoin(playerID string) error {
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) {
+		return ErrTablePlayerNotFound
+	}
+
+	if IsUnset(te.table.State.PlayerStates[playerIdx].Seat) {
+		return ErrTablePlayerInvalidAction
+	}
+
+	if te.table.State.PlayerStates[playerIdx].IsIn {
+		return nil
+	}
+
+	te.table.State.PlayerStates[playerIdx].IsIn = true
+
+	// If ReadyGroup is set and player is not ready, mark as ready
+	if isReady, exist := te.rg.GetParticipantStates()[int64(playerIdx)]; exist && !isReady {
+		te.rg.Ready(int64(playerIdx))
+	}
+
+	// Update seat manager
+	if err := te.sm.JoinPlayers([]string{playerID}); err != nil {
+		return err
+	}
+
+	// Sitting back in after sitting out through the BB
+	if err := te.applyMissedBlindRule(te.table.State.PlayerStates[playerIdx]); err != nil {
+		return err
+	}
+
+	te.emitEvent("PlayerJoin", playerID)
+	return nil
+}
+
+/*
+disconnectPlayer marks the player as disconnected, the caller must hold te.lock
+  - Returns false if the player was already disconnected
+*/
+func (te *tableEngine) disconnectPlayer(playerID string) (bool, error) {
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if IsUnset(playerIdx) {
+		return false, ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if playerState.IsDisconnected {
+		return false, nil
+	}
+	playerState.IsDisconnected = true
+
+	if te.options.DisconnectPolicy == DisconnectPolicy_SitOut && playerState.IsIn {
+		if err := te.sm.SitOutPlayers([]string{playerID}); err != nil {
+			return false, err
+		}
+	}
+
+	te.emitEvent("PlayerDisconnect", playerID)
+	te.emitTablePlayerStateEvent(playerState)
+	return true, nil
+}
+
+/*
+autoActDisconnectedPlayer passes, checks or folds for the disconnected player on their turn, the caller must hold te.lock
+  - Does nothing with DisconnectPolicy_UseTimer, the action timer runs as usual
+*/
+func (te *tableEngine) autoActDisconnectedPlayer() {
+	if te.options.DisconnectPolicy == DisconnectPolicy_UseTimer {
+		return
+	}
+
+	playerID, err := te.currentActorPlayerID()
+	if err != nil {
+		return
+	}
+
+	playerState := te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)]
+	gs := te.table.State.GameState
+	if p := gs.GetPlayer(gs.Status.CurrentPlayer); !playerState.IsDisconnected || p == nil || p.Acted {
+		return
+	}
+
+	if err := te.autoAct(playerID, false); err != nil {
+		te.emitErrorEvent("autoActDisconnectedPlayer", playerID, err)
+	}
+}
+
+/*
+autoAct passes, checks or folds for the player to act, the caller must hold te.lock
+  - isTimeout counts the check or fold toward MaxConsecutiveTimeouts, auto actions of disconnected players are not timeouts
+  - Neither kind resets the count the way a voluntary action does
+*/
+func (te *tableEngine) autoAct(playerID string, isTimeout bool) error {
+	gs := te.table.State.GameState
+	if gs == nil {
+		return ErrTablePlayerInvalidGameAction
+	}
+
+	p := gs.GetPlayer(gs.Status.CurrentPlayer)
+	if p == nil {
+		return ErrTablePlayerInvalidGameAction
+	}
+
+	if funk.Contains(p.AllowedActions, string(Action_Pass)) {
+		return te.playerPass(playerID)
+	}
+
+	playerState := te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)]
+	timeoutCount := playerState.TimeoutCount
+
 	var err error
-	var wg sync.WaitGroup
-	wg.Add(1)
+	if funk.Contains(p.AllowedActions, string(WagerAction_Check)) {
+		err = te.playerCheck(playerID)
+	} else if funk.Contains(p.AllowedActions, string(WagerAction_Fold)) {
+		err = te.playerFold(playerID)
+	} else {
+		return ErrTablePlayerInvalidGameAction
+	}
+	if err != nil {
+		return err
+	}
+
+	playerState.TimeoutCount = timeoutCount
+	if !isTimeout {
+		return nil
+	}
+
+	playerState.TimeoutCount++
+	return te.autoSitOutTimedOutPlayer(playerState)
+}
+
+// autoSitOutTimedOutPlayer sits the player out of the following games once MaxConsecutiveTimeouts is reached
+func (te *tableEngine) autoSitOutTimedOutPlayer(playerState *TablePlayerState) error {
+	if te.options.MaxConsecutiveTimeouts <= 0 || playerState.TimeoutCount < te.options.MaxConsecutiveTimeouts || !playerState.IsIn {
+		return nil
+	}
+
+	if err := te.sm.SitOutPlayers([]string{playerState.PlayerID}); err != nil {
+		return err
+	}
+	playerState.IsIn = false
+	playerState.TimeoutCount = 0
+
+	// no table update here, the table still shows the player to act until the game state is updated
+	te.emitTablePlayerStateEvent(playerState)
+	te.emitPlayerAutoSitOutEvent(playerState.PlayerID)
+	return nil
+}
+
+func (te *tableEngine) resetOpenGameFailure() {
+	te.openGameFailureLock.Lock()
+	defer te.openGameFailureLock.Unlock()
+
+	te.openGameFailureReasons = nil
+	te.openGameFailureAttempts = 0
+}
+
+// recordOpenGameFailure counts a failed attempt to open the hand and keeps its reason if it's a new one
+func (te *tableEngine) recordOpenGameFailure(err error) {
+	reason := err.Error()
+	var openGameErr *openGameError
+	if errors.As(err, &openGameErr) {
+		reason = openGameErr.reason
+	}
+
+	te.openGameFailureLock.Lock()
+	defer te.openGameFailureLock.Unlock()
+
+	te.openGameFailureAttempts++
+	if !funk.ContainsString(te.openGameFailureReasons, reason) {
+		te.openGameFailureReasons = append(te.openGameFailureReasons, reason)
+	}
+}
+
+// recordSeatJoin opens a seat history entry, dropping the oldest closed entries beyond MaxSeatHistory
+func (te *tableEngine) recordSeatJoin(playerID string, seat int) {
+	te.seatHistoryLock.Lock()
+	defer te.seatHistoryLock.Unlock()
+
+	te.seatHistory = append(te.seatHistory, SeatChange{
+		PlayerID: playerID,
+		Seat:     seat,
+		JoinedAt: time.Now().Unix(),
+	})
+
+	if te.options.MaxSeatHistory <= 0 {
+		return
+	}
+	seatHistory := make([]SeatChange, 0, len(te.seatHistory))
+	dropCount := len(te.seatHistory) - te.options.MaxSeatHistory
+	for _, sc := range te.seatHistory {
+		if dropCount > 0 && sc.LeftAt != 0 {
+			dropCount--
+			continue
+		}
+		seatHistory = append(seatHistory, sc)
+	}
+	te.seatHistory = seatHistory
+}
+
+// recordSeatLeave closes the player's open seat history entry
+func (te *tableEngine) recordSeatLeave(playerID string) {
+	te.seatHistoryLock.Lock()
+	defer te.seatHistoryLock.Unlock()
+
+	for i := len(te.seatHistory) - 1; i >= 0; i-- {
+		if te.seatHistory[i].PlayerID == playerID && te.seatHistory[i].LeftAt == 0 {
+			te.seatHistory[i].LeftAt = time.Now().Unix()
+			return
+		}
+	}
+}
+
+/*
+autoRebuyPlayers tops up players with auto-rebuy to AutoRebuyToStack (capped by MaxBuyIn) via redeemChips
+  - Cash mode only, must be called between hands
+  - Returns the players who are topped up
+*/
+func (te *tableEngine) autoRebuyPlayers() ([]*TablePlayerState, error) {
+	toppedUpPlayers := make([]*TablePlayerState, 0)
+	if te.table.Meta.Mode != CompetitionMode_Cash || te.table.Meta.AutoRebuyToStack <= 0 {
+		return toppedUpPlayers, nil
+	}
+
+	targetStack := te.table.Meta.AutoRebuyToStack
+	if maxBuyIn := te.table.Meta.MaxBuyIn; maxBuyIn > 0 && maxBuyIn < targetStack {
+		targetStack = maxBuyIn
+	}
+
+	for _, player := range te.table.State.PlayerStates {
+		if !player.IsAutoRebuy || player.Bankroll >= targetStack {
+			continue
+		}
+
+		if err := te.redeemChips(JoinPlayer{PlayerID: player.PlayerID, RedeemChips: targetStack - player.Bankroll}); err != nil {
+			return toppedUpPlayers, err
+		}
+		toppedUpPlayers = append(toppedUpPlayers, player)
+	}
+
+	return toppedUpPlayers, nil
+}
+
+// currentActorPlayerID maps the game's current player to the table player id
+func (te *tableEngine) currentActorPlayerID() (string, error) {
+	gs := te.table.State.GameState
+	if te.table.State.Status != TableStateStatus_TableGamePlaying || gs == nil {
+		return "", ErrTableNoCurrentActor
+	}
+
+	if gs.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted] {
+		return "", ErrTableNoCurrentActor
+	}
+
+	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gs.Status.CurrentPlayer)
+	if IsUnset(playerIdx) || playerIdx >= len(te.table.State.PlayerStates) {
+		return "", ErrTableNoCurrentActor
+	}
 
+	return te.table.State.PlayerStates[playerIdx].PlayerID, nil
+}
+
+// publishTableSnapshot keeps a copy of the table for GetTableSnapshot
+func (te *tableEngine) publishTableSnapshot() {
+	snapshot, err := te.table.Clone()
+	if err != nil {
+		fmt.Printf("[DEBUG#publishTableSnapshot] Table (%s) can't be cloned. Error: %v\n", te.table.ID, err)
+		return
+	}
+	te.tableSnapshot.Store(snapshot)
+}
+
+/*
+delay runs fn after interval seconds without blocking the caller, its error is emitted as eventName
+  - fn runs on another goroutine & takes the table lock itself, the caller may hold the lock (game states)
+*/
+func (te *tableEngine) delay(interval int, eventName string, fn func() error) {
 	te.tbForOpenGame.NewTask(time.Duration(interval)*time.Second, func(isCancelled bool) {
-		defer wg.Done()
+		if isCancelled {
+			return
+		}
+
+		// a task without interval runs inline, on the goroutine of the caller
+		go func() {
+			if err := fn(); err != nil {
+				te.emitErrorEvent(eventName, "", err)
+			}
+		}()
+	})
+}
+
+// updateGameState applies the game state to the table, the caller must hold te.lock
+func (te *tableEngine) updateGameState(gs *pokerlib.GameState) {
+	te.table.State.GameState = gs
+	te.expireInsuranceOffers(gs)
+
+	if te.table.State.Status == TableStateStatus_TableGamePlaying {
+		te.updateCurrentPlayerGameStatistics(gs)
+	}
+
+	te.detectRoundChanged(gs)
+
+	event, ok := pokerlib.GameEventBySymbol[gs.Status.CurrentEvent]
+	if !ok {
+		te.emitErrorEvent("handle updateGameState", "", ErrGameUnknownEvent)
+		return
+	}
+
+	switch event {
+	case pokerlib.GameEvent_GameClosed:
+		if err := te.onGameClosed(); err != nil {
+			te.emitErrorEvent("onGameClosed", "", err)
+		}
+		te.emitFinalCloseTable()
+	default:
+		te.updateCurrentActionEndAt(event, gs)
+		te.emitEvent(gs.Status.CurrentEvent, "")
+		te.emitActionRequired(event, gs)
+		te.emitTableStateEvent(TableStateEvent_GameUpdated)
+		if event == pokerlib.GameEvent_RoundClosed {
+			te.table.State.LastPlayerGameAction = nil
+		}
+		if event == pokerlib.GameEvent_BlindsRequested && te.table.Meta.AutoPostBlinds {
+			te.emitAutoPostedBlinds(gs)
+		}
+		if event == pokerlib.GameEvent_RoundStarted {
+			te.autoActDisconnectedPlayer()
+		}
+	}
+}
+
+// emitAutoPostedBlinds fires OnBlindPosted for the blinds the game posts without PlayerPay (TableMeta.AutoPostBlinds)
+func (te *tableEngine) emitAutoPostedBlinds(gs *pokerlib.GameState) {
+	for _, p := range gs.Players {
+		position, amount := blindPosting(gs, p.Idx)
+		if position == "" {
+			continue
+		}
+
+		if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(p.Idx); !IsUnset(playerIdx) {
+			te.emitBlindPostedEvent(te.table.State.PlayerStates[playerIdx].PlayerID, position, amount)
+		}
+	}
+}
+
+/*
+detectRoundChanged fires OnRoundChanged with the newly revealed board cards when the round advances
+  - Fires for each street when multiple streets advance at once (e.g. all-in run-out)
+*/
+func (te *tableEngine) detectRoundChanged(gs *pokerlib.GameState) {
+	if gs.GameID != te.roundChangedGameID {
+		te.roundChangedGameID = gs.GameID
+		te.lastRound = GameRound_Preflop
+	}
+
+	rounds := []string{GameRound_Preflop, GameRound_Flop, GameRound_Turn, GameRound_River}
+	boardCardCounts := []int{0, 3, 4, 5}
+	prevRoundIdx := funk.IndexOfString(rounds, te.lastRound)
+	currRoundIdx := funk.IndexOfString(rounds, gs.Status.Round)
+	if currRoundIdx <= prevRoundIdx {
+		return
+	}
+
+	for roundIdx := prevRoundIdx + 1; roundIdx <= currRoundIdx; roundIdx++ {
+		start := boardCardCounts[roundIdx-1]
+		end := boardCardCounts[roundIdx]
+		if end > len(gs.Status.Board) {
+			end = len(gs.Status.Board)
+		}
+		if start > end {
+			start = end
+		}
+		te.emitRoundChangedEvent(rounds[roundIdx], append([]string{}, gs.Status.Board[start:end]...))
+	}
+	te.lastRound = gs.Status.Round
+}
+
+/*
+offerInsurance offers all-in insurance to the players in the hand when the round closes into an all-in runout
+  - Players who can neither win nor lose the pot get no offer
+  - Offers replace the ones of the previous round and expire once the next card is dealt
+*/
+func (te *tableEngine) offerInsurance(gs *pokerlib.GameState) {
+	if !isAllinRunout(gs) {
+		return
+	}
+
+	equity, err := te.gameBackend.ComputeEquity(gs)
+	if err != nil {
+		te.emitErrorEvent("offerInsurance", "", err)
+		return
+	}
+
+	offers := make(map[string]*insuranceOffer)
+	offeredPlayerIDs := make([]string, 0)
+	for _, p := range gs.Players {
+		playerEquity, exist := equity[p.Idx]
+		if !exist || playerEquity <= 0 || playerEquity >= 1 {
+			continue
+		}
+
+		playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(p.Idx)
+		if IsUnset(playerIdx) {
+			continue
+		}
+
+		playerID := te.table.State.PlayerStates[playerIdx].PlayerID
+		offers[playerID] = &insuranceOffer{
+			gameID:     gs.GameID,
+			round:      gs.Status.Round,
+			boardCount: len(gs.Status.Board),
+			equity:     playerEquity,
+			maxPayout:  potsWinnable(gs, p.Idx),
+		}
+		offeredPlayerIDs = append(offeredPlayerIDs, playerID)
+	}
+	te.insuranceOffers = offers
+
+	// Delivered once the lock is released, players buy insurance right from the callback
+	for _, playerID := range offeredPlayerIDs {
+		offer := offers[playerID]
+		te.emitInsuranceOfferEvent(playerID, offer.equity, offer.maxPayout)
+	}
+}
+
+// expireInsuranceOffers drops the insurance offers once a new card is dealt or the game changes
+func (te *tableEngine) expireInsuranceOffers(gs *pokerlib.GameState) {
+	for playerID, offer := range te.insuranceOffers {
+		if offer.gameID != gs.GameID || offer.boardCount != len(gs.Status.Board) {
+			delete(te.insuranceOffers, playerID)
+		}
+	}
+}
+
+/*
+settleInsurance returns the chips the player's insurance changes the bankroll by
+  - Premiums are charged, the coverage is paid if the player doesn't win the pot outright
+*/
+func (te *tableEngine) settleInsurance(playerID string, isPotWon bool) int64 {
+	changed := int64(0)
+	for _, insurance := range te.table.State.Insurances {
+		if insurance.PlayerID != playerID {
+			continue
+		}
+
+		changed -= insurance.Amount
+		if !isPotWon {
+			insurance.Payout = insurance.Coverage
+			changed += insurance.Payout
+		}
+	}
+	return changed
+}
+
+/*
+settleDeadBlinds pays the dead big blinds of the hand to the winners of the main pot
+  - The posters were dealt in without them, their results count them as lost
+  - Falls back to winnerGamePlayerIndexes when the result has no pot winner, the odd chips go to the first winners
+*/
+func (te *tableEngine) settleDeadBlinds(winnerGamePlayerIndexes []int) {
+	result := te.table.State.GameState.Result
+	if len(te.deadBlinds) == 0 || result == nil {
+		return
+	}
+
+	var mainPot *settlement.PotResult
+	winners := winnerGamePlayerIndexes
+	if len(result.Pots) > 0 && len(result.Pots[0].Winners) > 0 {
+		mainPot = result.Pots[0]
+		winners = make([]int, 0, len(mainPot.Winners))
+		for _, winner := range mainPot.Winners {
+			winners = append(winners, winner.Idx)
+		}
+	}
+	if len(winners) == 0 {
+		return
+	}
+
+	deadChips := int64(0)
+	for gamePlayerIdx, chips := range te.deadBlinds {
+		deadChips += chips
+		for _, player := range result.Players {
+			if player.Idx == gamePlayerIdx {
+				player.Changed -= chips
+			}
+		}
+	}
+	if mainPot != nil {
+		mainPot.Total += deadChips
+	}
+
+	share := deadChips / int64(len(winners))
+	oddChips := deadChips % int64(len(winners))
+	for i, gamePlayerIdx := range winners {
+		chips := share
+		if int64(i) < oddChips {
+			chips++
+		}
+
+		if mainPot != nil {
+			mainPot.UpdateWinner(gamePlayerIdx, chips)
+		}
+		te.changeSettledChips(result, gamePlayerIdx, chips)
+	}
+}
+
+/*
+roundPotSplits rounds the shares of split pots down to MinChipUnit, sub-unit chips can't be awarded
+  - The chips left over go to the first winner of the pot clockwise from the button, the pot total is unchanged
+  - Does nothing when MinChipUnit is 1 or less
+*/
+func (te *tableEngine) roundPotSplits() {
+	unit := int64(te.table.Meta.MinChipUnit)
+	result := te.table.State.GameState.Result
+	if unit <= 1 || result == nil {
+		return
+	}
+
+	// game players start at the button, unless the button is dead
+	playerCount := len(te.table.State.GamePlayerIndexes)
+	buttonOffset := 0
+	if playerCount > 0 && funk.Contains(te.table.State.PlayerStates[te.table.State.GamePlayerIndexes[0]].Positions, Position_Dealer) {
+		buttonOffset = 1
+	}
+	distanceFromButton := func(gamePlayerIdx int) int {
+		return (gamePlayerIdx - buttonOffset + playerCount) % playerCount
+	}
+
+	for _, pot := range result.Pots {
+		if len(pot.Winners) < 2 {
+			continue
+		}
+
+		var first *settlement.Winner
+		remainder := int64(0)
+		for _, winner := range pot.Winners {
+			odd := winner.Withdraw % unit
+			winner.Withdraw -= odd
+			te.changeSettledChips(result, winner.Idx, -odd)
+			remainder += odd
+
+			if first == nil || distanceFromButton(winner.Idx) < distanceFromButton(first.Idx) {
+				first = winner
+			}
+		}
+
+		first.Withdraw += remainder
+		te.changeSettledChips(result, first.Idx, remainder)
+	}
+}
+
+// changeSettledChips changes the settled chips of the game player
+func (te *tableEngine) changeSettledChips(result *settlement.Result, gamePlayerIdx int, chips int64) {
+	for _, player := range result.Players {
+		if player.Idx == gamePlayerIdx {
+			player.Final += chips
+			player.Changed += chips
+			return
+		}
+	}
+}
+
+/*
+checkChipConservation compares the chips of the settled hand with the chips the game players brought into it
+  - Settled chips are the final bankrolls of the game players (rounding, insurance & settlement hook applied) plus the rake
+  - The rake is what the game result keeps from the chips brought in, a result returning more chips than brought in is a violation
+  - insuranceChips is what the insurance paid out minus the premiums, the table settles it apart from the pots
+  - Does nothing unless WithChipConservationCheck is set
+*/
+func (te *tableEngine) checkChipConservation(insuranceChips int64) error {
+	if !te.chipConservationCheck {
+		return nil
+	}
+
+	gs := te.table.State.GameState
+	rake := te.handChips
+	bankrolls := int64(0)
+	for _, result := range gs.Result.Players {
+		rake -= result.Final
+		bankrolls += te.table.State.PlayerStates[te.table.State.GamePlayerIndexes[result.Idx]].Bankroll
+	}
+
+	settledChips := bankrolls + rake
+	if rake < 0 {
+		settledChips = bankrolls
+	}
+	if rake < 0 || settledChips != te.handChips+insuranceChips {
+		return fmt.Errorf("%w: game (%s) with game count (%d) settled %d chips, %d before the hand (rake %d, insurance %d)", ErrChipConservationViolated, gs.GameID, te.table.State.GameCount, settledChips, te.handChips, rake, insuranceChips)
+	}
+	return nil
+}
+
+/*
+handleMisdeal voids the misdealt game & deals the hand again
+  - Bankrolls are untouched, a voided game never settles
+  - After maxMisdealRedeals redeals in a row the hand is voided & the table continues with the next hand
+  - Also deals the hand again when the backend keeps timing out on an automatic transition (ErrBackendTimeout)
+  - Called by the game callbacks, the caller must hold te.lock
+*/
+func (te *tableEngine) handleMisdeal(g Game, opts *pokerlib.GameOptions, reason string) {
+	if te.game != g {
+		return
+	}
+
+	g.Close()
+	te.misdealCount++
+	te.emitMisdealEvent(te.table.State.GameCount, reason)
+
+	te.table.State.LastPlayerGameAction = nil
+	te.table.State.CurrentActionEndAt = 0
+	for _, playerIdx := range te.table.State.GamePlayerIndexes {
+		te.table.State.PlayerStates[playerIdx].GameStatistics = NewPlayerGameStatistics()
+	}
 
-		if isCancelled {
-			return
+	if te.misdealCount <= maxMisdealRedeals {
+		if err := te.dealGame(opts); err != nil {
+			te.emitErrorEvent("handleMisdeal#dealGame", "", err)
 		}
+		return
+	}
 
-		err = fn()
-	})
+	// the voided hand never took the dead blinds, they are posted in the next game
+	for gamePlayerIdx := range te.deadBlinds {
+		te.table.State.PlayerStates[te.table.State.GamePlayerIndexes[gamePlayerIdx]].MustPostBB = true
+	}
+	te.deadBlinds = nil
 
-	wg.Wait()
-	return err
+	te.emitErrorEvent("handleMisdeal", "", ErrTableMisdealLimitReached)
+	if err := te.continueGame(te.table.AlivePlayers()); err != nil {
+		te.emitErrorEvent("handleMisdeal#continueGame", "", err)
+	}
 }
 
-func (te *tableEngine) updateGameState(gs *pokerlib.GameState) {
-	te.table.State.GameState = gs
+func (te *tableEngine) updateCurrentActionEndAt(event pokerlib.GameEvent, gs *pokerlib.GameState) {
+	if !te.isAwaitingWager(event, gs) {
+		// the player acted or nobody is to act
+		te.tbForActionWarning.Cancel()
+		return
+	}
 
-	if te.table.State.Status == TableStateStatus_TableGamePlaying {
-		te.updateCurrentPlayerGameStatistics(gs)
+	now := time.Now()
+	deadline := now.Add(time.Second * time.Duration(te.table.Meta.ActionTime))
+	te.actionTimerStartAt = now.Unix()
+	te.isActionTimerExtended = false
+	te.table.State.CurrentActionEndAt = deadline.Unix()
+
+	if playerID, err := te.currentActorPlayerID(); err == nil {
+		te.scheduleActionWarning(playerID, deadline)
 	}
+}
 
-	event, ok := pokerlib.GameEventBySymbol[gs.Status.CurrentEvent]
-	if !ok {
-		te.emitErrorEvent("handle updateGameState", "", ErrGameUnknownEvent)
+/*
+scheduleActionWarning fires OnActionWarning ActionWarningSeconds before the deadline of the player to act
+  - Replaces the warning of the previous action, nothing is scheduled when the deadline is closer than the warning
+  - Dropped if the player has acted or the deadline has changed in the meantime
+*/
+func (te *tableEngine) scheduleActionWarning(playerID string, deadline time.Time) {
+	warningSeconds := te.options.ActionWarningSeconds
+	delay := time.Until(deadline) - time.Duration(warningSeconds)*time.Second
+	if warningSeconds <= 0 || delay <= 0 {
+		te.tbForActionWarning.Cancel()
 		return
 	}
 
-	switch event {
-	case pokerlib.GameEvent_GameClosed:
-		if err := te.onGameClosed(); err != nil {
-			te.emitErrorEvent("onGameClosed", "", err)
+	endAt := te.table.State.CurrentActionEndAt
+	te.tbForActionWarning.NewTask(delay, func(isCancelled bool) {
+		if isCancelled || te.isReleased.Load() {
+			return
 		}
-	default:
-		te.updateCurrentActionEndAt(event, gs)
-		te.emitEvent(gs.Status.CurrentEvent, "")
-		te.emitTableStateEvent(TableStateEvent_GameUpdated)
-		if event == pokerlib.GameEvent_RoundClosed {
-			te.table.State.LastPlayerGameAction = nil
+
+		te.lock.Lock()
+		actorPlayerID, err := te.currentActorPlayerID()
+		isPending := err == nil && actorPlayerID == playerID && te.table.State.CurrentActionEndAt == endAt
+		te.lock.Unlock()
+		if isPending {
+			te.emitActionWarningEvent(playerID, warningSeconds)
 		}
-	}
+	})
 }
 
-func (te *tableEngine) updateCurrentActionEndAt(event pokerlib.GameEvent, gs *pokerlib.GameState) {
+// isAwaitingWager returns true when the current player has yet to make a betting decision
+func (te *tableEngine) isAwaitingWager(event pokerlib.GameEvent, gs *pokerlib.GameState) bool {
 	p := gs.GetPlayer(gs.Status.CurrentPlayer)
+	if p == nil {
+		return false
+	}
+
 	validRounds := []string{GameRound_Preflop, GameRound_Flop, GameRound_Turn, GameRound_River}
 	validRoundState := te.table.State.Status == TableStateStatus_TableGamePlaying && event == pokerlib.GameEvent_RoundStarted && funk.Contains(validRounds, gs.Status.Round)
-	validActions := []string{WagerAction_Call, WagerAction_Raise, WagerAction_AllIn, WagerAction_Check, WagerAction_Fold, WagerAction_Bet}
+	validActions := []PlayerActionType{WagerAction_Call, WagerAction_Raise, WagerAction_AllIn, WagerAction_Check, WagerAction_Fold, WagerAction_Bet}
 
 	isActionValid := true
 	for _, action := range p.AllowedActions {
-		if !funk.Contains(validActions, action) {
+		if !funk.Contains(validActions, PlayerActionType(action)) {
 			isActionValid = false
 			break
 		}
 	}
 
 	playerUnmoved := len(p.AllowedActions) > 0 && !p.Acted
-	if validRoundState && playerUnmoved && isActionValid {
-		te.table.State.CurrentActionEndAt = time.Now().Add(time.Second * time.Duration(te.table.Meta.ActionTime)).Unix()
+	return validRoundState && playerUnmoved && isActionValid
+}
+
+/*
+blindPosting returns what the game player posts in the ante or blinds phase, an empty position otherwise
+  - Position_Ante, Position_BB, Position_SB or Position_Dealer, a player with both blind positions posts the BB
+  - The amount is capped at the player's stack, a short player posts all-in
+*/
+func blindPosting(gs *pokerlib.GameState, gamePlayerIdx int) (string, int64) {
+	if gs == nil {
+		return "", 0
+	}
+
+	p := gs.GetPlayer(gamePlayerIdx)
+	if p == nil {
+		return "", 0
+	}
+
+	position := ""
+	amount := int64(0)
+	switch gs.Status.CurrentEvent {
+	case pokerlib.GameEventSymbols[pokerlib.GameEvent_AnteRequested]:
+		position, amount = Position_Ante, gs.Meta.Ante
+	case pokerlib.GameEventSymbols[pokerlib.GameEvent_BlindsRequested]:
+		if gs.Meta.Blind.BB > 0 && gs.HasPosition(p.Idx, Position_BB) {
+			position, amount = Position_BB, gs.Meta.Blind.BB
+		} else if gs.Meta.Blind.SB > 0 && gs.HasPosition(p.Idx, Position_SB) {
+			position, amount = Position_SB, gs.Meta.Blind.SB
+		} else if gs.Meta.Blind.Dealer > 0 && gs.HasPosition(p.Idx, Position_Dealer) {
+			position, amount = Position_Dealer, gs.Meta.Blind.Dealer
+		}
+	}
+
+	if amount > p.StackSize {
+		amount = p.StackSize
+	}
+	return position, amount
+}
+
+/*
+raiseSizing computes the no limit bet sizing presets of the game player
+  - A pot raise calls first, then raises by the pot including the call
+*/
+func raiseSizing(gs *pokerlib.GameState, gamePlayerIdx int) RaiseSizing {
+	p := gs.GetPlayer(gamePlayerIdx)
+
+	sizing := RaiseSizing{
+		AllIn: p.Wager + p.StackSize,
+	}
+	for _, player := range gs.Players {
+		sizing.Pot += player.Pot + player.Wager
+	}
+
+	sizing.MinRaise = gs.Status.MiniBet
+	if gs.Status.CurrentWager > 0 {
+		sizing.MinRaise = gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
+	}
+
+	callAmount := gs.Status.CurrentWager - p.Wager
+	if callAmount < 0 {
+		callAmount = 0
+	}
+
+	sizeRaise := func(potRatio float64) int64 {
+		chipLevel := gs.Status.CurrentWager + int64(float64(sizing.Pot+callAmount)*potRatio)
+		if chipLevel < sizing.MinRaise {
+			chipLevel = sizing.MinRaise
+		}
+		if chipLevel > sizing.AllIn {
+			chipLevel = sizing.AllIn
+		}
+		return chipLevel
+	}
+	sizing.HalfPotRaise = sizeRaise(0.5)
+	sizing.ThreeQuarterPotRaise = sizeRaise(0.75)
+	sizing.PotRaise = sizeRaise(1)
+	if sizing.MinRaise > sizing.AllIn {
+		sizing.MinRaise = sizing.AllIn
+	}
+	return sizing
+}
+
+// callChips returns the chips the game player needs to call the current wager, capped at the stack (all-in for less)
+func callChips(gs *pokerlib.GameState, gamePlayerIdx int) int64 {
+	p := gs.GetPlayer(gamePlayerIdx)
+	chips := gs.Status.CurrentWager - p.Wager
+	if chips > p.StackSize {
+		chips = p.StackSize
+	}
+	if chips < 0 {
+		chips = 0
+	}
+	return chips
+}
+
+/*
+callAmount returns the chips the game player calls, checked the way the game checks the call
+  - Returns ErrNotPlayersTurn when another player is to act, ErrActionNotAllowed when the player can't call
+*/
+func (te *tableEngine) callAmount(gamePlayerIdx int) (int64, error) {
+	gs := te.table.State.GameState
+	if gs == nil {
+		return 0, ErrGameNotStarted
+	}
+
+	if gs.GetPlayer(gamePlayerIdx) == nil {
+		return 0, ErrGamePlayerNotFound
+	}
+
+	if gs.Status.CurrentPlayer != gamePlayerIdx {
+		return 0, ErrNotPlayersTurn
+	}
+
+	if !gs.HasAction(gamePlayerIdx, string(WagerAction_Call)) {
+		return 0, ErrActionNotAllowed
+	}
+
+	return callChips(gs, gamePlayerIdx), nil
+}
+
+/*
+emitActionRequired fires OnActionRequired with the decision context of the player to act
+  - Pot odds: the share of the final pot the player puts in by calling
+  - Equity (opt-in, ActionRequiredEquity) is only computed from the flop on
+*/
+func (te *tableEngine) emitActionRequired(event pokerlib.GameEvent, gs *pokerlib.GameState) {
+	if !te.isAwaitingWager(event, gs) {
+		return
 	}
+
+	playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gs.Status.CurrentPlayer)
+	if IsUnset(playerIdx) {
+		return
+	}
+
+	p := gs.GetPlayer(gs.Status.CurrentPlayer)
+	action := ActionRequired{
+		GameCount:      te.table.State.GameCount,
+		PlayerID:       te.table.State.PlayerStates[playerIdx].PlayerID,
+		Round:          gs.Status.Round,
+		AllowedActions: append([]string{}, p.AllowedActions...),
+		ActionEndAt:    te.table.State.CurrentActionEndAt,
+	}
+
+	for _, player := range gs.Players {
+		action.Pot += player.Pot + player.Wager
+	}
+
+	action.CallAmount = callChips(gs, gs.Status.CurrentPlayer)
+	if action.CallAmount > 0 {
+		action.PotOdds = float64(action.CallAmount) / float64(action.Pot+action.CallAmount)
+	}
+
+	if te.options.ActionRequiredEquity && len(gs.Status.Board) >= 3 {
+		equity, err := te.gameBackend.ComputeEquity(gs)
+		if err != nil {
+			te.emitErrorEvent("emitActionRequired#ComputeEquity", action.PlayerID, err)
+		} else if playerEquity, exist := equity[p.Idx]; exist {
+			action.Equity = &playerEquity
+		}
+	}
+
+	te.emitActionRequiredEvent(action)
 }
 
 func (te *tableEngine) shouldAutoGameOpen() bool {
@@ -151,14 +1101,16 @@ func (te *tableEngine) calcLeavePlayers(status TableStateStatus, leavePlayerIDs
 	return newPlayerStates, newSeatMap, newGamePlayerIndexes
 }
 
-func (te *tableEngine) createPlayerGameAction(playerID string, playerIdx int, action string, chips int64, player *pokerlib.PlayerState) *TablePlayerGameAction {
+func (te *tableEngine) createPlayerGameAction(playerID string, playerIdx int, action PlayerActionType, chips int64, player *pokerlib.PlayerState) *TablePlayerGameAction {
 	pga := &TablePlayerGameAction{
 		CompetitionID: te.table.Meta.CompetitionID,
 		TableID:       te.table.ID,
+		GlobalHandID:  te.table.State.GlobalHandID,
 		GameCount:     te.table.State.GameCount,
 		UpdateAt:      time.Now().Unix(),
+		Sequence:      te.actionSequence.Add(1),
 		PlayerID:      playerID,
-		Action:        action,
+		Action:        string(action),
 		Chips:         chips,
 	}
 
@@ -184,6 +1136,10 @@ func (te *tableEngine) createPlayerGameAction(playerID string, playerIdx int, ac
 }
 
 func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
+	if err := validateRedeemChips(players); err != nil {
+		return err
+	}
+
 	playerSeatIDs := make(map[string]int)
 	playerRandomSeatIDs := make([]string, 0)
 
@@ -198,12 +1154,16 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 	// update to seat manager
 	if len(playerSeatIDs) > 0 {
 		if err := te.sm.AssignSeats(playerSeatIDs); err != nil {
-			return err
+			return te.seatAssignmentError(playerSeatIDs, err)
 		}
 	}
 
 	if len(playerRandomSeatIDs) > 0 {
-		if err := te.sm.RandomAssignSeats(playerRandomSeatIDs); err != nil {
+		if te.seatAssigner != nil {
+			if err := te.assignSeatsByAssigner(playerRandomSeatIDs); err != nil {
+				return err
+			}
+		} else if err := te.sm.RandomAssignSeats(playerRandomSeatIDs); err != nil {
 			return err
 		}
 	}
@@ -230,8 +1190,11 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 			IsParticipated: false,
 			Bankroll:       player.RedeemChips,
 			IsIn:           false,
+			BuyInTotal:     player.RedeemChips,
+			ReEntryCount:   te.reEntries.Count(te.table.Meta.CompetitionID, player.PlayerID),
 			GameStatistics: NewPlayerGameStatistics(),
 		}
+		te.restoreSessionStats(player)
 		newPlayers = append(newPlayers, player)
 
 		newPlayerIdx := len(te.table.State.PlayerStates) + len(newPlayers) - 1
@@ -240,6 +1203,17 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 
 	te.table.State.SeatMap = newSeatMap
 	te.table.State.PlayerStates = append(te.table.State.PlayerStates, newPlayers...)
+	for _, player := range newPlayers {
+		te.recordSeatJoin(player.PlayerID, player.Seat)
+	}
+	te.updateNextBBOrderPlayerIDs()
+
+	// Late registration: players joining mid-game don't get a free big blind
+	for _, player := range newPlayers {
+		if err := te.applyLateRegBlindRule(player); err != nil {
+			return err
+		}
+	}
 
 	// If time is up and players haven't joined, auto-join them
 	te.playersAutoIn()
@@ -253,26 +1227,269 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 	return nil
 }
 
+/*
+applyLateRegBlindRule marks a player joining mid-game by LateRegBlindRule
+  - LateRegBlindRule_WaitForBB: sits out until the BB reaches the player's seat
+  - LateRegBlindRule_PostDead: posts a dead big blind in the next game
+  - Players who will be the next BB (the first of NextBBOrderPlayerIDs) are dealt in as usual
+  - Players seated between the dealer & the BB sit out until the button passes, then are dealt in without a blind
+*/
+func (te *tableEngine) applyLateRegBlindRule(player *TablePlayerState) error {
+	if !te.sm.IsInitPositions() || te.table.Meta.Rule == CompetitionRule_ShortDeck {
+		return nil
+	}
+
+	if nextBBOrder := te.table.State.NextBBOrderPlayerIDs; len(nextBBOrder) > 0 && nextBBOrder[0] == player.PlayerID {
+		return nil
+	}
+
+	if te.sm.IsPlayerBetweenDealerBB(player.PlayerID) {
+		return nil
+	}
+
+	switch te.options.LateRegBlindRule {
+	case LateRegBlindRule_WaitForBB:
+		return te.sm.UpdatePlayerWaitingBB(player.PlayerID, true)
+	case LateRegBlindRule_PostDead:
+		player.MustPostBB = true
+	}
+
+	return nil
+}
+
+/*
+applyMissedBlindRule deals in a player sitting back in after missing the BB by LateRegBlindRule
+  - LateRegBlindRule_WaitForBB: sits out until the BB reaches the player's seat
+  - LateRegBlindRule_PostDead: sits out until the BB reaches the player's seat or PlayerPostMissedBlind
+  - LateRegBlindRule_None: dealt in right away, the missed blind is forgiven
+*/
+func (te *tableEngine) applyMissedBlindRule(player *TablePlayerState) error {
+	if !player.MissedBB {
+		return nil
+	}
+
+	switch te.options.LateRegBlindRule {
+	case LateRegBlindRule_WaitForBB, LateRegBlindRule_PostDead:
+		return te.sm.UpdatePlayerWaitingBB(player.PlayerID, true)
+	}
+
+	player.MissedBB = false
+	return nil
+}
+
+// initPositions places the button of the first hand by the initial button mode (InitialButtonMode_*)
+func (te *tableEngine) initPositions(mode string) error {
+	switch mode {
+	case InitialButtonMode_FixedSeat:
+		return te.sm.InitPositions(false)
+	case InitialButtonMode_HighCard:
+		dealerSeatID, err := te.drawButtonSeat()
+		if err != nil {
+			return err
+		}
+		return te.sm.InitPositionsFromDealer(dealerSeatID)
+	default:
+		return te.sm.InitPositions(true)
+	}
+}
+
+// drawButtonSeat deals a card to every active player, the highest card (by point, then suit) takes the button
+func (te *tableEngine) drawButtonSeat() (int, error) {
+	seats := te.sm.Seats()
+	seatIDs := make([]int, 0, len(seats))
+	for seatID, seatPlayer := range seats {
+		if seatPlayer != nil && seatPlayer.Active() {
+			seatIDs = append(seatIDs, seatID)
+		}
+	}
+	sort.Ints(seatIDs)
+
+	playerIDs := make([]string, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		playerIDs = append(playerIDs, seats[seatID].ID)
+	}
+
+	cards, err := te.gameBackend.DealForButton(playerIDs)
+	if err != nil {
+		return UnsetValue, err
+	}
+
+	dealerSeatID, highestRank := UnsetValue, UnsetValue
+	for _, seatID := range seatIDs {
+		rank := buttonCardRank(cards[seats[seatID].ID])
+		if IsUnset(rank) {
+			return UnsetValue, fmt.Errorf("%w: player (%s) is dealt (%s)", ErrTableInvalidButtonCards, seats[seatID].ID, cards[seats[seatID].ID])
+		}
+
+		if rank > highestRank {
+			dealerSeatID, highestRank = seatID, rank
+		}
+	}
+	return dealerSeatID, nil
+}
+
+// buttonCardRank ranks a card drawn for the button by point, then suit (spade > heart > diamond > club), UnsetValue if the card is invalid
+func buttonCardRank(card string) int {
+	if len(card) != 2 {
+		return UnsetValue
+	}
+
+	suitIdx := funk.IndexOfString(pokerlib.CardSuits, card[:1])
+	pointIdx := funk.IndexOfString(pokerlib.CardPoints, card[1:])
+	if suitIdx < 0 || pointIdx < 0 {
+		return UnsetValue
+	}
+	return pointIdx*len(pokerlib.CardSuits) + len(pokerlib.CardSuits) - 1 - suitIdx
+}
+
+/*
+updateMissedBlinds marks the players the BB skipped over after positions are rotated
+  - Players ahead of the new BB in the previous NextBBOrderPlayerIDs were sitting out when the BB passed their seat
+  - The new BB player has a blind to post, so their missed blind is cleared
+*/
+func (te *tableEngine) updateMissedBlinds(table *Table, prevBBOrder []string) {
+	if table.Meta.Rule == CompetitionRule_ShortDeck {
+		return
+	}
+
+	playerIdx, exist := table.State.SeatMap[te.sm.CurrentBBSeatID()]
+	if !exist || playerIdx < 0 || playerIdx >= len(table.State.PlayerStates) {
+		return
+	}
+
+	bbPlayer := table.State.PlayerStates[playerIdx]
+	bbPlayer.MissedBB = false
+	if !funk.ContainsString(prevBBOrder, bbPlayer.PlayerID) {
+		return
+	}
+
+	for _, playerID := range prevBBOrder {
+		if playerID == bbPlayer.PlayerID {
+			break
+		}
+
+		if idx := table.FindPlayerIdx(playerID); !IsUnset(idx) {
+			table.State.PlayerStates[idx].MissedBB = true
+		}
+	}
+}
+
+// assignSeatsByAssigner seats the players by the custom seat assigner (see WithSeatAssigner)
+func (te *tableEngine) assignSeatsByAssigner(playerIDs []string) error {
+	playerSeatIDs, err := te.seatAssigner(te.table, playerIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(playerSeatIDs) != len(playerIDs) {
+		return ErrTableInvalidSeatAssignment
+	}
+	for _, playerID := range playerIDs {
+		if _, exist := playerSeatIDs[playerID]; !exist {
+			return ErrTableInvalidSeatAssignment
+		}
+	}
+
+	if err := te.sm.AssignSeats(playerSeatIDs); err != nil {
+		return te.seatAssignmentError(playerSeatIDs, err)
+	}
+	return nil
+}
+
+/*
+seatAssignmentError maps seat manager assignment errors to table errors
+  - Wraps ErrTableSeatOccupied / ErrTableSeatOutOfRange with the offending seat so callers can fall back (e.g. random seat)
+  - A seat assigned to more than one player is reported as ErrTableSeatOccupied too
+  - Players are checked in player id order, the reported seat is the same for the same assignment
+  - Other errors are returned as is
+*/
+func (te *tableEngine) seatAssignmentError(playerSeatIDs map[string]int, err error) error {
+	playerIDs := make([]string, 0, len(playerSeatIDs))
+	for playerID := range playerSeatIDs {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	switch {
+	case errors.Is(err, seat_manager.ErrSeatOutOfRange):
+		for _, playerID := range playerIDs {
+			if seat := playerSeatIDs[playerID]; seat < 0 || seat >= te.table.Meta.TableMaxSeatCount {
+				return fmt.Errorf("%w: seat %d", ErrTableSeatOutOfRange, seat)
+			}
+		}
+	case errors.Is(err, seat_manager.ErrSeatAlreadyIsTaken):
+		seats := te.sm.Seats()
+		for _, playerID := range playerIDs {
+			seat := playerSeatIDs[playerID]
+			if sp, exist := seats[seat]; exist && sp != nil && sp.ID != playerID {
+				return fmt.Errorf("%w: seat %d", ErrTableSeatOccupied, seat)
+			}
+		}
+	case errors.Is(err, seat_manager.ErrDuplicateSeats):
+		assigned := make(map[int]bool)
+		for _, playerID := range playerIDs {
+			seat := playerSeatIDs[playerID]
+			if assigned[seat] {
+				return fmt.Errorf("%w: seat %d", ErrTableSeatOccupied, seat)
+			}
+			assigned[seat] = true
+		}
+	}
+	return err
+}
+
+// autoReadyJoinPlayers readies the players who haven't joined when the join ready group times out (Auto Ready By Default)
+func (te *tableEngine) autoReadyJoinPlayers(rg *syncsaga.ReadyGroup) {
+	states := rg.GetParticipantStates()
+	for playerIdx, isReady := range states {
+		if !isReady {
+			if int(playerIdx) < len(te.table.State.PlayerStates) {
+				te.emitPlayerAutoReadyEvent(te.table.State.PlayerStates[playerIdx].PlayerID, AutoReadyPhase_Join)
+			}
+			rg.Ready(playerIdx)
+		}
+	}
+}
+
+// readyProgress counts the ready participants of a ready group
+func readyProgress(states map[int64]bool) (ready, total int) {
+	for _, isReady := range states {
+		if isReady {
+			ready++
+		}
+	}
+	return ready, len(states)
+}
+
+// openGameProgress counts the participants who have finished the settlement for the next hand
+func (te *tableEngine) openGameProgress() (ready, total int) {
+	participants := te.ogm.GetState().Participants
+	for _, participant := range participants {
+		if participant.IsReady {
+			ready++
+		}
+	}
+	return ready, len(participants)
+}
+
 func (te *tableEngine) playersAutoIn() {
 	// Preparing ready group for waiting all players' join
 	te.rg.Stop()
 	te.rg.SetTimeoutInterval(17)
-	te.rg.OnTimeout(func(rg *syncsaga.ReadyGroup) {
-		// Auto Ready By Default
-		states := rg.GetParticipantStates()
-		for playerIdx, isReady := range states {
-			if !isReady {
-				rg.Ready(playerIdx)
-			}
-		}
+	te.rg.OnTimeout(te.autoReadyJoinPlayers)
+	te.rg.OnUpdated(func(rg *syncsaga.ReadyGroup) {
+		te.emitReadyProgressEvent(readyProgress(rg.GetParticipantStates()))
 	})
 	te.rg.OnCompleted(func(rg *syncsaga.ReadyGroup) {
+		te.lock.Lock()
+		defer te.lock.Unlock()
+
 		isInCount := 0
 		alivePlayers := 0
 		for playerIdx, player := range te.table.State.PlayerStates {
 			// If time is up and player is not seated, auto-seat them
 			if !player.IsIn {
-				te.PlayerJoin(player.PlayerID)
+				te.playerJoin(player.PlayerID)
 			}
 
 			if te.table.State.PlayerStates[playerIdx].IsIn {
@@ -296,10 +1513,8 @@ func (te *tableEngine) playersAutoIn() {
 		if isInCount >= 2 && alivePlayers >= 2 && !isGameRunning && te.table.State.BlindState.Level > 0 && te.table.State.GameCount == 0 {
 			// First hand not started, StartTableGame (MTT Only, CT is decided by competition)
 			// TODO Consider CT pausing
-			if te.table.Meta.Mode == CompetitionMode_MTT {
-				if err := te.StartTableGame(); err != nil {
-					te.emitErrorEvent("StartTableGame", "", err)
-				}
+			if te.table.Meta.Mode == CompetitionMode_MTT && te.table.State.StartAt == UnsetValue {
+				te.startTableGame()
 			}
 		}
 	})
@@ -313,20 +1528,108 @@ func (te *tableEngine) playersAutoIn() {
 	}
 
 	te.rg.Start()
+	if _, total := readyProgress(te.rg.GetParticipantStates()); total > 0 {
+		te.emitReadyProgressEvent(0, total)
+	}
 }
 
 func (te *tableEngine) batchRemovePlayers(playerIDs []string) error {
+	te.keepSessionStats(playerIDs)
+
+	openedSeats := make([]int, 0)
+	for _, playerID := range playerIDs {
+		if playerIdx := te.table.FindPlayerIdx(playerID); !IsUnset(playerIdx) && !IsUnset(te.table.State.PlayerStates[playerIdx].Seat) {
+			openedSeats = append(openedSeats, te.table.State.PlayerStates[playerIdx].Seat)
+		}
+	}
+
 	newPlayerStates, newSeatMap, newGamePlayerIndexes := te.calcLeavePlayers(te.table.State.Status, playerIDs, te.table.State.PlayerStates, te.table.Meta.TableMaxSeatCount)
 	te.table.State.PlayerStates = newPlayerStates
 	te.table.State.SeatMap = newSeatMap
 	te.table.State.GamePlayerIndexes = newGamePlayerIndexes
-	return te.sm.RemoveSeats(playerIDs)
+	te.updateNextBBOrderPlayerIDs()
+	if err := te.sm.RemoveSeats(playerIDs); err != nil {
+		return err
+	}
+	for _, playerID := range playerIDs {
+		te.recordSeatLeave(playerID)
+	}
+
+	// Notify the coordinator (e.g. must-move table can pull a player in)
+	for _, seat := range openedSeats {
+		te.emitSeatOpenedEvent(seat)
+	}
+	return nil
+}
+
+// markEliminatedPlayers records the leaving players without chips (e.g. giving up rebuy) as eliminated, see PlayerReEntry
+func (te *tableEngine) markEliminatedPlayers(playerIDs []string) {
+	for _, playerID := range playerIDs {
+		if playerIdx := te.table.FindPlayerIdx(playerID); !IsUnset(playerIdx) && te.table.State.PlayerStates[playerIdx].Bankroll <= 0 {
+			te.reEntries.markEliminated(te.table.Meta.CompetitionID, playerID)
+		}
+	}
+}
+
+/*
+keepSessionStats keeps the session stats of leaving players
+  - Only when PreserveSessionStats is enabled, otherwise stats are reset when the player rejoins
+*/
+func (te *tableEngine) keepSessionStats(playerIDs []string) {
+	if !te.options.PreserveSessionStats {
+		return
+	}
+
+	for _, playerID := range playerIDs {
+		playerIdx := te.table.FindPlayerIdx(playerID)
+		if IsUnset(playerIdx) {
+			continue
+		}
+
+		player := te.table.State.PlayerStates[playerIdx]
+		te.leftSessionStats[playerID] = SessionStats{
+			PlayerID:     player.PlayerID,
+			Bankroll:     player.Bankroll,
+			BuyInTotal:   player.BuyInTotal,
+			AddOnCount:   player.AddOnCount,
+			AddOnTotal:   player.AddOnTotal,
+			CashOutTotal: player.CashOutTotal,
+			SessionNet:   player.SessionNet,
+		}
+	}
+}
+
+/*
+restoreSessionStats carries the session stats over to a rejoining player
+  - Chips taken away when leaving are added to CashOutTotal, so BuyInTotal stays the chips bought & SessionNet is preserved
+*/
+func (te *tableEngine) restoreSessionStats(player *TablePlayerState) {
+	stats, exist := te.leftSessionStats[player.PlayerID]
+	if !exist {
+		return
+	}
+
+	delete(te.leftSessionStats, player.PlayerID)
+	player.BuyInTotal += stats.BuyInTotal
+	player.AddOnCount += stats.AddOnCount
+	player.AddOnTotal += stats.AddOnTotal
+	player.CashOutTotal += stats.CashOutTotal + stats.Bankroll
+	player.updateSessionNet()
+}
+
+// seatIDFrom returns the seat id offset seats after startSeatID in the table's rotation direction
+func (te *tableEngine) seatIDFrom(startSeatID, offset, seatCount int) int {
+	return seatIDFrom(startSeatID, offset, seatCount, te.table.Meta.RotationDirection)
+}
+
+func (te *tableEngine) updateNextBBOrderPlayerIDs() {
+	te.table.State.NextBBOrderPlayerIDs = te.refreshNextBBOrderPlayerIDs(te.sm.CurrentBBSeatID(), te.table.Meta.TableMaxSeatCount, te.table.State.PlayerStates, te.table.State.SeatMap)
 }
 
 func (te *tableEngine) refreshNextBBOrderPlayerIDs(currentBBSeatID, tableMaxSeatCount int, players []*TablePlayerState, seatMap map[int]int) []string {
 	nextBBOrderPlayerIDs := make([]string, 0)
-	for i := currentBBSeatID + 1; i <= tableMaxSeatCount+currentBBSeatID; i++ {
-		newBBSeatID := i % tableMaxSeatCount
+	for i := 1; i <= tableMaxSeatCount; i++ {
+		newBBSeatID := te.seatIDFrom(currentBBSeatID, i, tableMaxSeatCount)
 		if playerIdx, exists := seatMap[newBBSeatID]; exists && playerIdx >= 0 && playerIdx < len(players) && players[playerIdx].Bankroll > 0 {
 			nextBBOrderPlayerIDs = append(nextBBOrderPlayerIDs, players[playerIdx].PlayerID)
 		}
@@ -334,9 +1637,34 @@ func (te *tableEngine) refreshNextBBOrderPlayerIDs(currentBBSeatID, tableMaxSeat
 	return nextBBOrderPlayerIDs
 }
 
+// countDealablePlayers returns the number of joined players with chips
+func countDealablePlayers(players []*TablePlayerState) int {
+	count := 0
+	for _, player := range players {
+		if player.IsIn && player.Bankroll > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+/*
+calcGamePlayerIndexes returns the table player indexes dealt in, starting from the dealer
+  - Empty when fewer than two players participate, no hand can be dealt
+*/
 func (te *tableEngine) calcGamePlayerIndexes(rule string, maxSeatCount, currentDealerSeatID, currentSBSeatID, currentBBSeatID int, seatMap map[int]int, players []*TablePlayerState) []int {
 	playerLen := len(players)
 	gamePlayerIndexes := make([]int, 0)
+	participatedCount := 0
+	for _, player := range players {
+		if player.IsParticipated {
+			participatedCount++
+		}
+	}
+	if participatedCount < 2 {
+		return gamePlayerIndexes
+	}
+
 	playerPositions := make(map[int][]string) // key: player_index, value: positions
 	if rule == CompetitionRule_ShortDeck {
 		dealerPlayerIdx := seatMap[currentDealerSeatID]
@@ -370,43 +1698,29 @@ func (te *tableEngine) calcGamePlayerIndexes(rule string, maxSeatCount, currentD
 		}
 
 		// find by dealer or empty-dealer situation
-		if dealerPlayerIdx == UnsetValue {
-			// find fake dealer index
-			fakeDealerSeatID := UnsetValue
-			startSeatID := UnsetValue
-			if sbPlayerIdx == UnsetValue {
-				// empty sb, use prior bb active player as fake dealer
-				startSeatID = currentBBSeatID
-			} else {
-				// has sb, use prior sb active player as fake dealer
-				startSeatID = currentSBSeatID
-			}
-
-			// find fake dealer seat id
-			for i := startSeatID + maxSeatCount - 1; i >= startSeatID; i-- {
-				seatID := i % maxSeatCount
-				if sp, ok := te.sm.Seats()[seatID]; ok && sp != nil && sp.Active() {
-					fakeDealerSeatID = seatID
-					break
-				}
+		activeSeatIDs := make([]int, 0)
+		for seatID, sp := range te.sm.Seats() {
+			if sp != nil && sp.Active() {
+				activeSeatIDs = append(activeSeatIDs, seatID)
 			}
+		}
+		dealerSeatID := te.dealerSelector.SelectDealerSeat(DealerSelection{
+			MaxSeatCount:      maxSeatCount,
+			DealerSeatID:      currentDealerSeatID,
+			SBSeatID:          currentSBSeatID,
+			BBSeatID:          currentBBSeatID,
+			HasDealerPlayer:   !IsUnset(dealerPlayerIdx),
+			HasSBPlayer:       !IsUnset(sbPlayerIdx),
+			ActiveSeatIDs:     activeSeatIDs,
+			RotationDirection: te.table.Meta.RotationDirection,
+		})
 
-			// create game player indexes (starts at fake dealer player index)
-			for i := fakeDealerSeatID; i < len(seatMap)+fakeDealerSeatID; i++ {
-				seatID := i % len(seatMap)
-				playerIdx := seatMap[seatID]
-				if playerIdx >= 0 && players[playerIdx].IsParticipated {
-					gamePlayerIndexes = append(gamePlayerIndexes, playerIdx)
-				}
-			}
-		} else {
-			startSeatID := currentDealerSeatID
-			for i := startSeatID; i < len(seatMap)+startSeatID; i++ {
-				seatID := i % len(seatMap)
-				playerIdx := seatMap[seatID]
-				if playerIdx >= 0 && players[playerIdx].IsParticipated {
-					gamePlayerIndexes = append(gamePlayerIndexes, playerIdx)
-				}
+		// create game player indexes (starts at dealer or fake dealer player index)
+		for i := 0; i < len(seatMap); i++ {
+			seatID := te.seatIDFrom(dealerSeatID, i, len(seatMap))
+			playerIdx := seatMap[seatID]
+			if playerIdx >= 0 && players[playerIdx].IsParticipated {
+				gamePlayerIndexes = append(gamePlayerIndexes, playerIdx)
 			}
 		}
 	}
@@ -1,15 +1,35 @@
 package pokertable
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/d-protocol/pokerlib"
 	"github.com/d-protocol/pokerlib/settlement"
+	"github.com/google/uuid"
 	"github.com/thoas/go-funk"
 )
 
+var (
+	openGameRetryCount    = 10 // retries after the first failed attempt to open a hand
+	openGameRetryInterval = time.Second * 3
+)
+
+// openGameError is an ErrTableOpenGameFailed with the specific reason
+type openGameError struct {
+	reason string
+}
+
+func (e *openGameError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrTableOpenGameFailed.Error(), e.reason)
+}
+
+func (e *openGameError) Unwrap() error {
+	return ErrTableOpenGameFailed
+}
+
 func (te *tableEngine) tableGameOpen() error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
@@ -20,16 +40,24 @@ func (te *tableEngine) tableGameOpen() error {
 	}
 
 	// Start the game
+	te.resetOpenGameFailure()
 	newTable, err := te.openGame(te.table)
 
-	retry := 10
 	if err != nil {
+		// Too few players won't change by retrying, the competition closes or merges the table
+		if errors.Is(err, ErrTableOpenGameFailedNotEnoughPlayers) {
+			te.recordOpenGameFailure(err)
+			te.emitTableStarvedEvent()
+			return nil
+		}
+
 		// Retry opening the game within 30 seconds
 		if errors.Is(err, ErrTableOpenGameFailed) {
+			te.recordOpenGameFailure(err)
 			reopened := false
 
-			for i := 0; i < retry; i++ {
-				time.Sleep(time.Second * 3)
+			for i := 0; i < openGameRetryCount; i++ {
+				time.Sleep(openGameRetryInterval)
 
 				// Game already started, do nothing
 				gameStartingStatuses := []TableStateStatus{
@@ -45,10 +73,12 @@ func (te *tableEngine) tableGameOpen() error {
 				newTable, err = te.openGame(te.table)
 				if err != nil {
 					if errors.Is(err, ErrTableOpenGameFailed) {
+						te.recordOpenGameFailure(err)
 						fmt.Printf("table (%s): failed to open game. retry %d time(s)...\n", te.table.ID, i+1)
 						continue
 					} else if errors.Is(err, ErrTableOpenGameFailedInBlindBreakingLevel) {
 						// Already in a break, do nothing
+						te.recordOpenGameFailure(err)
 						fmt.Printf("table (%s): failed to open game when blind level is negative\n", te.table.ID)
 						return nil
 					} else {
@@ -61,10 +91,12 @@ func (te *tableEngine) tableGameOpen() error {
 			}
 
 			if !reopened {
-				return err
+				reason, attempts := te.GetLastOpenGameFailure()
+				return fmt.Errorf("%w after %d attempt(s): %s", ErrTableOpenGameFailed, attempts, reason)
 			}
 		} else if errors.Is(err, ErrTableOpenGameFailedInBlindBreakingLevel) {
 			// Already in a break, do nothing
+			te.recordOpenGameFailure(err)
 			fmt.Printf("table (%s): failed to open game when blind level is negative\n", te.table.ID)
 			return nil
 		} else {
@@ -75,19 +107,28 @@ func (te *tableEngine) tableGameOpen() error {
 	te.emitEvent("tableGameOpen", "")
 
 	// Start the game engine for this hand
-	return te.startGame()
+	if err := te.startGame(); err != nil {
+		te.seatsLocked.Store(false)
+		return err
+	}
+	return nil
 }
 
 func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 	// Step 1: Check TableState
 	if !oldTable.State.BlindState.IsSet() {
-		return oldTable, ErrTableOpenGameFailed
+		return oldTable, &openGameError{reason: "blind is not set"}
 	}
 
 	if oldTable.State.BlindState.IsBreaking() {
 		return oldTable, ErrTableOpenGameFailedInBlindBreakingLevel
 	}
 
+	// A single player left (e.g. the other one busted), there is nobody to play against
+	if countDealablePlayers(oldTable.State.PlayerStates) < 2 {
+		return oldTable, ErrTableOpenGameFailedNotEnoughPlayers
+	}
+
 	// Step 2: Clone Table for calculation
 	cloneTable, err := oldTable.Clone()
 	if err != nil {
@@ -99,17 +140,18 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 
 	// Step 4: Calculate seats
 	if !te.sm.IsInitPositions() {
-		if err := te.sm.InitPositions(true); err != nil {
-			return oldTable, ErrTableOpenGameFailed
+		if err := te.initPositions(cloneTable.Meta.InitialButtonMode); err != nil {
+			return oldTable, &openGameError{reason: fmt.Sprintf("unable to init positions (%v)", err)}
 		}
 	} else {
 		if err := te.sm.RotatePositions(); err != nil {
-			return oldTable, ErrTableOpenGameFailed
+			return oldTable, &openGameError{reason: fmt.Sprintf("unable to rotate positions (%v)", err)}
 		}
+		te.updateMissedBlinds(cloneTable, oldTable.State.NextBBOrderPlayerIDs)
 	}
 
 	// Step 5: Update information about players participating in this hand
-	// update player is_participated
+	// update player is_participated & stack at hand start
 	for i := 0; i < len(cloneTable.State.PlayerStates); i++ {
 		player := cloneTable.State.PlayerStates[i]
 		active, err := te.sm.IsPlayerActive(player.PlayerID)
@@ -117,6 +159,7 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 			return oldTable, err
 		}
 		player.IsParticipated = active
+		player.StackAtHandStart = player.Bankroll
 	}
 
 	// update gamePlayerIndexes & positions
@@ -139,6 +182,9 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 	cloneTable.State.CurrentSBSeat = te.sm.CurrentSBSeatID()
 	cloneTable.State.CurrentBBSeat = te.sm.CurrentBBSeatID()
 
+	// GamePlayerIndexes are bound to the seats until the hand is over
+	te.seatsLocked.Store(true)
+
 	return cloneTable, nil
 }
 
@@ -153,10 +199,8 @@ func (te *tableEngine) startGame() error {
 	if rule == CompetitionRule_ShortDeck {
 		opts = pokerlib.NewShortDeckGameOptions()
 		opts.Deck = pokerlib.NewShortDeckCards()
-	} else if rule == CompetitionRule_Omaha {
-		opts.HoleCardsCount = 4
-		opts.RequiredHoleCardsCount = 2
 	}
+	opts.HoleCardsCount, opts.RequiredHoleCardsCount = te.table.Meta.holeCardsCounts()
 
 	// preparing blind
 	opts.Ante = blind.Ante
@@ -168,44 +212,161 @@ func (te *tableEngine) startGame() error {
 
 	// preparing players
 	playerSettings := make([]*pokerlib.PlayerSetting, 0)
-	for _, playerIdx := range te.table.State.GamePlayerIndexes {
+	te.deadBlinds = make(map[int]int64)
+	for gamePlayerIdx, playerIdx := range te.table.State.GamePlayerIndexes {
 		player := te.table.State.PlayerStates[playerIdx]
+		bankroll := player.Bankroll
+		if player.MustPostBB {
+			// late registration: a dead big blind goes into the pot, it is not a live bet & the BB stays where it is
+			// a stack that can't cover it plays without posting
+			if !funk.Contains(player.Positions, Position_BB) && bankroll > blind.BB {
+				te.deadBlinds[gamePlayerIdx] = blind.BB
+				bankroll -= blind.BB
+			}
+			player.MustPostBB = false
+		}
 		playerSettings = append(playerSettings, &pokerlib.PlayerSetting{
-			Bankroll:  player.Bankroll,
+			Bankroll:  bankroll,
 			Positions: player.Positions,
 		})
 	}
 	if !funk.Contains(playerSettings[0].Positions, Position_Dealer) {
+		// dead button: the first player only marks where the game starts, nobody posts the dealer blind
 		playerSettings[0].Positions = append(playerSettings[0].Positions, Position_Dealer)
+		opts.Blind.Dealer = 0
 	}
 	opts.Players = playerSettings
 
+	// a redealt misdeal keeps the same hand id
+	te.table.State.GlobalHandID = uuid.New().String()
+	te.misdealCount = 0
+	if err := te.dealGame(opts); err != nil {
+		return err
+	}
+
+	te.emitDeadBlinds()
+	return nil
+}
+
+// emitDeadBlinds emits the dead big blinds posted into the hand, the posters are not the BB of the game
+func (te *tableEngine) emitDeadBlinds() {
+	for gamePlayerIdx, playerIdx := range te.table.State.GamePlayerIndexes {
+		chips, exist := te.deadBlinds[gamePlayerIdx]
+		if !exist {
+			continue
+		}
+
+		player := te.table.State.PlayerStates[playerIdx]
+		pga := te.createPlayerGameAction(player.PlayerID, playerIdx, Action_Pay, chips, te.game.GetGameState().GetPlayer(gamePlayerIdx))
+		pga.Round = Position_DeadBB
+		pga.IsForced = true
+		te.emitGamePlayerActionEvent(*pga)
+		te.emitBlindPostedEvent(player.PlayerID, Position_DeadBB, chips)
+	}
+}
+
+// dealGame creates & starts the game of the hand, the caller must hold te.lock
+func (te *tableEngine) dealGame(opts *pokerlib.GameOptions) error {
+	blind := te.table.State.BlindState
+
 	// create game
-	te.game = NewGame(te.gameBackend, opts)
+	gameOpts := make([]GameOpt, 0)
+	if te.table.Meta.AutoPostBlinds {
+		gameOpts = append(gameOpts, WithAutoPostBlinds())
+	}
+	if te.options.ManualRoundAdvance {
+		gameOpts = append(gameOpts, WithManualRoundAdvance())
+	}
+	g := NewGame(te.gameBackend, opts, gameOpts...)
+	te.game = g
+	handHistory, err := newHandHistory(te.table, opts)
+	if err != nil {
+		te.emitErrorEvent("dealGame#newHandHistory", "", err)
+	}
+	te.handHistory = handHistory
+	te.handChips = 0
+	for _, player := range opts.Players {
+		te.handChips += player.Bankroll
+	}
+	for _, chips := range te.deadBlinds {
+		te.handChips += chips
+	}
+	// The game callbacks run on the game goroutine, they take the lock & their callbacks are delivered once it is released
 	te.game.OnGameStateUpdated(func(gs *pokerlib.GameState) {
+		te.lock.LockDeferringEmits()
+		defer te.lock.Unlock()
+
+		// voided by a misdeal
+		if te.game != g {
+			return
+		}
+
+		if reason := validateDeal(gs, opts.HoleCardsCount); reason != "" {
+			te.handleMisdeal(g, opts, reason)
+			return
+		}
+
 		te.updateGameState(gs)
 	})
 	te.game.OnGameErrorUpdated(func(gs *pokerlib.GameState, err error) {
+		te.lock.LockDeferringEmits()
+		defer te.lock.Unlock()
+
+		// the backend kept timing out on an automatic transition, nothing moves the hand on so it's dealt again
+		if errors.Is(err, ErrBackendTimeout) {
+			te.emitErrorEvent("OnGameErrorUpdated", "", err)
+			te.handleMisdeal(g, opts, err.Error())
+			return
+		}
+
 		te.table.State.GameState = gs
-		go te.emitErrorEvent("OnGameErrorUpdated", "", err)
+		te.emitErrorEvent("OnGameErrorUpdated", "", err)
+	})
+	te.game.OnPlayerAutoReady(func(gamePlayerIdx int) {
+		te.lock.LockDeferringEmits()
+		defer te.lock.Unlock()
+
+		if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx); !IsUnset(playerIdx) {
+			te.emitPlayerAutoReadyEvent(te.table.State.PlayerStates[playerIdx].PlayerID, AutoReadyPhase_Game)
+		}
 	})
 	te.game.OnAntesReceived(func(gs *pokerlib.GameState) {
+		te.lock.LockDeferringEmits()
+		defer te.lock.Unlock()
+
 		for gpIdx, p := range gs.Players {
-			if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gpIdx); playerIdx != UnsetValue {
+			if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gpIdx); !IsUnset(playerIdx) {
+				// A player who can't cover the ante posts the rest of the stack and goes all-in
+				action := Action_Pay
+				if p.StackSize == 0 {
+					action = WagerAction_AllIn
+				}
+
 				player := te.table.State.PlayerStates[playerIdx]
-				pga := te.createPlayerGameAction(player.PlayerID, playerIdx, "pay", player.Bankroll, p)
+				pga := te.createPlayerGameAction(player.PlayerID, playerIdx, action, p.Pot, p)
 				pga.Round = "ante"
+				pga.IsForced = true
 				te.emitGamePlayerActionEvent(*pga)
 			}
 		}
 	})
 	te.game.OnBlindsReceived(func(gs *pokerlib.GameState) {
+		te.lock.LockDeferringEmits()
+		defer te.lock.Unlock()
+
 		for gpIdx, p := range gs.Players {
 			for _, pos := range p.Positions {
 				if funk.Contains([]string{Position_SB, Position_BB}, pos) {
-					if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gpIdx); playerIdx != UnsetValue {
+					if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(gpIdx); !IsUnset(playerIdx) {
+						// A player who can't cover the blind posts the rest of the stack and goes all-in
+						action := Action_Pay
+						if p.StackSize == 0 {
+							action = WagerAction_AllIn
+						}
+
 						player := te.table.State.PlayerStates[playerIdx]
-						pga := te.createPlayerGameAction(player.PlayerID, playerIdx, "pay", player.Bankroll, p)
+						pga := te.createPlayerGameAction(player.PlayerID, playerIdx, action, player.Bankroll, p)
+						pga.IsForced = true
 						te.emitGamePlayerActionEvent(*pga)
 					}
 				}
@@ -213,28 +374,107 @@ func (te *tableEngine) startGame() error {
 		}
 	})
 	te.game.OnGameRoundClosed(func(gs *pokerlib.GameState) {
+		te.lock.LockDeferringEmits()
+		defer te.lock.Unlock()
+
 		te.table.State.CurrentActionEndAt = 0
+		te.offerInsurance(gs)
 	})
 
 	// start game
-	if _, err := te.game.Start(); err != nil {
+	gs, err := te.game.Start()
+	if err != nil {
 		return err
 	}
 
+	// record the deck in dealing order
+	if te.handHistory != nil {
+		te.handHistory.Options.Deck = append([]string{}, gs.Meta.Deck...)
+	}
+
+	// publish hand commitment
+	if err := te.commitHand(gs); err != nil {
+		te.emitErrorEvent("startGame#commitHand", "", err)
+	}
+
 	te.table.State.Status = TableStateStatus_TableGamePlaying
 	te.table.State.GameBlindState = &TableBlindState{
 		Level:  blind.Level,
 		Ante:   blind.Ante,
-		Dealer: blind.Dealer,
+		Dealer: opts.Blind.Dealer,
 		SB:     blind.SB,
 		BB:     blind.BB,
 	}
+	handBlindState := *te.table.State.GameBlindState
+	te.handBlindStates.Store(te.table.State.GameCount, &handBlindState)
+	te.retainHand(te.table.State.GameCount, gs.GameID)
+	return nil
+}
+
+type retainedHand struct {
+	gameCount int
+	gameID    string
+}
+
+/*
+retainHand records the dealt hand & drops the records of the hands beyond the hand retention, see WithHandRetention
+  - A redealt misdeal replaces the game of the hand, the seed of the voided game is released right away
+  - Records of a game count reused by a later hand (the game count starts over after a merge) belong to the later hand & are kept
+*/
+func (te *tableEngine) retainHand(gameCount int, gameID string) {
+	if te.handRetention <= 0 {
+		return
+	}
+
+	if last := len(te.retainedHands) - 1; last >= 0 && te.retainedHands[last].gameCount == gameCount {
+		te.releaseSeed(te.retainedHands[last].gameID)
+		te.retainedHands = te.retainedHands[:last]
+	}
+	te.retainedHands = append(te.retainedHands, retainedHand{gameCount: gameCount, gameID: gameID})
+
+	for len(te.retainedHands) > te.handRetention {
+		hand := te.retainedHands[0]
+		te.retainedHands = te.retainedHands[1:]
+		te.releaseSeed(hand.gameID)
+
+		isReused := false
+		for _, retained := range te.retainedHands {
+			isReused = isReused || retained.gameCount == hand.gameCount
+		}
+		if !isReused {
+			te.handCommitments.Delete(hand.gameCount)
+			te.handBlindStates.Delete(hand.gameCount)
+			te.handHistories.Delete(hand.gameCount)
+		}
+	}
+}
+
+func (te *tableEngine) releaseSeed(gameID string) {
+	if releaser, ok := te.gameBackend.(seedReleaser); ok {
+		releaser.ReleaseSeed(gameID)
+	}
+}
+
+func (te *tableEngine) commitHand(gs *pokerlib.GameState) error {
+	seed, err := te.gameBackend.GetSeed(gs.GameID)
+	if err != nil {
+		return err
+	}
+
+	commitment := NewHandCommitment(seed, gs.Meta.Deck)
+	te.handSeed = seed
+	te.handCommitments.Store(te.table.State.GameCount, commitment)
+	te.table.State.HandCommitment = commitment
 	return nil
 }
 
+// settleGame applies the result of the closed game to the table in a single lock section, the caller must hold te.lock
 func (te *tableEngine) settleGame() []*TablePlayerState {
 	te.table.State.Status = TableStateStatus_TableGameSettled
 
+	// Reveal hand seed
+	te.table.State.HandSeed = te.handSeed
+
 	// Calculate showdown winning chance
 	notFoldCount := 0
 	for _, result := range te.table.State.GameState.Result.Players {
@@ -256,7 +496,7 @@ func (te *tableEngine) settleGame() []*TablePlayerState {
 	winnerPlayerIndexes := make(map[int]bool)
 	for _, winnerGamePlayerIndex := range winnerGamePlayerIndexes {
 		playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(winnerGamePlayerIndex)
-		if playerIdx == UnsetValue {
+		if IsUnset(playerIdx) {
 			fmt.Printf("[DEBUGsettleGame] can't find player index from game player index (%d)", winnerGamePlayerIndex)
 			continue
 		}
@@ -264,12 +504,34 @@ func (te *tableEngine) settleGame() []*TablePlayerState {
 		winnerPlayerIndexes[playerIdx] = true
 	}
 
+	// No winner among the remaining players (backend bug), keep the bankrolls before the hand instead of applying the result
+	hasNoWinner := len(winnerPlayerIndexes) == 0 && rank.ContributorCount() > 0
+	if hasNoWinner {
+		gsJSON, _ := json.Marshal(te.table.State.GameState)
+		fmt.Printf("[DEBUG#settleGame] Table (%s) game (%s) has no winner. Game state: %s\n", te.table.ID, te.table.State.GameState.GameID, string(gsJSON))
+		te.emitErrorEvent("settleGame", "", ErrSettlementNoWinner)
+	}
+
+	if !hasNoWinner {
+		te.settleDeadBlinds(winnerGamePlayerIndexes)
+		te.roundPotSplits()
+	}
+
 	// Update player chips based on win/loss to their bankroll
+	sawFlop := te.table.State.GameState.Status.Round != GameRound_Preflop
 	alivePlayers := make([]*TablePlayerState, 0)
+	handResults := make([]PlayerHandResult, 0, len(te.table.State.GameState.Result.Players))
+	insuranceChips := int64(0)
 	for _, player := range te.table.State.GameState.Result.Players {
 		playerIdx := te.table.State.GamePlayerIndexes[player.Idx]
 		playerState := te.table.State.PlayerStates[playerIdx]
-		playerState.Bankroll = player.Final
+		if !hasNoWinner {
+			_, isWinner := winnerPlayerIndexes[playerIdx]
+			insuranceChanged := te.settleInsurance(playerState.PlayerID, isWinner && len(winnerPlayerIndexes) == 1)
+			insuranceChips += insuranceChanged
+			playerState.Bankroll = player.Final + insuranceChanged
+			playerState.updateSessionNet()
+		}
 
 		// Update player showdown winning chance
 		p := te.table.State.GameState.GetPlayer(player.Idx)
@@ -282,28 +544,128 @@ func (te *tableEngine) settleGame() []*TablePlayerState {
 			playerState.GameStatistics.ShowdownWinningChance = false
 		}
 
+		handResults = append(handResults, PlayerHandResult{
+			PlayerID:       playerState.PlayerID,
+			GamePlayerIdx:  player.Idx,
+			SawFlop:        sawFlop && (p == nil || !p.Fold || playerState.GameStatistics.FoldRound != GameRound_Preflop),
+			WentToShowdown: playerState.GameStatistics.ShowdownWinningChance,
+			Won:            player.Changed > 0,
+			Changed:        player.Changed,
+		})
+	}
+
+	// Custom settlement (e.g. bounties) on top of the standard one, under the lock of the settlement
+	if te.settlementHook != nil && !hasNoWinner {
+		if err := te.settlementHook(te.table.State.GameState.Result, te.table); err != nil {
+			te.emitErrorEvent("settleGame#settlementHook", "", err)
+		}
+	}
+
+	for i, player := range te.table.State.GameState.Result.Players {
+		playerState := te.table.State.PlayerStates[te.table.State.GamePlayerIndexes[player.Idx]]
+		if te.settlementHook != nil {
+			playerState.updateSessionNet()
+		}
+		handResults[i].Net = playerState.Bankroll - playerState.StackAtHandStart
+
 		if playerState.Bankroll > 0 {
 			alivePlayers = append(alivePlayers, playerState)
+		} else {
+			te.reEntries.markEliminated(te.table.Meta.CompetitionID, playerState.PlayerID)
+		}
+	}
+
+	if te.handHistory != nil {
+		te.handHistory.Bankrolls = make(map[string]int64)
+		for _, player := range te.table.State.GameState.Result.Players {
+			playerState := te.table.State.PlayerStates[te.table.State.GamePlayerIndexes[player.Idx]]
+			te.handHistory.Bankrolls[playerState.PlayerID] = playerState.Bankroll
 		}
+		te.handHistories.Store(te.table.State.GameCount, te.handHistory)
+		te.handHistory = nil
+	}
+
+	if err := te.checkChipConservation(insuranceChips); err != nil {
+		te.emitErrorEvent("settleGame", "", err)
 	}
 
 	// Update NextBBOrderPlayerIDs (remove players without chips)
-	te.table.State.NextBBOrderPlayerIDs = te.refreshNextBBOrderPlayerIDs(te.sm.CurrentBBSeatID(), te.table.Meta.TableMaxSeatCount, te.table.State.PlayerStates, te.table.State.SeatMap)
+	te.updateNextBBOrderPlayerIDs()
+
+	// Everyone folded to the BB preflop (walk)
+	if notFoldCount == 1 {
+		if bbPlayerID, amount, ok := te.walkResult(); ok {
+			te.emitWalkEvent(bbPlayerID, amount)
+		}
+	}
 
 	te.emitEvent("SettleTableGameResult", "")
 	te.emitTableStateEvent(TableStateEvent_GameSettled)
+	te.emitGameSettledEvent(te.table.State.GameCount, handResults)
 
 	return alivePlayers
 }
 
+/*
+walkResult returns the BB player id and the chips it wins if the hand ended as a walk
+  - Walk: everyone folds to the BB preflop, no showdown
+*/
+func (te *tableEngine) walkResult() (string, int64, bool) {
+	gs := te.table.State.GameState
+	if gs.Status.Round != GameRound_Preflop {
+		return "", 0, false
+	}
+
+	for _, p := range gs.Players {
+		if p.Fold {
+			continue
+		}
+
+		playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(p.Idx)
+		if IsUnset(playerIdx) || !funk.Contains(te.table.State.PlayerStates[playerIdx].Positions, Position_BB) {
+			return "", 0, false
+		}
+
+		amount := int64(0)
+		for _, result := range gs.Result.Players {
+			if result.Idx == p.Idx {
+				amount = result.Changed
+			}
+		}
+		return te.table.State.PlayerStates[playerIdx].PlayerID, amount, true
+	}
+
+	return "", 0, false
+}
+
 func (te *tableEngine) continueGame(alivePlayers []*TablePlayerState) error {
+	// The hand is over, seats can change again
+	te.seatsLocked.Store(false)
+
 	// Reset table state
 	te.table.State.Status = TableStateStatus_TableGameStandby
 	te.table.State.GamePlayerIndexes = make([]int, 0)
-	te.table.State.NextBBOrderPlayerIDs = make([]string, 0)
 	te.table.State.CurrentActionEndAt = 0
 	te.table.State.GameState = nil
 	te.table.State.LastPlayerGameAction = nil
+	te.table.State.HandCommitment = ""
+	te.table.State.HandSeed = ""
+	te.table.State.Insurances = nil
+	te.table.State.GlobalHandID = ""
+	te.handSeed = ""
+	te.applyPendingBlind()
+
+	// Top up before deciding who is able to play the next hand
+	toppedUpPlayers, err := te.autoRebuyPlayers()
+	if err != nil {
+		return err
+	}
+	for _, player := range toppedUpPlayers {
+		if !funk.Contains(alivePlayers, player) {
+			alivePlayers = append(alivePlayers, player)
+		}
+	}
+
 	for i := 0; i < len(te.table.State.PlayerStates); i++ {
 		playerState := te.table.State.PlayerStates[i]
 		playerState.Positions = make([]string, 0)
@@ -329,9 +691,20 @@ func (te *tableEngine) continueGame(alivePlayers []*TablePlayerState) error {
 		ctMTTAutoGameOpenEnd = time.Now().Unix() > tableEndAt
 	}
 
+	// Hard stop is due, the hand just settled is the last one (any mode)
+	hardStopAt := te.hardStopAt.Load()
+	if hardStopAt > 0 && time.Now().Unix() >= hardStopAt {
+		ctMTTAutoGameOpenEnd = true
+	}
+
 	if ctMTTAutoGameOpenEnd {
 		nextMoveInterval = 1
 		nextMoveHandler = func() error {
+			// No hand opens anymore, a deferred pause must not pause a later session
+			te.lock.Lock()
+			te.pauseRequested = false
+			te.lock.Unlock()
+
 			fmt.Printf("[DEBUG#continueGame] delay -> not auto opened %s table (%s), end: %s, now: %s\n", te.table.Meta.Mode, te.table.ID, time.Unix(te.table.State.StartAt, 0).Add(time.Second*time.Duration(te.table.Meta.MaxDuration)), time.Now())
 			te.onAutoGameOpenEnd(te.table.Meta.CompetitionID, te.table.ID)
 			return nil
@@ -339,20 +712,26 @@ func (te *tableEngine) continueGame(alivePlayers []*TablePlayerState) error {
 	} else {
 		nextMoveInterval = te.options.GameContinueInterval
 		nextMoveHandler = func() error {
-			// If the table is closed during the Interval, do not continue
-			if te.table.State.Status == TableStateStatus_TableClosed {
+			// The deferred pause is consumed on every path, under the lock PauseTable defers it with
+			te.lock.Lock()
+			isPauseRequested := te.pauseRequested
+			te.pauseRequested = false
+
+			// If the table is closed or released during the Interval, do not continue
+			if te.table.State.Status == TableStateStatus_TableClosed || te.isReleased.Load() {
+				te.lock.Unlock()
 				return nil
 			}
 
-			// If the table is released during the Interval, do not continue
-			if te.isReleased {
-				return nil
+			// Table continuation: pause or open
+			shouldPause := isPauseRequested || te.table.ShouldPause()
+			if shouldPause {
+				te.table.State.Status = TableStateStatus_TablePausing
 			}
+			te.lock.Unlock()
 
-			// Table continuation: pause or open
-			if te.table.ShouldPause() {
+			if shouldPause {
 				// Pause processing
-				te.table.State.Status = TableStateStatus_TablePausing
 				te.emitEvent("ContinueGame -> Pause", "")
 				te.emitTableStateEvent(TableStateEvent_StatusUpdated)
 			} else {
@@ -363,7 +742,15 @@ func (te *tableEngine) continueGame(alivePlayers []*TablePlayerState) error {
 					for idx, player := range alivePlayers {
 						participants[player.PlayerID] = idx
 					}
-					te.SetUpTableGame(nextGameCount, participants)
+					if err := te.SetUpTableGame(nextGameCount, participants); err != nil {
+						te.emitErrorEvent("continueGame#SetUpTableGame", "", err)
+					}
+					return nil
+				}
+
+				// Too few alive players to open a hand, the competition closes or merges the table
+				if len(te.table.AlivePlayers()) < te.table.Meta.TableMinPlayerCount {
+					te.emitTableStarvedEvent()
 					return nil
 				}
 
@@ -375,5 +762,6 @@ func (te *tableEngine) continueGame(alivePlayers []*TablePlayerState) error {
 		}
 	}
 
-	return te.delay(nextMoveInterval, nextMoveHandler)
+	te.delay(nextMoveInterval, "continueGame", nextMoveHandler)
+	return nil
 }
@@ -0,0 +1,3760 @@
+package pokertable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/pot"
+	"github.com/d-protocol/pokerlib/settlement"
+	"github.com/d-protocol/syncsaga"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func newTestTableSetting() TableSetting {
+	return TableSetting{
+		TableID: uuid.New().String(),
+		Meta: TableMeta{
+			CompetitionID:       uuid.New().String(),
+			Rule:                CompetitionRule_Default,
+			Mode:                CompetitionMode_CT,
+			MaxDuration:         10,
+			TableMaxSeatCount:   9,
+			TableMinPlayerCount: 2,
+			MinChipUnit:         10,
+			ActionTime:          10,
+		},
+		Blind: TableBlindState{
+			Level: 1,
+			SB:    10,
+			BB:    20,
+		},
+	}
+}
+
+/*
+newTestPlayingTableEngine creates a table engine with initialized positions
+  - P1: seat 0 (bb), P2: seat 2, P3: seat 4 (dealer), P4: seat 6 (sb)
+*/
+func newTestPlayingTableEngine(t testing.TB, options *TableEngineOptions, opts ...TableEngineOpt) *tableEngine {
+	te := NewTableEngine(options, append([]TableEngineOpt{WithGameBackend(NewNativeGameBackend())}, opts...)...).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	for playerID, seat := range map[string]int{"P1": 0, "P2": 2, "P3": 4, "P4": 6} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+		assert.NoError(t, te.PlayerJoin(playerID))
+	}
+
+	assert.NoError(t, te.sm.InitPositions(false))
+	assert.Equal(t, 4, te.sm.CurrentDealerSeatID())
+	assert.Equal(t, 0, te.sm.CurrentBBSeatID())
+	te.updateNextBBOrderPlayerIDs()
+
+	return te
+}
+
+func TestTableEngine_LateRegBlindRule_WaitForBB(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.LateRegBlindRule = LateRegBlindRule_WaitForBB
+	te := newTestPlayingTableEngine(t, options)
+
+	// P5 joins directly behind the button
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}))
+	assert.NoError(t, te.PlayerJoin("P5"))
+
+	active, err := te.sm.IsPlayerActive("P5")
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assert.False(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P5")].MustPostBB)
+
+	// P6 joins as the next bb
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P6", RedeemChips: 1000, Seat: 1}))
+	assert.NoError(t, te.PlayerJoin("P6"))
+
+	active, err = te.sm.IsPlayerActive("P6")
+	assert.NoError(t, err)
+	assert.True(t, active)
+}
+
+func TestTableEngine_LateRegBlindRule_PostDead(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.LateRegBlindRule = LateRegBlindRule_PostDead
+	te := newTestPlayingTableEngine(t, options)
+
+	// P5 joins directly behind the button
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}))
+	assert.NoError(t, te.PlayerJoin("P5"))
+
+	active, err := te.sm.IsPlayerActive("P5")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.True(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P5")].MustPostBB)
+
+	// P6 joins between the button and the BB, waits for the button to pass & posts nothing
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P6", RedeemChips: 1000, Seat: 5}))
+	assert.NoError(t, te.PlayerJoin("P6"))
+
+	active, err = te.sm.IsPlayerActive("P6")
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assert.False(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P6")].MustPostBB)
+}
+
+func TestTableEngine_LateRegBlindRule_PostDead_DeadBlind(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.LateRegBlindRule = LateRegBlindRule_PostDead
+	te := newTestPlayingTableEngine(t, options)
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}))
+	assert.NoError(t, te.PlayerJoin("P5"))
+	openTestNextGame(t, te)
+
+	var mu sync.Mutex
+	posts := make(map[string]int64)
+	te.OnBlindPosted(func(playerID string, position string, amount int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		posts[playerID+":"+position] = amount
+	})
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.game.Close()
+	gs := te.game.GetGameState()
+	te.lock.Unlock()
+
+	// the dead blind is not a live bet, the game still has a single BB
+	bbCount := 0
+	for _, p := range gs.Players {
+		if funk.Contains(p.Positions, Position_BB) {
+			bbCount++
+		}
+	}
+	assert.Equal(t, 1, bbCount)
+
+	p5 := te.table.State.PlayerStates[te.table.FindPlayerIdx("P5")]
+	assert.False(t, p5.MustPostBB)
+	p5GamePlayerIdx := te.table.FindGamePlayerIdx("P5")
+	assert.Equal(t, int64(980), gs.GetPlayer(p5GamePlayerIdx).Bankroll)
+	assert.Equal(t, map[int]int64{p5GamePlayerIdx: 20}, te.deadBlinds)
+	assert.Equal(t, int64(5000), te.handChips)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int64{"P5:" + Position_DeadBB: 20}, posts)
+}
+
+func TestTableEngine_SettleDeadBlinds(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.deadBlinds = map[int]int64{3: 20}
+	te.table.State.GameState = &pokerlib.GameState{
+		Result: &settlement.Result{
+			Players: []*settlement.PlayerResult{
+				{Idx: 0, Final: 1015, Changed: 15},
+				{Idx: 1, Final: 1015, Changed: 15},
+				{Idx: 2, Final: 990, Changed: -10},
+				{Idx: 3, Final: 960, Changed: -20},
+			},
+			Pots: []*settlement.PotResult{
+				{Total: 70, Winners: []*settlement.Winner{{Idx: 0, Withdraw: 35}, {Idx: 1, Withdraw: 35}}},
+			},
+		},
+	}
+
+	// the main pot winners split the dead blind, the poster loses it
+	te.settleDeadBlinds([]int{0})
+	result := te.table.State.GameState.Result
+	assert.Equal(t, []int64{1025, 1025, 990, 960}, []int64{result.Players[0].Final, result.Players[1].Final, result.Players[2].Final, result.Players[3].Final})
+	assert.Equal(t, []int64{25, 25, -10, -40}, []int64{result.Players[0].Changed, result.Players[1].Changed, result.Players[2].Changed, result.Players[3].Changed})
+	assert.Equal(t, int64(90), result.Pots[0].Total)
+	assert.Equal(t, int64(45), result.Pots[0].Winners[0].Withdraw)
+}
+
+func TestTableEngine_LateRegBlindRule_None(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}))
+	assert.NoError(t, te.PlayerJoin("P5"))
+
+	active, err := te.sm.IsPlayerActive("P5")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.False(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P5")].MustPostBB)
+}
+
+func TestTableEngine_SessionStats_LeaveAndRejoin(t *testing.T) {
+	for _, preserve := range []bool{false, true} {
+		options := NewTableEngineOptions()
+		options.PreserveSessionStats = preserve
+		te := NewTableEngine(options, WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+		_, err := te.CreateTable(newTestTableSetting())
+		assert.NoError(t, err)
+
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P1", RedeemChips: 1000, Seat: 0}))
+		assert.NoError(t, te.PlayerRedeemChips(JoinPlayer{PlayerID: "P1", RedeemChips: 500}))
+
+		// P1 wins 300 chips
+		te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll = 1800
+		te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].updateSessionNet()
+
+		stats, err := te.GetPlayerSessionStats("P1")
+		assert.NoError(t, err)
+		assert.Equal(t, SessionStats{PlayerID: "P1", Bankroll: 1800, BuyInTotal: 1500, SessionNet: 300}, stats)
+
+		// P1 leaves with 1800 chips & rejoins with 1000 chips
+		assert.NoError(t, te.PlayersLeave([]string{"P1"}))
+		_, err = te.GetPlayerSessionStats("P1")
+		assert.ErrorIs(t, err, ErrTablePlayerNotFound)
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P1", RedeemChips: 1000, Seat: 0}))
+
+		stats, err = te.GetPlayerSessionStats("P1")
+		assert.NoError(t, err)
+		if preserve {
+			assert.Equal(t, SessionStats{PlayerID: "P1", Bankroll: 1000, BuyInTotal: 2500, CashOutTotal: 1800, SessionNet: 300}, stats)
+		} else {
+			assert.Equal(t, SessionStats{PlayerID: "P1", Bankroll: 1000, BuyInTotal: 1000, SessionNet: 0}, stats)
+		}
+	}
+}
+
+func TestTableEngine_ErrorChannel(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	table, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	te.emitErrorEvent("OnOpenGameReady#tableGameOpen", "P1", ErrTableOpenGameFailed)
+
+	tableErr := <-te.ErrorChannel()
+	assert.ErrorIs(t, tableErr, ErrTableOpenGameFailed)
+	assert.Equal(t, table.ID, tableErr.TableID)
+	assert.Equal(t, "OnOpenGameReady#tableGameOpen", tableErr.EventName)
+	assert.Equal(t, "P1", tableErr.PlayerID)
+
+	// errors are dropped instead of blocking when the buffer is full
+	for i := 0; i < TableErrorChannelSize+1; i++ {
+		te.emitErrorEvent("StartTableGame", "", ErrTableOpenGameFailed)
+	}
+	assert.Len(t, te.ErrorChannel(), TableErrorChannelSize)
+}
+
+func newTestPositionsTableEngine(t *testing.T, maxSeat int, seats []int) *tableEngine {
+	setting := newTestTableSetting()
+	setting.Meta.TableMaxSeatCount = maxSeat
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(setting)
+	assert.NoError(t, err)
+
+	for _, seat := range seats {
+		playerID := fmt.Sprintf("P%d", seat)
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+		assert.NoError(t, te.PlayerJoin(playerID))
+	}
+	assert.NoError(t, te.sm.InitPositions(false))
+	return te
+}
+
+// assertPositionsFromDealer asserts player positions clockwise from the dealer seat
+func assertPositionsFromDealer(t *testing.T, te *tableEngine, expected [][]string) {
+	te.updatePlayerPositions(te.table.Meta.TableMaxSeatCount, te.table.State.PlayerStates)
+
+	dealerSeatID := te.sm.CurrentDealerSeatID()
+	actual := make([][]string, 0)
+	for i := dealerSeatID; i < dealerSeatID+te.table.Meta.TableMaxSeatCount; i++ {
+		seatID := i % te.table.Meta.TableMaxSeatCount
+		if playerIdx := te.table.State.SeatMap[seatID]; playerIdx != UnsetValue {
+			actual = append(actual, te.table.State.PlayerStates[playerIdx].Positions)
+		}
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestTableEngine_UpdatePlayerPositions_6Max(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 6, []int{0, 1, 2, 3, 4, 5})
+	assertPositionsFromDealer(t, te, [][]string{
+		{Position_Dealer},
+		{Position_SB},
+		{Position_BB},
+		{Position_UG},
+		{Position_HJ},
+		{Position_CO},
+	})
+}
+
+func TestTableEngine_UpdatePlayerPositions_9Max(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 9, []int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+	assertPositionsFromDealer(t, te, [][]string{
+		{Position_Dealer},
+		{Position_SB},
+		{Position_BB},
+		{Position_UG},
+		{Position_UG2},
+		{Position_MP},
+		{Position_MP2},
+		{Position_HJ},
+		{Position_CO},
+	})
+}
+
+func TestTableEngine_UpdatePlayerPositions_9MaxShortHanded(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 9, []int{0, 2, 3, 5, 7})
+	assertPositionsFromDealer(t, te, [][]string{
+		{Position_Dealer},
+		{Position_SB},
+		{Position_BB},
+		{Position_UG},
+		{Position_CO},
+	})
+}
+
+func TestTableEngine_UpdatePlayerPositions_HeadsUp(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 9, []int{1, 6})
+	assertPositionsFromDealer(t, te, [][]string{
+		{Position_Dealer, Position_SB},
+		{Position_BB},
+	})
+}
+
+func TestTableEngine_UpdatePlayerPositions_ResetsSittingOutPlayer(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 6, []int{0, 1, 2, 3, 4, 5})
+
+	// the player after the bb sits out with positions of the previous hand
+	sittingOutSeatID := (te.sm.CurrentBBSeatID() + 1) % 6
+	sittingOutPlayer := te.table.State.PlayerStates[te.table.State.SeatMap[sittingOutSeatID]]
+	sittingOutPlayer.Positions = []string{Position_CO}
+	assert.NoError(t, te.sm.UpdatePlayerWaitingBB(sittingOutPlayer.PlayerID, true))
+
+	assertPositionsFromDealer(t, te, [][]string{
+		{Position_Dealer},
+		{Position_SB},
+		{Position_BB},
+		{},
+		{Position_UG},
+		{Position_CO},
+	})
+}
+
+func TestTableEngine_GetPlayerHoleCards(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	// P5 waits for the next game
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}))
+
+	_, err := te.GetPlayerHoleCards("P1")
+	assert.ErrorIs(t, err, ErrTablePlayerNotParticipated)
+
+	// P1 & P2 are participating, cards are not dealt yet
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.GameState = &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{{Idx: 0}, {Idx: 1}},
+	}
+	_, err = te.GetPlayerHoleCards("P1")
+	assert.ErrorIs(t, err, ErrTableHoleCardsNotDealt)
+
+	// cards dealt & P2 folded
+	te.table.State.GameState.Players[0].HoleCards = []string{"SA", "HK"}
+	te.table.State.GameState.Players[1].HoleCards = []string{"D2", "C7"}
+	te.table.State.GameState.Players[1].Fold = true
+
+	holeCards, err := te.GetPlayerHoleCards("P1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SA", "HK"}, holeCards)
+
+	holeCards, err = te.GetPlayerHoleCards("P2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"D2", "C7"}, holeCards)
+
+	// returns a copy
+	holeCards[0] = "SA"
+	assert.Equal(t, []string{"D2", "C7"}, te.table.State.GameState.Players[1].HoleCards)
+
+	_, err = te.GetPlayerHoleCards("P5")
+	assert.ErrorIs(t, err, ErrTablePlayerNotParticipated)
+
+	_, err = te.GetPlayerHoleCards("P6")
+	assert.ErrorIs(t, err, ErrTablePlayerNotFound)
+}
+
+func TestTableEngine_DetectRoundChanged_AllinRunOut(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	rounds := make([]string, 0)
+	boards := make([][]string, 0)
+	te.OnRoundChanged(func(round string, board []string) {
+		rounds = append(rounds, round)
+		boards = append(boards, board)
+	})
+
+	gs := &pokerlib.GameState{GameID: "game"}
+	gs.Status.Round = GameRound_Preflop
+	te.detectRoundChanged(gs)
+	assert.Empty(t, rounds)
+
+	// run out from preflop to river at once
+	gs.Status.Round = GameRound_River
+	gs.Status.Board = []string{"SA", "SK", "SQ", "SJ", "ST"}
+	te.detectRoundChanged(gs)
+	te.detectRoundChanged(gs)
+
+	assert.Equal(t, []string{GameRound_Flop, GameRound_Turn, GameRound_River}, rounds)
+	assert.Equal(t, [][]string{{"SA", "SK", "SQ"}, {"SJ"}, {"ST"}}, boards)
+
+	// next game
+	next := &pokerlib.GameState{GameID: "next"}
+	next.Status.Round = GameRound_Flop
+	next.Status.Board = []string{"H2", "H3", "H4"}
+	te.detectRoundChanged(next)
+	assert.Equal(t, GameRound_Flop, rounds[len(rounds)-1])
+	assert.Equal(t, []string{"H2", "H3", "H4"}, boards[len(boards)-1])
+}
+
+func TestTableEngine_RotationDirection(t *testing.T) {
+	newTableEngine := func(direction string) *tableEngine {
+		te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+		tableSetting := newTestTableSetting()
+		tableSetting.Meta.RotationDirection = direction
+		_, err := te.CreateTable(tableSetting)
+		assert.NoError(t, err)
+
+		for playerID, seat := range map[string]int{"P1": 0, "P2": 2, "P3": 4, "P4": 6} {
+			assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+			assert.NoError(t, te.PlayerJoin(playerID))
+		}
+
+		assert.NoError(t, te.sm.InitPositions(false))
+		assert.Equal(t, 0, te.sm.CurrentBBSeatID())
+		te.updateNextBBOrderPlayerIDs()
+		return te
+	}
+
+	// clockwise by default
+	te := newTableEngine("")
+	assert.Equal(t, RotationDirection_Clockwise, te.table.Meta.RotationDirection)
+	assert.Equal(t, []string{"P2", "P3", "P4", "P1"}, te.GetNextBBOrder())
+
+	// bb order reverses under counter-clockwise
+	te = newTableEngine(RotationDirection_CounterClockwise)
+	assert.Equal(t, []string{"P4", "P3", "P2", "P1"}, te.GetNextBBOrder())
+
+	te.updatePlayerPositions(te.table.Meta.TableMaxSeatCount, te.table.State.PlayerStates)
+	positions := make(map[string][]string)
+	for _, player := range te.table.State.PlayerStates {
+		positions[player.PlayerID] = player.Positions
+	}
+	assert.Equal(t, []string{Position_BB}, positions["P1"])
+	assert.Equal(t, []string{Position_SB}, positions["P2"])
+	assert.Equal(t, []string{Position_Dealer}, positions["P3"])
+}
+
+func TestTableEngine_PeekNextPositions(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	dealer, sb, bb, err := te.PeekNextPositions()
+	assert.NoError(t, err)
+	assert.Equal(t, "P4", dealer)
+	assert.Equal(t, "P1", sb)
+	assert.Equal(t, "P2", bb)
+
+	// the real button does not move
+	assert.Equal(t, 4, te.sm.CurrentDealerSeatID())
+	assert.Equal(t, 6, te.sm.CurrentSBSeatID())
+	assert.Equal(t, 0, te.sm.CurrentBBSeatID())
+}
+
+func TestTableEngine_PeekNextPositions_SkipsBustingPlayer(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	// P2 is about to bust
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll = 0
+
+	_, _, bb, err := te.PeekNextPositions()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "P2", bb)
+
+	// prediction matches the real rotation
+	dealer, sb, bb, err := te.PeekNextPositions()
+	assert.NoError(t, err)
+	assert.NoError(t, te.sm.UpdatePlayerHasChips("P2", false))
+	assert.NoError(t, te.sm.RotatePositions())
+	assert.Equal(t, dealer, te.sm.Seats()[te.sm.CurrentDealerSeatID()].ID)
+	assert.Equal(t, sb, te.sm.Seats()[te.sm.CurrentSBSeatID()].ID)
+	assert.Equal(t, bb, te.sm.Seats()[te.sm.CurrentBBSeatID()].ID)
+}
+
+func TestTableEngine_PeekNextPositions_BeforeInitPositions(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	_, _, _, err = te.PeekNextPositions()
+	assert.ErrorIs(t, err, ErrTablePositionsNotInitialized)
+}
+
+func TestTableEngine_GetPlayerButtonDistance(t *testing.T) {
+	tableSetting := newTestTableSetting()
+	tableSetting.Meta.TableMaxSeatCount = 6
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(tableSetting)
+	assert.NoError(t, err)
+
+	for playerID, seat := range map[string]int{"P1": 0, "P2": 1, "P3": 3, "P4": 5} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+	}
+
+	_, err = te.GetPlayerButtonDistance("P1")
+	assert.ErrorIs(t, err, ErrTablePositionsNotInitialized)
+	_, err = te.GetPlayerButtonDistance("P5")
+	assert.ErrorIs(t, err, ErrTablePlayerNotFound)
+
+	testCases := []struct {
+		name       string
+		dealerSeat int
+		direction  string
+		expected   map[string]int
+	}{
+		{name: "clockwise", dealerSeat: 3, direction: RotationDirection_Clockwise, expected: map[string]int{"P3": 0, "P4": 1, "P1": 2, "P2": 3}},
+		{name: "counter clockwise", dealerSeat: 3, direction: RotationDirection_CounterClockwise, expected: map[string]int{"P3": 0, "P2": 1, "P1": 2, "P4": 3}},
+		{name: "dead button", dealerSeat: 2, direction: RotationDirection_Clockwise, expected: map[string]int{"P3": 1, "P4": 2, "P1": 3, "P2": 4}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			te.table.State.CurrentDealerSeat = tc.dealerSeat
+			te.table.Meta.RotationDirection = tc.direction
+
+			for playerID, expected := range tc.expected {
+				distance, err := te.GetPlayerButtonDistance(playerID)
+				assert.NoError(t, err)
+				assert.Equal(t, expected, distance, playerID)
+			}
+		})
+	}
+}
+
+func TestTableEngine_GetStandings(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	for playerID, bankroll := range map[string]int64{"P1": 500, "P2": 1500, "P3": 500, "P4": 1200} {
+		te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)].Bankroll = bankroll
+	}
+
+	standings := te.GetStandings()
+	assert.Len(t, standings, 4)
+
+	expected := []struct {
+		rank     int
+		playerID string
+	}{
+		{1, "P2"},
+		{2, "P4"},
+		{3, "P1"}, // tie, ordered by seat
+		{3, "P3"},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.rank, standings[i].Rank)
+		assert.Equal(t, e.playerID, standings[i].PlayerID)
+		assert.Equal(t, int64(925), standings[i].ChipAverage)
+	}
+}
+
+func TestTableEngine_GetStandings_Empty(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	assert.Empty(t, te.GetStandings())
+}
+
+func TestTableEngine_UpdateBlind_DeferredUntilHandSettles(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	te.table.State.GameBlindState = &TableBlindState{Level: 1, SB: 10, BB: 20}
+
+	// level changes mid-hand
+	te.UpdateBlind(2, 0, 0, 20, 40)
+	assert.Equal(t, 1, te.table.State.BlindState.Level)
+	assert.Equal(t, int64(20), te.table.State.BlindState.BB)
+	assert.Equal(t, *te.table.State.GameBlindState, TableBlindState{Level: 1, SB: 10, BB: 20})
+	level, ok := te.GetPendingBlindLevel()
+	assert.True(t, ok)
+	assert.Equal(t, 2, level)
+
+	// the latest level wins
+	te.blindLevels = []TableBlindState{{Level: 3, SB: 30, BB: 60}}
+	assert.True(t, te.nextBlindLevel())
+	assert.Equal(t, 1, te.table.State.BlindState.Level)
+	level, ok = te.GetPendingBlindLevel()
+	assert.True(t, ok)
+	assert.Equal(t, 3, level)
+
+	// hand settled
+	te.table.State.Status = TableStateStatus_TableGameStandby
+	te.applyPendingBlind()
+	assert.Equal(t, 3, te.table.State.BlindState.Level)
+	assert.Equal(t, int64(60), te.table.State.BlindState.BB)
+	_, ok = te.GetPendingBlindLevel()
+	assert.False(t, ok)
+}
+
+func TestTableEngine_UpdateBlind_BetweenHands(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+
+	te.UpdateBlind(2, 0, 0, 20, 40)
+	assert.Equal(t, 2, te.table.State.BlindState.Level)
+	assert.Equal(t, int64(40), te.table.State.BlindState.BB)
+	_, ok := te.GetPendingBlindLevel()
+	assert.False(t, ok)
+}
+
+func TestTableEngine_CreatePlayerGameAction_Sequence(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	first := te.createPlayerGameAction("P1", 0, WagerAction_Call, 0, nil)
+	second := te.createPlayerGameAction("P2", 1, WagerAction_Fold, 0, nil)
+	assert.Greater(t, second.Sequence, first.Sequence)
+
+	// concurrency-safe & unique
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sequences := make(map[int64]bool)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pga := te.createPlayerGameAction("P1", 0, WagerAction_Check, 0, nil)
+			mu.Lock()
+			sequences[pga.Sequence] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, sequences, 100)
+
+	// never resets between hands
+	te.table.State.GameCount++
+	assert.Equal(t, second.Sequence+101, te.createPlayerGameAction("P1", 0, WagerAction_Check, 0, nil).Sequence)
+}
+
+func TestTableEngine_CreatePlayerGameAction_Serialization(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	actions := map[PlayerActionType]string{
+		Action_Ready:      "ready",
+		Action_Pay:        "pay",
+		Action_Pass:       "pass",
+		WagerAction_Fold:  "fold",
+		WagerAction_Check: "check",
+		WagerAction_Call:  "call",
+		WagerAction_AllIn: "allin",
+		WagerAction_Bet:   "bet",
+		WagerAction_Raise: "raise",
+	}
+	for action, expected := range actions {
+		data, err := json.Marshal(te.createPlayerGameAction("P1", 0, action, 0, nil))
+		assert.NoError(t, err)
+
+		var payload map[string]interface{}
+		assert.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, expected, payload["action"])
+	}
+}
+
+func TestTableEngine_ApplyDeal(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameSettled
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P4")].Bankroll = 0
+
+	var applied map[string]int64
+	te.OnDealApplied(func(payouts map[string]int64) {
+		applied = payouts
+	})
+
+	payouts := map[string]int64{"P1": 1200, "P2": 1000, "P3": 800}
+	assert.NoError(t, te.ApplyDeal(payouts))
+	assert.Equal(t, payouts, applied)
+	assert.Equal(t, payouts, te.table.State.DealPayouts)
+	assert.Equal(t, TableStateStatus(TableStateStatus_TableClosed), te.table.State.Status)
+}
+
+func TestTableEngine_ApplyDeal_Rejected(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+
+	// sum mismatch
+	err := te.ApplyDeal(map[string]int64{"P1": 1000, "P2": 1000, "P3": 1000, "P4": 999})
+	assert.ErrorIs(t, err, ErrTableDealPayoutMismatch)
+
+	// missing player
+	err = te.ApplyDeal(map[string]int64{"P1": 2000, "P2": 1000, "P3": 1000})
+	assert.ErrorIs(t, err, ErrTableDealInvalidPlayers)
+
+	// unknown player
+	err = te.ApplyDeal(map[string]int64{"P1": 1000, "P2": 1000, "P3": 1000, "P5": 1000})
+	assert.ErrorIs(t, err, ErrTableDealInvalidPlayers)
+
+	// mid-hand
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	err = te.ApplyDeal(map[string]int64{"P1": 1000, "P2": 1000, "P3": 1000, "P4": 1000})
+	assert.ErrorIs(t, err, ErrTableDealInvalidState)
+
+	assert.Nil(t, te.table.State.DealPayouts)
+	assert.Equal(t, TableStateStatus(TableStateStatus_TableGamePlaying), te.table.State.Status)
+}
+
+// balanceSidesSeatAssigner seats each newcomer at the first empty seat on the emptier half of the table
+func balanceSidesSeatAssigner(table *Table, playerIDs []string) (map[string]int, error) {
+	maxSeat := table.Meta.TableMaxSeatCount
+	occupied := make(map[int]bool)
+	for _, player := range table.State.PlayerStates {
+		occupied[player.Seat] = true
+	}
+
+	sideCount := func(from, to int) (int, int) {
+		count, emptySeat := 0, UnsetValue
+		for seat := from; seat < to; seat++ {
+			if occupied[seat] {
+				count++
+			} else if emptySeat == UnsetValue {
+				emptySeat = seat
+			}
+		}
+		return count, emptySeat
+	}
+
+	playerSeatIDs := make(map[string]int)
+	for _, playerID := range playerIDs {
+		leftCount, leftSeat := sideCount(0, maxSeat/2)
+		rightCount, rightSeat := sideCount(maxSeat/2, maxSeat)
+		seat := leftSeat
+		if seat == UnsetValue || (rightSeat != UnsetValue && rightCount < leftCount) {
+			seat = rightSeat
+		}
+		if seat == UnsetValue {
+			return nil, ErrTableNoEmptySeats
+		}
+
+		playerSeatIDs[playerID] = seat
+		occupied[seat] = true
+	}
+	return playerSeatIDs, nil
+}
+
+func TestTableEngine_WithSeatAssigner_BalanceSides(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend()), WithSeatAssigner(balanceSidesSeatAssigner)).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	// left side: seats 0 ~ 3, right side: seats 4 ~ 8
+	for playerID, seat := range map[string]int{"P1": 0, "P2": 1, "P3": 2} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+	}
+
+	for _, playerID := range []string{"P4", "P5", "P6"} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: UnsetValue}))
+	}
+	assert.Equal(t, 4, te.table.State.PlayerStates[te.table.FindPlayerIdx("P4")].Seat)
+	assert.Equal(t, 5, te.table.State.PlayerStates[te.table.FindPlayerIdx("P5")].Seat)
+	assert.Equal(t, 6, te.table.State.PlayerStates[te.table.FindPlayerIdx("P6")].Seat)
+
+	// sides are even, back to the left side
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P7", RedeemChips: 1000, Seat: UnsetValue}))
+	assert.Equal(t, 3, te.table.State.PlayerStates[te.table.FindPlayerIdx("P7")].Seat)
+}
+
+func TestTableEngine_WithSeatAssigner_Validation(t *testing.T) {
+	var assigner func(table *Table, playerIDs []string) (map[string]int, error)
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend()), WithSeatAssigner(func(table *Table, playerIDs []string) (map[string]int, error) {
+		return assigner(table, playerIDs)
+	})).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P1", RedeemChips: 1000, Seat: 0}))
+
+	// occupied seat
+	assigner = func(table *Table, playerIDs []string) (map[string]int, error) {
+		return map[string]int{"P2": 0}, nil
+	}
+	assert.ErrorIs(t, te.PlayerReserve(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTableSeatOccupied)
+
+	// out of range
+	assigner = func(table *Table, playerIDs []string) (map[string]int, error) {
+		return map[string]int{"P2": 9}, nil
+	}
+	assert.ErrorIs(t, te.PlayerReserve(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTableSeatOutOfRange)
+
+	// wrong player
+	assigner = func(table *Table, playerIDs []string) (map[string]int, error) {
+		return map[string]int{"P3": 1}, nil
+	}
+	assert.ErrorIs(t, te.PlayerReserve(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTableInvalidSeatAssignment)
+
+	// the same seat for two players
+	_, err = te.UpdateTablePlayers([]JoinPlayer{
+		{PlayerID: "P2", RedeemChips: 1000, Seat: 3},
+		{PlayerID: "P3", RedeemChips: 1000, Seat: 3},
+	}, nil)
+	assert.ErrorIs(t, err, ErrTableSeatOccupied)
+	assert.ErrorContains(t, err, "seat 3")
+
+	assert.Equal(t, UnsetValue, te.table.FindPlayerIdx("P2"))
+}
+
+func TestTableEngine_PauseTable_BetweenHands(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+
+	deferred, err := te.PauseTable()
+	assert.NoError(t, err)
+	assert.False(t, deferred)
+	assert.False(t, te.pauseRequested)
+	assert.Equal(t, TableStateStatus(TableStateStatus_TablePausing), te.table.State.Status)
+}
+
+func TestTableEngine_PauseTable_DroppedWithoutNextHand(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+
+	deferred, err := te.PauseTable()
+	assert.NoError(t, err)
+	assert.True(t, deferred)
+
+	// the hard stop is due, no hand opens after the settled one & the deferred pause is dropped
+	te.hardStopAt.Store(time.Now().Unix())
+	te.lock.Lock()
+	assert.NoError(t, te.continueGame(te.table.AlivePlayers()))
+	te.lock.Unlock()
+	assert.Eventually(t, func() bool {
+		te.lock.Lock()
+		defer te.lock.Unlock()
+		return !te.pauseRequested
+	}, 3*time.Second, 10*time.Millisecond)
+
+	te.lock.Lock()
+	defer te.lock.Unlock()
+	assert.Equal(t, TableStateStatus(TableStateStatus_TableGameStandby), te.table.State.Status)
+}
+
+func TestTableEngine_GetOpenSeats(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, te.GetOpenSeats())
+
+	for playerID, seat := range map[string]int{"P1": 1, "P2": 4, "P3": 5, "P4": 8} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+	}
+	assert.NoError(t, te.PlayersLeave([]string{"P2"}))
+
+	openSeats := te.GetOpenSeats()
+	assert.Equal(t, []int{0, 2, 3, 4, 6, 7}, openSeats)
+
+	// agrees with the seat manager
+	smOpenSeats := make([]int, 0)
+	for seat := 0; seat < te.table.Meta.TableMaxSeatCount; seat++ {
+		if te.sm.Seats()[seat] == nil {
+			smOpenSeats = append(smOpenSeats, seat)
+		}
+	}
+	assert.Equal(t, smOpenSeats, openSeats)
+
+	// returns a copy
+	openSeats[0] = 1
+	assert.Equal(t, 0, te.GetOpenSeats()[0])
+}
+
+func TestTableSetting_Validate(t *testing.T) {
+	tableSetting := newTestTableSetting()
+	assert.NoError(t, tableSetting.Validate())
+
+	// 9-max short deck
+	tableSetting.Meta.Rule = CompetitionRule_ShortDeck
+	assert.ErrorIs(t, tableSetting.Validate(), ErrTableInvalidCreateSetting)
+
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend()))
+	_, err := te.CreateTable(tableSetting)
+	assert.ErrorIs(t, err, ErrTableInvalidCreateSetting)
+
+	tableSetting.Meta.TableMaxSeatCount = 6
+	assert.NoError(t, tableSetting.Validate())
+
+	// operators adjust the limit
+	defer func(maxSeats int) { RuleMaxSeats[CompetitionRule_ShortDeck] = maxSeats }(RuleMaxSeats[CompetitionRule_ShortDeck])
+	RuleMaxSeats[CompetitionRule_ShortDeck] = 5
+	assert.ErrorIs(t, tableSetting.Validate(), ErrTableInvalidCreateSetting)
+
+	// more join players than seats
+	tableSetting = newTestTableSetting()
+	tableSetting.Meta.TableMaxSeatCount = 1
+	tableSetting.JoinPlayers = []JoinPlayer{{PlayerID: "P1", RedeemChips: 1000, Seat: 0}, {PlayerID: "P2", RedeemChips: 1000, Seat: 1}}
+	assert.ErrorIs(t, tableSetting.Validate(), ErrTableInvalidCreateSetting)
+}
+
+func TestTableSetting_Validate_RedeemChips(t *testing.T) {
+	tableSetting := newTestTableSetting()
+	tableSetting.JoinPlayers = []JoinPlayer{
+		{PlayerID: "P1", RedeemChips: 1000, Seat: 0},
+		{PlayerID: "P2", RedeemChips: 0, Seat: 1},
+	}
+
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend()))
+	_, err := te.CreateTable(tableSetting)
+	assert.ErrorIs(t, err, ErrTableInvalidRedeemChips)
+	var redeemChipsErr *InvalidRedeemChipsError
+	if assert.ErrorAs(t, err, &redeemChipsErr) {
+		assert.Equal(t, "P2", redeemChipsErr.PlayerID)
+		assert.Equal(t, int64(0), redeemChipsErr.RedeemChips)
+	}
+
+	// players reserving a seat later
+	tableSetting.JoinPlayers = tableSetting.JoinPlayers[:1]
+	_, err = te.CreateTable(tableSetting)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, te.PlayerReserve(JoinPlayer{PlayerID: "P3", RedeemChips: -100, Seat: 2}), ErrTableInvalidRedeemChips)
+	assert.Equal(t, UnsetValue, te.GetTable().FindPlayerIdx("P3"))
+	assert.ErrorIs(t, te.PlayerReserve(JoinPlayer{PlayerID: "P3", RedeemChips: 0, Seat: 2}), ErrTableInvalidRedeemChips)
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P3", RedeemChips: 1000, Seat: 2}))
+
+	// rebuys don't change the bankroll or the buy-ins
+	for _, redeemChips := range []int64{0, -100} {
+		assert.ErrorIs(t, te.PlayerReserve(JoinPlayer{PlayerID: "P3", RedeemChips: redeemChips, Seat: 2}), ErrTableInvalidRedeemChips)
+		assert.ErrorIs(t, te.PlayerRedeemChips(JoinPlayer{PlayerID: "P3", RedeemChips: redeemChips}), ErrTableInvalidRedeemChips)
+	}
+	stats, err := te.GetPlayerSessionStats("P3")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), stats.Bankroll)
+	assert.Equal(t, int64(1000), stats.BuyInTotal)
+}
+
+func TestTableEngine_OpenGameTimeout(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.OpenGameTimeout = 3
+	te := NewTableEngine(options, WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, te.ogm.GetState().Timeout)
+
+	// a single participant never opens a game
+	te.SetUpTableGame(1, map[string]int{"P1": 0})
+
+	// still waiting for settlement after the default timeout
+	time.Sleep(time.Duration(NewTableEngineOptions().OpenGameTimeout)*time.Second + 200*time.Millisecond)
+	assert.False(t, te.ogm.GetState().Participants["P1"].IsReady)
+	assert.NoError(t, te.ogm.Ready("P1"))
+}
+
+func TestTableEngine_OpenGameTimeout_Default(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.OpenGameTimeout = 0
+	te := NewTableEngine(options, WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+	assert.Equal(t, NewTableEngineOptions().OpenGameTimeout, te.ogm.GetState().Timeout)
+}
+
+func TestTableEngine_OnInsufficientParticipants(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	counts := make(chan int, 1)
+	te.OnInsufficientParticipants(func(count int) {
+		counts <- count
+	})
+
+	te.SetUpTableGame(1, map[string]int{"P1": 0})
+	assert.NoError(t, te.ogm.Ready("P1"))
+
+	select {
+	case count := <-counts:
+		assert.Equal(t, 1, count)
+	case <-time.After(time.Second):
+		t.Fatal("insufficient participants event is not emitted")
+	}
+	assert.Nil(t, te.table.State.GameState)
+}
+
+func TestTableEngine_SetUpTableGame_InvalidParticipants(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+
+	assert.NoError(t, te.SetUpTableGame(1, map[string]int{"P1": 1, "P2": 0}))
+	assert.Equal(t, 1, te.ogm.GetState().GameCount)
+
+	// duplicate index
+	assert.ErrorIs(t, te.SetUpTableGame(2, map[string]int{"P1": 0, "P2": 0}), ErrTableInvalidParticipants)
+	// index out of range
+	assert.ErrorIs(t, te.SetUpTableGame(2, map[string]int{"P1": 0, "P2": 2}), ErrTableInvalidParticipants)
+	assert.ErrorIs(t, te.SetUpTableGame(2, map[string]int{"P1": -1, "P2": 0}), ErrTableInvalidParticipants)
+
+	// the open game is left untouched
+	assert.Equal(t, 1, te.ogm.GetState().GameCount)
+	assert.Len(t, te.ogm.GetState().Participants, 2)
+}
+
+func TestTableEngine_GetReadyState(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	type progress struct{ ready, total int }
+	progresses := make(chan progress, 16)
+	te.OnReadyProgress(func(ready, total int) {
+		progresses <- progress{ready, total}
+	})
+	waitProgress := func(expected progress) {
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case p := <-progresses:
+				if p == expected {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("ready progress %d/%d is not emitted", expected.ready, expected.total)
+			}
+		}
+	}
+
+	// P5 is seated but hasn't joined yet
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}))
+	waitProgress(progress{0, 1})
+	assert.Equal(t, map[string]bool{"P5": false}, te.GetReadyState())
+
+	assert.NoError(t, te.PlayerJoin("P5"))
+	waitProgress(progress{1, 1})
+	assert.Empty(t, te.GetReadyState())
+
+	// players finishing the settlement
+	assert.NoError(t, te.SetUpTableGame(2, map[string]int{"P1": 0, "P2": 1, "P3": 2, "P4": 3}))
+	waitProgress(progress{0, 4})
+	assert.NoError(t, te.PlayerSettlementFinish("P1"))
+	waitProgress(progress{1, 4})
+	assert.Equal(t, map[string]bool{"P1": true, "P2": false, "P3": false, "P4": false}, te.GetReadyState())
+}
+
+func TestTableEngine_OnSettlementTimeout(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.OpenGameTimeout = 1
+	te := newTestPlayingTableEngine(t, options)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	pendings := make(chan []string, 1)
+	te.OnSettlementTimeout(func(pendingPlayerIDs []string) {
+		pendings <- pendingPlayerIDs
+	})
+
+	// P4 never finishes the settlement
+	assert.NoError(t, te.SetUpTableGame(1, map[string]int{"P1": 0, "P2": 1, "P3": 2, "P4": 3}))
+	for _, playerID := range []string{"P1", "P2", "P3"} {
+		assert.NoError(t, te.PlayerSettlementFinish(playerID))
+	}
+
+	select {
+	case pendingPlayerIDs := <-pendings:
+		assert.Equal(t, []string{"P4"}, pendingPlayerIDs)
+	case <-time.After(2 * time.Second):
+		t.Fatal("settlement timeout event is not emitted")
+	}
+
+	// the hand opens anyway
+	timeout := time.After(time.Second)
+	for {
+		te.lock.Lock()
+		gs, gamePlayerIndexes := te.table.State.GameState, te.table.State.GamePlayerIndexes
+		te.lock.Unlock()
+		if gs != nil {
+			assert.Len(t, gamePlayerIndexes, 4)
+			break
+		}
+
+		select {
+		case <-timeout:
+			t.Fatal("hand is not opened")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestTableEngine_MinOpenGameParticipantCount(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	tableSetting := newTestTableSetting()
+	tableSetting.Meta.TableMinPlayerCount = 3
+	_, err := te.CreateTable(tableSetting)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, te.minOpenGameParticipantCount())
+
+	// never opens a game alone
+	te.table.Meta.TableMinPlayerCount = 1
+	assert.Equal(t, 2, te.minOpenGameParticipantCount())
+}
+
+func TestTableEngine_AutoReadyJoinPlayers(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	autoReadyPlayers := make(map[string]string)
+	te.OnPlayerAutoReady(func(playerID string, phase string) {
+		autoReadyPlayers[playerID] = phase
+	})
+
+	rg := syncsaga.NewReadyGroup()
+	for playerIdx, player := range te.table.State.PlayerStates {
+		rg.Add(int64(playerIdx), player.PlayerID == "P1" || player.PlayerID == "P3")
+	}
+	te.autoReadyJoinPlayers(rg)
+
+	assert.Equal(t, map[string]string{"P2": AutoReadyPhase_Join, "P4": AutoReadyPhase_Join}, autoReadyPlayers)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.GameState = &pokerlib.GameState{
+		GameID:  "G1",
+		Players: []*pokerlib.PlayerState{{Idx: 0, HoleCards: []string{"SA", "HK"}}, {Idx: 1, HoleCards: []string{"D2", "C7"}}},
+	}
+	te.table.State.DealPayouts = map[string]int64{"P4": 400, "P1": 100, "P3": 300, "P2": 200}
+
+	expected, err := CanonicalJSON(te.table)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(expected), " ")
+	assert.NotContains(t, string(expected), "\n")
+
+	// byte-identical across repeated marshals & deep copies of the same state
+	for i := 0; i < 20; i++ {
+		data, err := CanonicalJSON(te.table)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+
+		cloned, err := te.table.Clone()
+		assert.NoError(t, err)
+		data, err = CanonicalJSON(cloned)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	}
+
+	// keys are sorted, not in struct field order
+	data := string(expected)
+	assert.Less(t, strings.Index(data, `"action_time":`), strings.Index(data, `"competition_id":`))
+	assert.Less(t, strings.Index(data, `"bankroll":`), strings.Index(data, `"player_id":`))
+	assert.Contains(t, data, `"deal_payouts":{"P1":100,"P2":200,"P3":300,"P4":400}`)
+
+	// different state, different bytes
+	te.table.State.GameState.Players[1].HoleCards = []string{"D2", "C8"}
+	data2, err := CanonicalJSON(te.table)
+	assert.NoError(t, err)
+	assert.NotEqual(t, expected, data2)
+}
+
+func TestTableEngine_StartGame_DealerBlind(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.BlindState.Dealer = 20
+
+	// P3 (seat 4) is on the button
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P3"), te.table.FindPlayerIdx("P4"), te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P3")].Positions = []string{Position_Dealer}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P4")].Positions = []string{Position_SB}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Positions = []string{Position_BB}
+	assert.NoError(t, te.startGame())
+	assert.Equal(t, int64(20), te.game.GetGameState().Meta.Blind.Dealer)
+	assert.Equal(t, int64(20), te.table.State.GameBlindState.Dealer)
+}
+
+func TestTableEngine_StartGame_DealerBlind_DeadButton(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.BlindState.Dealer = 20
+
+	// the button left, P3 only marks where the game starts
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P3"), te.table.FindPlayerIdx("P4"), te.table.FindPlayerIdx("P1")}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P3")].Positions = []string{}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P4")].Positions = []string{Position_SB}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Positions = []string{Position_BB}
+	assert.NoError(t, te.startGame())
+	assert.Equal(t, int64(0), te.game.GetGameState().Meta.Blind.Dealer)
+	assert.Equal(t, int64(0), te.table.State.GameBlindState.Dealer)
+}
+
+func TestTableEngine_SettleGame_NoWinner(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	// P1 & P2 folded, the remaining game player isn't mapped to any table player
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.GameState = &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{{Idx: 0, Fold: true}, {Idx: 1, Fold: true}, {Idx: 2, Combination: &pokerlib.CombinationInfo{Power: 100}}},
+		Result: &settlement.Result{
+			Players: []*settlement.PlayerResult{{Idx: 0, Final: 900, Changed: -100}, {Idx: 1, Final: 1100, Changed: 100}},
+		},
+	}
+
+	alivePlayers := te.settleGame()
+
+	tableErr := <-te.ErrorChannel()
+	assert.ErrorIs(t, tableErr, ErrSettlementNoWinner)
+	assert.Equal(t, "settleGame", tableErr.EventName)
+
+	// bankrolls before the hand are kept
+	assert.Len(t, alivePlayers, 2)
+	assert.Equal(t, int64(1000), te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll)
+	assert.Equal(t, int64(1000), te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll)
+}
+
+func TestTableEngine_SettleGame_Winner(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.GameState = &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{{Idx: 0, Fold: true}, {Idx: 1, Combination: &pokerlib.CombinationInfo{Power: 100}}},
+		Result: &settlement.Result{
+			Players: []*settlement.PlayerResult{{Idx: 0, Final: 900, Changed: -100}, {Idx: 1, Final: 1100, Changed: 100}},
+		},
+	}
+
+	te.settleGame()
+	assert.Len(t, te.ErrorChannel(), 0)
+	assert.Equal(t, int64(900), te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll)
+	assert.Equal(t, int64(1100), te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll)
+}
+
+func TestTableEngine_SettleGame_SettlementHook(t *testing.T) {
+	// bounty tournament: the winner of the hand collects the bounty of every eliminated player
+	bounties := map[string]int64{"P1": 100, "P2": 100}
+	errBounty := errors.New("bounty not found")
+	bountyHook := func(result *settlement.Result, table *Table) error {
+		winner := result.Players[0]
+		for _, player := range result.Players {
+			if player.Changed > winner.Changed {
+				winner = player
+			}
+		}
+		winnerState := table.State.PlayerStates[table.State.GamePlayerIndexes[winner.Idx]]
+
+		for _, player := range result.Players {
+			playerState := table.State.PlayerStates[table.State.GamePlayerIndexes[player.Idx]]
+			if playerState.Bankroll > 0 {
+				continue
+			}
+
+			bounty, exist := bounties[playerState.PlayerID]
+			if !exist {
+				return errBounty
+			}
+			winnerState.Bankroll += bounty
+			delete(bounties, playerState.PlayerID)
+		}
+		return nil
+	}
+
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithSettlementHook(bountyHook))
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.GameState = &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{{Idx: 0, Combination: &pokerlib.CombinationInfo{Power: 50}}, {Idx: 1, Combination: &pokerlib.CombinationInfo{Power: 100}}},
+		Result: &settlement.Result{
+			Players: []*settlement.PlayerResult{{Idx: 0, Final: 0, Changed: -1000}, {Idx: 1, Final: 2000, Changed: 1000}},
+		},
+	}
+
+	// P2 knocks P1 out
+	alivePlayers := te.settleGame()
+	assert.Len(t, te.ErrorChannel(), 0)
+	assert.Len(t, alivePlayers, 1)
+	p2 := te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")]
+	assert.Equal(t, int64(2100), p2.Bankroll)
+	assert.Equal(t, int64(1100), p2.SessionNet)
+	assert.Equal(t, int64(0), te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll)
+	assert.NotContains(t, bounties, "P1")
+
+	// the bounty is already collected
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll = 1000
+	te.settleGame()
+	tableErr := <-te.ErrorChannel()
+	assert.ErrorIs(t, tableErr, errBounty)
+	assert.Equal(t, "settleGame#settlementHook", tableErr.EventName)
+}
+
+func TestTableEngine_SettleGame_RoundPotSplits(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithChipConservationCheck())
+	assert.Equal(t, 10, te.table.Meta.MinChipUnit)
+
+	// P3 (button), P4 & P1 put 50 each in, P3 & P1 split the pot of 150
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P3"), te.table.FindPlayerIdx("P4"), te.table.FindPlayerIdx("P1")}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P3")].Positions = []string{Position_Dealer}
+	te.handChips = 3000
+
+	result := settlement.NewResult()
+	for gamePlayerIdx := range te.table.State.GamePlayerIndexes {
+		result.AddPlayer(gamePlayerIdx, 1000)
+	}
+	result.AddPot(150, []*pot.Level{{Level: 50, Wager: 50, Total: 150, Contributors: []int{0, 1, 2}}})
+	for gamePlayerIdx, score := range []int{100, 50, 100} {
+		result.UpdateScore(gamePlayerIdx, score)
+	}
+	result.Calculate()
+
+	te.table.State.GameState = &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{
+			{Idx: 0, Combination: &pokerlib.CombinationInfo{Power: 100}},
+			{Idx: 1, Combination: &pokerlib.CombinationInfo{Power: 50}},
+			{Idx: 2, Combination: &pokerlib.CombinationInfo{Power: 100}},
+		},
+		Result: result,
+	}
+
+	// 75 each is rounded down to 70, P1 is the first winner clockwise from the button and gets the 10 left over
+	te.settleGame()
+	assert.Len(t, te.ErrorChannel(), 0)
+	for playerID, bankroll := range map[string]int64{"P3": 1020, "P4": 950, "P1": 1030} {
+		assert.Equal(t, bankroll, te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)].Bankroll, playerID)
+	}
+
+	withdraws := make(map[int]int64)
+	total := int64(0)
+	for _, winner := range result.Pots[0].Winners {
+		withdraws[winner.Idx] = winner.Withdraw
+		total += winner.Withdraw
+	}
+	assert.Equal(t, map[int]int64{0: 70, 2: 80}, withdraws)
+	assert.Equal(t, result.Pots[0].Total, total)
+}
+
+func TestTableEngine_GetNextHandParticipants(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	assert.ElementsMatch(t, []string{"P1", "P2", "P3", "P4"}, te.GetNextHandParticipants())
+
+	// P2 busted & P3 sits out
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll = 0
+	assert.NoError(t, te.sm.SitOutPlayers([]string{"P3"}))
+
+	participants := te.GetNextHandParticipants()
+	assert.ElementsMatch(t, []string{"P1", "P4"}, participants)
+
+	// nothing is mutated
+	assert.Equal(t, 4, te.sm.CurrentDealerSeatID())
+	assert.True(t, te.sm.Seats()[2].HasChips)
+	for _, playerState := range te.table.State.PlayerStates {
+		assert.False(t, playerState.IsParticipated)
+	}
+
+	// agrees with the real rotation
+	assert.NoError(t, te.sm.UpdatePlayerHasChips("P2", false))
+	assert.NoError(t, te.sm.RotatePositions())
+	for _, playerState := range te.table.State.PlayerStates {
+		active, err := te.sm.IsPlayerActive(playerState.PlayerID)
+		assert.NoError(t, err)
+		assert.Equal(t, active, funk.Contains(participants, playerState.PlayerID), playerState.PlayerID)
+	}
+}
+
+func TestTableEngine_GetNextHandParticipants_BeforeInitPositions(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+	assert.Empty(t, te.GetNextHandParticipants())
+
+	for playerID, seat := range map[string]int{"P1": 0, "P2": 2, "P3": 4} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+		assert.NoError(t, te.PlayerJoin(playerID))
+	}
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P3")].Bankroll = 0
+	assert.ElementsMatch(t, []string{"P1", "P2"}, te.GetNextHandParticipants())
+	assert.False(t, te.sm.IsInitPositions())
+}
+
+func TestTableEngine_PlayerChangeSeat(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+	var changedSeatMap map[int]int
+	te.OnSeatMapChanged(func(tableID string, seatMap map[int]int) {
+		changedSeatMap = seatMap
+	})
+
+	// P2 moves from seat 2 to seat 3
+	assert.NoError(t, te.PlayerChangeSeat("P2", 3))
+	playerIdx := te.table.FindPlayerIdx("P2")
+	assert.Equal(t, 3, te.table.State.PlayerStates[playerIdx].Seat)
+	assert.Equal(t, playerIdx, te.table.State.SeatMap[3])
+	assert.Equal(t, UnsetValue, te.table.State.SeatMap[2])
+	assert.Equal(t, te.table.State.SeatMap, changedSeatMap)
+	assert.Nil(t, te.sm.Seats()[2])
+	assert.Equal(t, "P2", te.sm.Seats()[3].ID)
+	assert.Contains(t, te.GetOpenSeats(), 2)
+}
+
+func TestTableEngine_PlayerChangeSeat_SeatsLocked(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	// seats are locked as soon as the hand is opened
+	assert.ErrorIs(t, te.PlayerChangeSeat("P2", 3), ErrTableSeatsLocked)
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+	assert.ErrorIs(t, te.PlayerChangeSeat("P2", 3), ErrTableSeatsLocked)
+	assert.Equal(t, 2, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Seat)
+
+	// every other seat-mutating path is locked too
+	_, err := te.UpdateTablePlayers([]JoinPlayer{{PlayerID: "P5", RedeemChips: 1000, Seat: 3}}, nil)
+	assert.ErrorIs(t, err, ErrTableSeatsLocked)
+	_, err = te.UpdateTablePlayers(nil, []string{"P4"})
+	assert.ErrorIs(t, err, ErrTableSeatsLocked)
+	assert.ErrorIs(t, te.PlayersLeave([]string{"P4"}), ErrTableSeatsLocked)
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 3}), ErrTableSeatsLocked)
+	assert.Len(t, te.table.State.PlayerStates, 4)
+
+	// everybody folds to the BB
+	driveTestHand(t, te, foldToBBTestWager(te), nil)
+
+	// released between hands
+	assert.NoError(t, te.PlayerChangeSeat("P2", 3))
+	assert.Equal(t, 3, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Seat)
+}
+
+func TestTableEngine_AutoAct_DisconnectedIsNotTimeout(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.MaxConsecutiveTimeouts = 1
+	te := newTestPlayingTableEngine(t, options)
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// up to the first wager
+	actorPlayerID := func() string {
+		var playerID string
+		driveTestHand(t, te, nil, func(status TableStateStatus, gs *pokerlib.GameState) bool {
+			playerID, _ = te.GetCurrentActorPlayerID()
+			return playerID != "" && gs != nil && gs.HasAction(gs.Status.CurrentPlayer, string(WagerAction_Fold))
+		})
+		return playerID
+	}
+
+	// the disconnected player folds right away, it is not a timeout
+	disconnectedPlayerID := actorPlayerID()
+	assert.NoError(t, te.PlayerDisconnect(disconnectedPlayerID))
+	assert.Eventually(t, func() bool {
+		playerID, _ := te.GetCurrentActorPlayerID()
+		return playerID != disconnectedPlayerID
+	}, time.Second, 10*time.Millisecond)
+
+	te.lock.Lock()
+	disconnected := te.table.State.PlayerStates[te.table.FindPlayerIdx(disconnectedPlayerID)]
+	assert.Equal(t, 0, disconnected.TimeoutCount)
+	assert.True(t, disconnected.IsIn)
+	te.lock.Unlock()
+
+	// the next player's action time is up
+	timedOutPlayerID := actorPlayerID()
+	assert.NoError(t, te.PlayerActionTimeout(timedOutPlayerID))
+
+	te.lock.Lock()
+	defer te.lock.Unlock()
+	assert.False(t, te.table.State.PlayerStates[te.table.FindPlayerIdx(timedOutPlayerID)].IsIn)
+}
+
+func TestTableEngine_PlayerChangeSeat_Rejected(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+
+	// P3 sits at seat 4
+	assert.ErrorIs(t, te.PlayerChangeSeat("P2", 4), ErrTableSeatOccupied)
+	assert.ErrorIs(t, te.PlayerChangeSeat("P2", 9), ErrTableSeatOutOfRange)
+	assert.ErrorIs(t, te.PlayerChangeSeat("P2", -1), ErrTableSeatOutOfRange)
+	assert.ErrorIs(t, te.PlayerChangeSeat("P5", 3), ErrTablePlayerNotFound)
+
+	// mid-hand
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	assert.ErrorIs(t, te.PlayerChangeSeat("P2", 3), ErrTableChangeSeatInvalidState)
+
+	// nothing moved
+	assert.Equal(t, 2, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Seat)
+	assert.Equal(t, "P2", te.sm.Seats()[2].ID)
+}
+
+func TestTableEngine_GetLastOpenGameFailure(t *testing.T) {
+	retryInterval := openGameRetryInterval
+	openGameRetryInterval = time.Millisecond
+	defer func() { openGameRetryInterval = retryInterval }()
+
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	reason, attempts := te.GetLastOpenGameFailure()
+	assert.Equal(t, "", reason)
+	assert.Equal(t, 0, attempts)
+
+	// blind is never set
+	te.table.State.BlindState.SB = 0
+	err := te.tableGameOpen()
+	assert.ErrorIs(t, err, ErrTableOpenGameFailed)
+	assert.Contains(t, err.Error(), "blind is not set")
+
+	reason, attempts = te.GetLastOpenGameFailure()
+	assert.Equal(t, "blind is not set", reason)
+	assert.Equal(t, openGameRetryCount+1, attempts)
+	assert.Nil(t, te.table.State.GameState)
+
+	// breaking level
+	te.table.State.BlindState.SB = 10
+	te.table.State.BlindState.Level = -1
+	assert.NoError(t, te.tableGameOpen())
+	reason, attempts = te.GetLastOpenGameFailure()
+	assert.Equal(t, ErrTableOpenGameFailedInBlindBreakingLevel.Error(), reason)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTableEngine_StartTableGame_AlreadyStarted(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	assert.NoError(t, te.StartTableGame())
+	startAt := te.table.State.StartAt
+	assert.NotEqual(t, int64(UnsetValue), startAt)
+
+	assert.ErrorIs(t, te.StartTableGame(), ErrTableAlreadyStarted)
+	assert.Equal(t, startAt, te.table.State.StartAt)
+}
+
+func TestTableEngine_StartTableGameIfNotStarted(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	readyCount := 0
+	te.OnReadyOpenFirstTableGame(func(competitionID, tableID string, gameCount int, playerStates []*TablePlayerState) {
+		readyCount++
+	})
+
+	assert.NoError(t, te.StartTableGameIfNotStarted())
+	assert.NoError(t, te.StartTableGameIfNotStarted())
+	assert.Equal(t, 1, readyCount)
+}
+
+func TestTableEngine_GetSeatHistory(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 8}))
+	assert.NoError(t, te.PlayerChangeSeat("P5", 7))
+	assert.NoError(t, te.PlayersLeave([]string{"P5"}))
+
+	history := funk.Filter(te.GetSeatHistory(), func(sc SeatChange) bool {
+		return sc.PlayerID == "P5"
+	}).([]SeatChange)
+	assert.Len(t, history, 2)
+
+	// moved away from seat 8
+	assert.Equal(t, 8, history[0].Seat)
+	assert.NotZero(t, history[0].JoinedAt)
+	assert.NotZero(t, history[0].LeftAt)
+
+	// left from seat 7
+	assert.Equal(t, 7, history[1].Seat)
+	assert.GreaterOrEqual(t, history[1].JoinedAt, history[0].LeftAt)
+	assert.NotZero(t, history[1].LeftAt)
+
+	// players still seated have open entries
+	for _, sc := range te.GetSeatHistory() {
+		if sc.PlayerID != "P5" {
+			assert.Zero(t, sc.LeftAt)
+		}
+	}
+}
+
+func TestTableEngine_GetSeatHistory_MaxSeatHistory(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.MaxSeatHistory = 5
+	te := newTestPlayingTableEngine(t, options)
+
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 8}))
+	assert.NoError(t, te.PlayerChangeSeat("P5", 7))
+	assert.NoError(t, te.PlayerChangeSeat("P5", 5))
+
+	// the closed entries are dropped first, seated players are kept
+	history := te.GetSeatHistory()
+	assert.Len(t, history, 5)
+	for _, sc := range history {
+		assert.Zero(t, sc.LeftAt)
+	}
+	assert.Equal(t, 5, history[4].Seat)
+}
+
+func TestTableEngine_AutoRebuyPlayers(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.Meta.Mode = CompetitionMode_Cash
+	te.table.Meta.AutoRebuyToStack = 1000
+	te.table.Meta.MaxBuyIn = 800
+
+	for playerID, bankroll := range map[string]int64{"P1": 100, "P2": 0, "P3": 100, "P4": 900} {
+		te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)].Bankroll = bankroll
+	}
+	assert.NoError(t, te.PlayerSetAutoRebuy("P1", true))
+	assert.NoError(t, te.PlayerSetAutoRebuy("P2", true))
+	assert.NoError(t, te.PlayerSetAutoRebuy("P4", true))
+
+	updatedPlayerIDs := make([]string, 0)
+	te.OnTablePlayerStateUpdated(func(competitionID, tableID string, playerState *TablePlayerState) {
+		updatedPlayerIDs = append(updatedPlayerIDs, playerState.PlayerID)
+	})
+
+	// capped by MaxBuyIn, players without auto-rebuy or above the target are untouched
+	toppedUpPlayers, err := te.autoRebuyPlayers()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"P1", "P2"}, funk.Map(toppedUpPlayers, func(p *TablePlayerState) string {
+		return p.PlayerID
+	}))
+	assert.ElementsMatch(t, []string{"P1", "P2"}, updatedPlayerIDs)
+	bankrolls := make(map[string]int64)
+	for _, player := range te.table.State.PlayerStates {
+		bankrolls[player.PlayerID] = player.Bankroll
+	}
+	assert.Equal(t, map[string]int64{"P1": 800, "P2": 800, "P3": 100, "P4": 900}, bankrolls)
+	assert.Equal(t, int64(1700), te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].BuyInTotal)
+
+	// cash mode only
+	te.table.Meta.Mode = CompetitionMode_CT
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll = 100
+	toppedUpPlayers, err = te.autoRebuyPlayers()
+	assert.NoError(t, err)
+	assert.Empty(t, toppedUpPlayers)
+}
+
+/*
+TestTableEngine_ConcurrentPublicMethods hammers the public methods from many goroutines
+  - Run with -race to verify that every method takes the table lock
+  - The methods are called while a hand is played, then while the table is closed & released
+*/
+func TestTableEngine_ConcurrentPublicMethods(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// every call is made from its own goroutine until stop returns true
+	hammer := func(stop func() bool, calls []func(i int)) *sync.WaitGroup {
+		var wg sync.WaitGroup
+		for _, call := range calls {
+			wg.Add(1)
+			go func(call func(i int)) {
+				defer wg.Done()
+				for i := 0; !stop(); i++ {
+					call(i)
+					time.Sleep(time.Millisecond)
+				}
+			}(call)
+		}
+		return &wg
+	}
+
+	var isHandOver atomic.Bool
+	wg := hammer(isHandOver.Load, []func(i int){
+		func(i int) { te.StartTableGame() },
+		func(i int) { te.StartTableGameIfNotStarted() },
+		func(i int) { te.PauseTable() },
+		func(i int) { te.UpdateBlind(i, 0, 0, 10, 20) },
+		func(i int) { te.GetPendingBlindLevel() },
+		func(i int) { te.PlayerJoin("P1") },
+		func(i int) { te.PlayerReserve(JoinPlayer{PlayerID: "P5", RedeemChips: 1000, Seat: 8}) },
+		func(i int) { te.PlayersLeave([]string{"P5"}) },
+		func(i int) {
+			if i%2 == 0 {
+				te.UpdateTablePlayers([]JoinPlayer{{PlayerID: "P6", RedeemChips: 1000, Seat: 7}}, nil)
+			} else {
+				te.UpdateTablePlayers(nil, []string{"P6"})
+			}
+		},
+		func(i int) { te.PlayerRedeemChips(JoinPlayer{PlayerID: "P2", RedeemChips: 10}) },
+		func(i int) { te.PlayerSetAutoRebuy("P3", i%2 == 0) },
+		func(i int) { te.PlayerPostMissedBlind("P3") },
+		func(i int) { te.PlayerDisconnect("P4") },
+		func(i int) { te.PlayerReconnect("P4") },
+		func(i int) { te.PlayerExtendActionDeadline("P1", 1) },
+		func(i int) { te.PlayerActionTimeout("P1") },
+		func(i int) { te.PlayerBet("P1", 20) },
+		func(i int) { te.PlayerFold("P2") },
+		func(i int) { te.GetStandings() },
+		func(i int) { te.GetNextBBOrder() },
+		func(i int) { te.GetOpenSeats() },
+		func(i int) { te.GetPlayerSessionStats("P2") },
+		func(i int) { te.GetNextHandParticipants() },
+		func(i int) { te.PeekNextPositions() },
+		func(i int) { te.IsPlayerTurn("P1") },
+		func(i int) { te.GetCurrentActorPlayerID() },
+		func(i int) { te.GetSeatHistory() },
+		func(i int) { te.HealthCheck() },
+	})
+
+	// everybody folds to the BB while the calls are made
+	driveTestHand(t, te, foldToBBTestWager(te), nil)
+	isHandOver.Store(true)
+	wg.Wait()
+	assert.ErrorIs(t, te.StartTableGame(), ErrTableAlreadyStarted)
+
+	// closing & releasing race with the calls between hands
+	rounds := 20
+	var closeCount atomic.Int64
+	isClosed := func() bool { return closeCount.Load() >= int64(rounds) }
+	wg = hammer(isClosed, []func(i int){
+		func(i int) {
+			te.CloseTable()
+			closeCount.Add(1)
+		},
+		func(i int) { te.ReleaseTable() },
+		func(i int) { te.PauseTable() },
+		func(i int) { te.ScheduleHardStop(time.Now()) },
+		func(i int) { te.PlayerRedeemChips(JoinPlayer{PlayerID: "P2", RedeemChips: 10}) },
+		func(i int) { te.GetStandings() },
+		func(i int) { te.HealthCheck() },
+	})
+	wg.Wait()
+	assert.Equal(t, TableStateStatus(TableStateStatus_TableClosed), te.table.State.Status)
+	assert.False(t, te.HealthCheck().IsEngineAlive)
+}
+
+func TestTableEngine_GetBettingRound(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	_, _, err := te.GetBettingRound()
+	assert.ErrorIs(t, err, ErrGameNotStarted)
+
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	tests := []struct {
+		name        string
+		event       pokerlib.GameEvent
+		round       string
+		actions     []string
+		bettingOpen bool
+	}{
+		{name: "ready", event: pokerlib.GameEvent_ReadyRequested, round: GameRound_Preflop, actions: []string{string(Action_Ready)}},
+		{name: "ante", event: pokerlib.GameEvent_AnteRequested, round: GameRound_Preflop, actions: []string{string(Action_Pay)}},
+		{name: "blinds", event: pokerlib.GameEvent_BlindsRequested, round: GameRound_Preflop, actions: []string{string(Action_Pay)}},
+		{name: "betting", event: pokerlib.GameEvent_RoundStarted, round: GameRound_Flop, actions: []string{string(WagerAction_Check), string(WagerAction_Bet)}, bettingOpen: true},
+		{name: "all-in player passes", event: pokerlib.GameEvent_RoundStarted, round: GameRound_Turn, actions: []string{string(Action_Pass)}},
+		{name: "no pending actions", event: pokerlib.GameEvent_RoundStarted, round: GameRound_Turn},
+		{name: "round closed", event: pokerlib.GameEvent_RoundClosed, round: GameRound_River},
+	}
+	for _, tt := range tests {
+		gs := newPreflopRaisedGameState()
+		gs.Status.CurrentEvent = pokerlib.GameEventSymbols[tt.event]
+		gs.Status.Round = tt.round
+		gs.Players[gs.Status.CurrentPlayer].AllowedActions = tt.actions
+		te.table.State.GameState = gs
+
+		round, bettingOpen, err := te.GetBettingRound()
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.round, round, tt.name)
+		assert.Equal(t, tt.bettingOpen, bettingOpen, tt.name)
+	}
+}
+
+func TestTableEngine_GetBoardTexture(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	_, err := te.GetBoardTexture()
+	assert.ErrorIs(t, err, ErrGameNotStarted)
+
+	// preflop
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	te.table.State.GameState = &pokerlib.GameState{}
+	texture, err := te.GetBoardTexture()
+	assert.NoError(t, err)
+	assert.Equal(t, BoardTexture{Board: []string{}}, texture)
+
+	// monotone flop
+	te.table.State.GameState.Status.Board = []string{"H9", "HT", "HJ"}
+	texture, err = te.GetBoardTexture()
+	assert.NoError(t, err)
+	assert.True(t, texture.IsMonotone)
+	assert.True(t, texture.IsFlushPossible)
+	assert.False(t, texture.IsTwoTone)
+	assert.False(t, texture.IsRainbow)
+	assert.False(t, texture.IsPaired)
+	assert.Equal(t, 3, texture.ConnectedCount)
+	assert.True(t, texture.IsStraightDrawHeavy)
+}
+
+func TestNewBoardTexture(t *testing.T) {
+	// paired two-tone turn
+	texture := newBoardTexture([]string{"SK", "HK", "S7", "D2"})
+	assert.True(t, texture.IsPaired)
+	assert.True(t, texture.IsTwoTone)
+	assert.False(t, texture.IsMonotone)
+	assert.False(t, texture.IsFlushPossible)
+	assert.False(t, texture.IsStraightDrawHeavy)
+
+	// rainbow dry flop
+	texture = newBoardTexture([]string{"SK", "H7", "D2"})
+	assert.True(t, texture.IsRainbow)
+	assert.False(t, texture.IsTwoTone)
+	assert.False(t, texture.IsPaired)
+	assert.Equal(t, 1, texture.ConnectedCount)
+	assert.False(t, texture.IsStraightDrawHeavy)
+
+	// the ace plays low in the wheel
+	texture = newBoardTexture([]string{"SA", "H2", "D4"})
+	assert.Equal(t, 3, texture.ConnectedCount)
+	assert.True(t, texture.IsStraightDrawHeavy)
+}
+
+func TestTableEngine_GetEffectiveStacks(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	_, err := te.GetEffectiveStacks()
+	assert.ErrorIs(t, err, ErrGameNotStarted)
+
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2"), te.table.FindPlayerIdx("P3"), te.table.FindPlayerIdx("P4")}
+	te.table.State.GameState = &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{
+			{Idx: 0, StackSize: 300},
+			{Idx: 1, StackSize: 1500},
+			{Idx: 2, StackSize: 800},
+			{Idx: 3, StackSize: 5000, Fold: true},
+		},
+	}
+
+	stacks, err := te.GetEffectiveStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"P1": 300, "P2": 800, "P3": 800}, stacks)
+
+	// P2 is all-in
+	te.table.State.GameState.Players[1].StackSize = 0
+	stacks, err = te.GetEffectiveStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"P1": 300, "P2": 0, "P3": 300}, stacks)
+}
+
+/*
+newTestMissedBBTableEngine sits P2 (the next BB) out through the next game
+  - The BB skips P2 and moves on to P3 (seat 4)
+*/
+func newTestMissedBBTableEngine(t *testing.T, lateRegBlindRule string) *tableEngine {
+	options := NewTableEngineOptions()
+	options.LateRegBlindRule = lateRegBlindRule
+	te := newTestPlayingTableEngine(t, options)
+
+	assert.NoError(t, te.sm.SitOutPlayers([]string{"P2"}))
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].IsIn = false
+	openTestNextGame(t, te)
+	assert.Equal(t, 4, te.sm.CurrentBBSeatID())
+	assert.True(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].MissedBB)
+
+	// sits back in
+	assert.NoError(t, te.PlayerJoin("P2"))
+	return te
+}
+
+func openTestNextGame(t *testing.T, te *tableEngine) {
+	newTable, err := te.openGame(te.table)
+	assert.NoError(t, err)
+	te.table = newTable
+	te.updateNextBBOrderPlayerIDs()
+}
+
+/*
+driveTestHand plays the started hand until isDone returns true, the test fails after 3 seconds
+  - Every player gets ready, pays the blinds & passes, wager plays the player to act
+  - The table is read under te.lock, the players act through the public methods once it is released
+  - isDone is called with the status & the game state of every poll, nil waits for the table back to standby
+*/
+func driveTestHand(t *testing.T, te *tableEngine, wager func(playerID string, gs *pokerlib.GameState), isDone func(status TableStateStatus, gs *pokerlib.GameState) bool) {
+	if isDone == nil {
+		isDone = func(status TableStateStatus, gs *pokerlib.GameState) bool {
+			return status == TableStateStatus_TableGameStandby
+		}
+	}
+
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("hand is not played to the end")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		te.lock.Lock()
+		status, gs := te.table.State.Status, te.table.State.GameState
+		playerIDs := make(map[int]string)
+		if gs != nil {
+			for _, p := range gs.Players {
+				if playerIdx := te.table.FindPlayerIndexFromGamePlayerIndex(p.Idx); !IsUnset(playerIdx) {
+					playerIDs[p.Idx] = te.table.State.PlayerStates[playerIdx].PlayerID
+				}
+			}
+		}
+		te.lock.Unlock()
+
+		if isDone(status, gs) {
+			return
+		}
+		if gs == nil {
+			continue
+		}
+
+		for _, p := range gs.Players {
+			playerID := playerIDs[p.Idx]
+			switch {
+			case gs.HasAction(p.Idx, string(Action_Ready)):
+				te.PlayerReady(playerID)
+			case gs.HasAction(p.Idx, string(Action_Pay)):
+				te.PlayerPay(playerID, 0)
+			case gs.HasAction(p.Idx, string(Action_Pass)):
+				te.PlayerPass(playerID)
+			case p.Idx == gs.Status.CurrentPlayer && wager != nil:
+				wager(playerID, gs)
+			}
+		}
+	}
+}
+
+// foldToBBTestWager folds every player to act, everybody folds to the BB
+func foldToBBTestWager(te *tableEngine) func(playerID string, gs *pokerlib.GameState) {
+	return func(playerID string, gs *pokerlib.GameState) {
+		if gs.HasAction(gs.Status.CurrentPlayer, string(WagerAction_Fold)) {
+			te.PlayerFold(playerID)
+		}
+	}
+}
+
+// checkDownTestWager checks or calls with every player to act, everybody goes to the showdown
+func checkDownTestWager(te *tableEngine) func(playerID string, gs *pokerlib.GameState) {
+	return func(playerID string, gs *pokerlib.GameState) {
+		switch {
+		case gs.HasAction(gs.Status.CurrentPlayer, string(WagerAction_Check)):
+			te.PlayerCheck(playerID)
+		case gs.HasAction(gs.Status.CurrentPlayer, string(WagerAction_Call)):
+			te.PlayerCall(playerID)
+		}
+	}
+}
+
+func TestTableEngine_GetHandBlindState(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+
+	playTestHand := func(gameCount int) {
+		te.lock.Lock()
+		defer te.lock.Unlock()
+		te.table.State.GameCount = gameCount
+		assert.NoError(t, te.startGame())
+		te.game.Close()
+		te.table.State.Status = TableStateStatus_TableGameStandby
+	}
+
+	playTestHand(1)
+	te.UpdateBlind(3, 10, 0, 50, 100)
+	playTestHand(2)
+
+	blind, err := te.GetHandBlindState(1)
+	assert.NoError(t, err)
+	assert.Equal(t, TableBlindState{Level: 1, SB: 10, BB: 20}, *blind)
+
+	blind, err = te.GetHandBlindState(2)
+	assert.NoError(t, err)
+	assert.Equal(t, TableBlindState{Level: 3, Ante: 10, SB: 50, BB: 100}, *blind)
+
+	// a copy, the history can't be changed
+	blind.Level = 4
+	blind, err = te.GetHandBlindState(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, blind.Level)
+
+	_, err = te.GetHandBlindState(3)
+	assert.ErrorIs(t, err, ErrTableHandBlindStateNotFound)
+}
+
+func TestTableEngine_WithHandRetention(t *testing.T) {
+	backend := NewNativeGameBackend()
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithGameBackend(backend), WithHandRetention(2))
+	openTestNextGame(t, te)
+
+	gameIDs := make([]string, 0)
+	for gameCount := 1; gameCount <= 3; gameCount++ {
+		te.lock.Lock()
+		te.table.State.GameCount = gameCount
+		assert.NoError(t, te.startGame())
+		te.game.Close()
+		gameIDs = append(gameIDs, te.game.GetGameState().GameID)
+		te.table.State.Status = TableStateStatus_TableGameStandby
+		te.lock.Unlock()
+	}
+
+	// the first hand is beyond the retention
+	_, err := te.GetHandCommitment(1)
+	assert.ErrorIs(t, err, ErrTableHandCommitmentNotFound)
+	_, err = te.GetHandBlindState(1)
+	assert.ErrorIs(t, err, ErrTableHandBlindStateNotFound)
+	_, err = backend.GetSeed(gameIDs[0])
+	assert.ErrorIs(t, err, ErrGameSeedNotFound)
+
+	for gameCount := 2; gameCount <= 3; gameCount++ {
+		_, err := te.GetHandCommitment(gameCount)
+		assert.NoError(t, err)
+		_, err = te.GetHandBlindState(gameCount)
+		assert.NoError(t, err)
+		_, err = backend.GetSeed(gameIDs[gameCount-1])
+		assert.NoError(t, err)
+	}
+}
+
+func TestTableEngine_HandRetentionOption(t *testing.T) {
+	// bounded by default, tables created by the Manager only take the options
+	assert.Positive(t, NewTableEngineOptions().HandRetention)
+
+	options := NewTableEngineOptions()
+	options.HandRetention = 1
+	te := newTestPlayingTableEngine(t, options)
+	openTestNextGame(t, te)
+
+	for gameCount := 1; gameCount <= 2; gameCount++ {
+		te.lock.Lock()
+		te.table.State.GameCount = gameCount
+		assert.NoError(t, te.startGame())
+		te.game.Close()
+		te.table.State.Status = TableStateStatus_TableGameStandby
+		te.lock.Unlock()
+	}
+
+	_, err := te.GetHandCommitment(1)
+	assert.ErrorIs(t, err, ErrTableHandCommitmentNotFound)
+	_, err = te.GetHandCommitment(2)
+	assert.NoError(t, err)
+}
+
+func TestTableEngine_GlobalHandID(t *testing.T) {
+	ids := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+		openTestNextGame(t, te)
+		_, err := te.GetCurrentGlobalHandID()
+		assert.ErrorIs(t, err, ErrGameNotStarted)
+
+		for hand := 0; hand < 3; hand++ {
+			te.lock.Lock()
+			te.table.State.GameCount = 1 // the game count starts over after every merge
+			assert.NoError(t, te.startGame())
+			te.game.Close()
+			pga := te.createPlayerGameAction("P1", 0, WagerAction_Fold, 0, nil)
+			te.lock.Unlock()
+
+			id, err := te.GetCurrentGlobalHandID()
+			assert.NoError(t, err)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, id, pga.GlobalHandID)
+			assert.False(t, ids[id], "hand id %s is reused", id)
+			ids[id] = true
+		}
+	}
+	assert.Len(t, ids, 6)
+}
+
+func TestTableEngine_MissedBB_PostDead(t *testing.T) {
+	te := newTestMissedBBTableEngine(t, LateRegBlindRule_PostDead)
+	p2 := te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")]
+	assert.True(t, te.sm.Seats()[2].IsWaitingBB)
+
+	// no more waiting, the blind is posted in the next game P2 is dealt in
+	assert.NoError(t, te.PlayerPostMissedBlind("P2"))
+	assert.False(t, te.sm.Seats()[2].IsWaitingBB)
+	assert.False(t, p2.MissedBB)
+	assert.True(t, p2.MustPostBB)
+
+	assert.ErrorIs(t, te.PlayerPostMissedBlind("P2"), ErrTablePlayerNoMissedBlind)
+	assert.ErrorIs(t, te.PlayerPostMissedBlind("P5"), ErrTablePlayerNotFound)
+}
+
+func TestTableEngine_MissedBB_WaitForBB(t *testing.T) {
+	te := newTestMissedBBTableEngine(t, LateRegBlindRule_WaitForBB)
+	assert.ErrorIs(t, te.PlayerPostMissedBlind("P2"), ErrTablePlayerInvalidAction)
+
+	// BB: P4 (seat 6), P1 (seat 0), then P2 (seat 2)
+	for _, bbSeatID := range []int{6, 0} {
+		openTestNextGame(t, te)
+		assert.Equal(t, bbSeatID, te.sm.CurrentBBSeatID())
+		active, err := te.sm.IsPlayerActive("P2")
+		assert.NoError(t, err)
+		assert.False(t, active)
+	}
+
+	openTestNextGame(t, te)
+	assert.Equal(t, 2, te.sm.CurrentBBSeatID())
+	active, err := te.sm.IsPlayerActive("P2")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.False(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].MissedBB)
+}
+
+func TestTableEngine_MissedBB_None(t *testing.T) {
+	te := newTestMissedBBTableEngine(t, LateRegBlindRule_None)
+
+	assert.False(t, te.sm.Seats()[2].IsWaitingBB)
+	assert.False(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].MissedBB)
+}
+
+/*
+newTestInsuranceTableEngine runs a two-way all-in into the flop
+  - P1 (AA) & P2 (KK) are all-in for 1000 each, board: D2 C7 S9
+  - P2 wins with a king on the turn or river, unless the other card is an ace (83 of 990 runouts)
+*/
+func newTestInsuranceTableEngine(t *testing.T) (*tableEngine, *pokerlib.GameState) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+
+	gs := &pokerlib.GameState{GameID: "game"}
+	gs.Meta.Deck = pokerlib.NewStandardDeckCards()
+	gs.Meta.CombinationPowers = pokerlib.NewStardardGameOptions().CombinationPowers
+	gs.Status.Round = GameRound_Flop
+	gs.Status.Board = []string{"D2", "C7", "S9"}
+	gs.Status.Pots = []*pot.Pot{{Total: 2000, Contributors: map[int]int64{0: 1000, 1: 1000}}}
+	gs.Players = []*pokerlib.PlayerState{
+		{Idx: 0, Pot: 1000, HoleCards: []string{"SA", "HA"}},
+		{Idx: 1, Pot: 1000, HoleCards: []string{"SK", "HK"}},
+	}
+	te.table.State.GameState = gs
+	return te, gs
+}
+
+func settleTestInsuranceGame(te *tableEngine, winnerGamePlayerIdx int) {
+	gs := te.table.State.GameState
+	results := make([]*settlement.PlayerResult, 0)
+	for _, p := range gs.Players {
+		p.Combination = &pokerlib.CombinationInfo{Power: 100}
+		final := int64(0)
+		if p.Idx == winnerGamePlayerIdx {
+			p.Combination.Power = 200
+			final = 2000
+		}
+		results = append(results, &settlement.PlayerResult{Idx: p.Idx, Final: final, Changed: final - 1000})
+	}
+	gs.Result = &settlement.Result{Players: results}
+	te.settleGame()
+}
+
+func TestTableEngine_Insurance_TwoWayAllin(t *testing.T) {
+	te, gs := newTestInsuranceTableEngine(t)
+
+	equities := make(map[string]float64)
+	maxPayouts := make(map[string]int64)
+	te.OnInsuranceOffer(func(playerID string, equity float64, maxPayout int64) {
+		equities[playerID] = equity
+		maxPayouts[playerID] = maxPayout
+
+		// P1 is ahead and insures the pot right away
+		if playerID == "P1" {
+			assert.ErrorIs(t, te.PlayerBuyInsurance(playerID, 0), ErrTableInvalidInsuranceAmount)
+			assert.ErrorIs(t, te.PlayerBuyInsurance(playerID, 168), ErrTableInvalidInsuranceAmount)
+			assert.NoError(t, te.PlayerBuyInsurance(playerID, 83))
+			assert.ErrorIs(t, te.PlayerBuyInsurance(playerID, 83), ErrTablePlayerNoInsuranceOffer)
+		}
+	})
+	te.offerInsurance(gs)
+
+	assert.InDelta(t, 907.0/990, equities["P1"], 1e-9)
+	assert.InDelta(t, 83.0/990, equities["P2"], 1e-9)
+	assert.Equal(t, map[string]int64{"P1": 2000, "P2": 2000}, maxPayouts)
+
+	assert.Len(t, te.table.State.Insurances, 1)
+	insurance := te.table.State.Insurances[0]
+	assert.Equal(t, "P1", insurance.PlayerID)
+	assert.Equal(t, GameRound_Flop, insurance.Round)
+	assert.Equal(t, int64(83), insurance.Amount)
+	assert.InDelta(t, 990, insurance.Coverage, 1)
+
+	// P2's offer expires with the turn
+	turn := cloneGameState(gs)
+	turn.Status.Round = GameRound_Turn
+	turn.Status.Board = append(turn.Status.Board, "C3")
+	te.expireInsuranceOffers(turn)
+	assert.ErrorIs(t, te.PlayerBuyInsurance("P2", 1), ErrTablePlayerNoInsuranceOffer)
+
+	t.Run("InsuredPlayerWins", func(t *testing.T) {
+		te, gs := newTestInsuranceTableEngine(t)
+		te.OnInsuranceOffer(func(playerID string, equity float64, maxPayout int64) {
+			if playerID == "P1" {
+				assert.NoError(t, te.PlayerBuyInsurance(playerID, 83))
+			}
+		})
+		te.offerInsurance(gs)
+		settleTestInsuranceGame(te, 0)
+
+		// the premium is charged apart from the pot
+		p1 := te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")]
+		assert.Equal(t, int64(2000-83), p1.Bankroll)
+		assert.Equal(t, int64(1000-83), p1.SessionNet)
+		assert.Equal(t, int64(0), te.table.State.Insurances[0].Payout)
+		assert.Equal(t, int64(0), te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll)
+	})
+
+	t.Run("InsuredPlayerLoses", func(t *testing.T) {
+		te, gs := newTestInsuranceTableEngine(t)
+		te.OnInsuranceOffer(func(playerID string, equity float64, maxPayout int64) {
+			if playerID == "P1" {
+				assert.NoError(t, te.PlayerBuyInsurance(playerID, 83))
+			}
+		})
+		te.offerInsurance(gs)
+		settleTestInsuranceGame(te, 1)
+
+		// the coverage keeps P1 alive, the pot goes to P2 as is
+		insurance := te.table.State.Insurances[0]
+		assert.Equal(t, insurance.Coverage, insurance.Payout)
+		p1 := te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")]
+		assert.Equal(t, insurance.Coverage-83, p1.Bankroll)
+		assert.Equal(t, int64(2000), te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll)
+	})
+}
+
+func TestTableEngine_Insurance_NotOffered(t *testing.T) {
+	te, gs := newTestInsuranceTableEngine(t)
+	offered := false
+	te.OnInsuranceOffer(func(playerID string, equity float64, maxPayout int64) {
+		offered = true
+	})
+
+	// P2 still has chips to bet with
+	gs.Players[0].StackSize = 500
+	gs.Players[1].StackSize = 500
+	te.offerInsurance(gs)
+
+	// river: no cards to come
+	gs.Players[0].StackSize = 0
+	gs.Players[1].StackSize = 0
+	gs.Status.Board = append(gs.Status.Board, "C3", "H4")
+	te.offerInsurance(gs)
+
+	assert.False(t, offered)
+	assert.ErrorIs(t, te.PlayerBuyInsurance("P1", 1), ErrTablePlayerNoInsuranceOffer)
+}
+
+func TestTableEngine_ActionRequired_PotOdds(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.ActionRequiredEquity = true
+	te, gs := newTestInsuranceTableEngine(t)
+	te.options = options
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+
+	var actions []ActionRequired
+	te.OnActionRequired(func(action ActionRequired) {
+		actions = append(actions, action)
+	})
+
+	// P1 bets half the pot into P2
+	gs.Status.Pots = nil
+	gs.Status.CurrentWager = 50
+	gs.Status.CurrentPlayer = 1
+	gs.Players[0].Pot, gs.Players[0].Wager, gs.Players[0].StackSize = 50, 50, 900
+	gs.Players[1].Pot, gs.Players[1].StackSize = 50, 950
+	gs.Players[1].AllowedActions = []string{string(WagerAction_Fold), string(WagerAction_Call), string(WagerAction_Raise)}
+
+	te.emitActionRequired(pokerlib.GameEvent_RoundStarted, gs)
+	assert.Len(t, actions, 1)
+	assert.Equal(t, "P2", actions[0].PlayerID)
+	assert.Equal(t, int64(150), actions[0].Pot)
+	assert.Equal(t, int64(50), actions[0].CallAmount)
+	assert.InDelta(t, 0.25, actions[0].PotOdds, 1e-9)
+	if assert.NotNil(t, actions[0].Equity) {
+		assert.InDelta(t, 83.0/990, *actions[0].Equity, 1e-9)
+	}
+
+	// nothing to call, equity disabled
+	te.options.ActionRequiredEquity = false
+	gs.Status.CurrentWager = 0
+	gs.Players[0].Wager = 0
+	te.emitActionRequired(pokerlib.GameEvent_RoundStarted, gs)
+	assert.Len(t, actions, 2)
+	assert.Equal(t, int64(0), actions[1].CallAmount)
+	assert.Equal(t, float64(0), actions[1].PotOdds)
+	assert.Nil(t, actions[1].Equity)
+
+	// player already acted
+	gs.Players[1].Acted = true
+	te.emitActionRequired(pokerlib.GameEvent_RoundStarted, gs)
+	assert.Len(t, actions, 2)
+}
+
+func TestTableEngine_GetRaiseSizingOptions(t *testing.T) {
+	te, gs := newTestInsuranceTableEngine(t)
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+
+	// P1 bets half the pot into P2
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted]
+	gs.Status.Pots = nil
+	gs.Status.CurrentWager = 50
+	gs.Status.PreviousRaiseSize = 50
+	gs.Status.CurrentPlayer = 1
+	gs.Players[0].Pot, gs.Players[0].Wager, gs.Players[0].StackSize = 50, 50, 900
+	gs.Players[1].Pot, gs.Players[1].StackSize = 50, 950
+
+	sizing, err := te.GetRaiseSizingOptions("P2")
+	assert.NoError(t, err)
+	assert.Equal(t, RaiseSizing{
+		Pot:                  150,
+		MinRaise:             100,
+		HalfPotRaise:         150,
+		ThreeQuarterPotRaise: 200,
+		PotRaise:             250,
+		AllIn:                950,
+	}, sizing)
+
+	_, err = te.GetRaiseSizingOptions("P1")
+	assert.ErrorIs(t, err, ErrNotPlayersTurn)
+	_, err = te.GetRaiseSizingOptions("P9")
+	assert.ErrorIs(t, err, ErrTablePlayerNotFound)
+
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundClosed]
+	_, err = te.GetRaiseSizingOptions("P2")
+	assert.ErrorIs(t, err, ErrGameInvalidAction)
+}
+
+func TestTableEngine_GetCallAmount(t *testing.T) {
+	te, gs := newTestInsuranceTableEngine(t)
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+
+	// P1 raises to 300, P2 has 120 behind after betting 40
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted]
+	gs.Status.CurrentWager = 300
+	gs.Status.CurrentPlayer = 1
+	gs.Players[0].Wager, gs.Players[0].StackSize = 300, 700
+	gs.Players[1].Wager, gs.Players[1].StackSize = 40, 120
+	gs.Players[1].AllowedActions = []string{string(WagerAction_Fold), string(WagerAction_Call), string(WagerAction_AllIn)}
+
+	// all-in for less
+	amount, err := te.GetCallAmount("P2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), amount)
+
+	// a deeper stack calls the difference
+	gs.Players[1].StackSize = 900
+	amount, err = te.GetCallAmount("P2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(260), amount)
+
+	_, err = te.GetCallAmount("P1")
+	assert.ErrorIs(t, err, ErrNotPlayersTurn)
+	assert.ErrorIs(t, err, ErrGameInvalidAction)
+	_, err = te.GetCallAmount("P9")
+	assert.ErrorIs(t, err, ErrTablePlayerNotFound)
+
+	gs.Players[1].AllowedActions = []string{string(WagerAction_Fold), string(WagerAction_AllIn)}
+	_, err = te.GetCallAmount("P2")
+	assert.ErrorIs(t, err, ErrActionNotAllowed)
+	assert.ErrorIs(t, err, ErrGameInvalidAction)
+}
+
+func TestRaiseSizing(t *testing.T) {
+	gs := &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{
+			{Idx: 0, Pot: 50, StackSize: 1000},
+			{Idx: 1, Pot: 50, StackSize: 1000},
+		},
+	}
+	gs.Status.MiniBet = 20
+
+	// nothing to call
+	assert.Equal(t, RaiseSizing{Pot: 100, MinRaise: 20, HalfPotRaise: 50, ThreeQuarterPotRaise: 75, PotRaise: 100, AllIn: 1000}, raiseSizing(gs, 1))
+
+	// facing a bet of 50, presets are capped at all-in
+	gs.Status.CurrentWager = 50
+	gs.Status.PreviousRaiseSize = 50
+	gs.Players[0].Wager = 50
+	gs.Players[1].StackSize = 120
+	assert.Equal(t, RaiseSizing{Pot: 150, MinRaise: 100, HalfPotRaise: 120, ThreeQuarterPotRaise: 120, PotRaise: 120, AllIn: 120}, raiseSizing(gs, 1))
+
+	// too short to raise
+	gs.Players[1].StackSize = 80
+	assert.Equal(t, RaiseSizing{Pot: 150, MinRaise: 80, HalfPotRaise: 80, ThreeQuarterPotRaise: 80, PotRaise: 80, AllIn: 80}, raiseSizing(gs, 1))
+}
+
+func waitTestGameEvent(t *testing.T, te *tableEngine, event pokerlib.GameEvent) {
+	assert.Eventually(t, func() bool {
+		te.lock.Lock()
+		defer te.lock.Unlock()
+		gs := te.table.State.GameState
+		return gs != nil && gs.Status.CurrentEvent == pokerlib.GameEventSymbols[event]
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTableEngine_OnBlindPosted(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+
+	type posted struct {
+		playerID string
+		position string
+		amount   int64
+	}
+	var mu sync.Mutex
+	var posts []posted
+	te.OnBlindPosted(func(playerID string, position string, amount int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		posts = append(posts, posted{playerID: playerID, position: position, amount: amount})
+	})
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+	defer te.game.Close()
+
+	waitTestGameEvent(t, te, pokerlib.GameEvent_ReadyRequested)
+	for _, playerID := range []string{"P1", "P2", "P3", "P4"} {
+		assert.NoError(t, te.PlayerReady(playerID))
+	}
+
+	sbPlayerID, bbPlayerID, otherPlayerID := "", "", ""
+	for _, player := range te.table.State.PlayerStates {
+		if funk.Contains(player.Positions, Position_SB) {
+			sbPlayerID = player.PlayerID
+		} else if funk.Contains(player.Positions, Position_BB) {
+			bbPlayerID = player.PlayerID
+		} else {
+			otherPlayerID = player.PlayerID
+		}
+	}
+
+	waitTestGameEvent(t, te, pokerlib.GameEvent_BlindsRequested)
+	assert.Error(t, te.PlayerPay(otherPlayerID, 0))
+	assert.NoError(t, te.PlayerPay(sbPlayerID, 10))
+	assert.NoError(t, te.PlayerPay(bbPlayerID, 20))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []posted{
+		{playerID: sbPlayerID, position: Position_SB, amount: 10},
+		{playerID: bbPlayerID, position: Position_BB, amount: 20},
+	}, posts)
+}
+
+func TestTableEngine_OnBlindPosted_AutoPostBlinds(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.Meta.AutoPostBlinds = true
+	openTestNextGame(t, te)
+
+	var mu sync.Mutex
+	posts := make(map[string]int64)
+	te.OnBlindPosted(func(playerID string, position string, amount int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		posts[position] = amount
+	})
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+	defer te.game.Close()
+
+	waitTestGameEvent(t, te, pokerlib.GameEvent_ReadyRequested)
+	for _, playerID := range []string{"P1", "P2", "P3", "P4"} {
+		assert.NoError(t, te.PlayerReady(playerID))
+	}
+
+	// no PlayerPay, the game posts the blinds by itself
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(posts) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int64{Position_SB: 10, Position_BB: 20}, posts)
+}
+
+func TestTableEngine_ScheduleHardStop(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+	gameCount := te.table.State.GameCount
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	ended := make(chan string, 1)
+	te.OnAutoGameOpenEnd(func(competitionID, tableID string) {
+		ended <- tableID
+	})
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// venue closes mid-hand
+	waitTestGameEvent(t, te, pokerlib.GameEvent_ReadyRequested)
+	assert.NoError(t, te.ScheduleHardStop(time.Now()))
+
+	// everybody folds to the BB, the hand is played to the end
+	driveTestHand(t, te, foldToBBTestWager(te), func(status TableStateStatus, gs *pokerlib.GameState) bool {
+		return len(ended) > 0
+	})
+	assert.Equal(t, te.table.ID, <-ended)
+
+	te.lock.Lock()
+	assert.Equal(t, gameCount, te.table.State.GameCount)
+	assert.EqualValues(t, TableStateStatus_TableGameStandby, te.table.State.Status)
+	te.lock.Unlock()
+
+	assert.NoError(t, te.CloseTable())
+	assert.ErrorIs(t, te.ScheduleHardStop(time.Now()), ErrTableClosed)
+}
+
+func TestTableEngine_CloseTable_DeliversLastHand(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	type tableUpdate struct {
+		status    TableStateStatus
+		isSettled bool
+	}
+	updates := make(chan tableUpdate, 1024)
+	te.OnTableUpdated(func(table *Table) {
+		gs := table.State.GameState
+		updates <- tableUpdate{
+			status:    table.State.Status,
+			isSettled: gs != nil && gs.Result != nil,
+		}
+	})
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// everybody folds to the BB, the table is closed before the last fold is handled
+	closed := false
+	driveTestHand(t, te, func(playerID string, gs *pokerlib.GameState) {
+		inHandCount := 0
+		for _, p := range gs.Players {
+			if !p.Fold {
+				inHandCount++
+			}
+		}
+
+		if inHandCount > 2 {
+			te.PlayerFold(playerID)
+			return
+		}
+
+		// the game closes asynchronously, its states wait for the lock
+		te.lock.Lock()
+		_, err := te.game.Fold(gs.Status.CurrentPlayer)
+		assert.NoError(t, err)
+		assert.NoError(t, te.closeTable())
+		te.lock.Unlock()
+		closed = true
+	}, func(status TableStateStatus, gs *pokerlib.GameState) bool {
+		return closed
+	})
+
+	// closed, then the settled last hand, then closed as the final word
+	isClosed, isSettled := false, false
+	for !isSettled || !isClosed {
+		select {
+		case update := <-updates:
+			if update.status == TableStateStatus_TableClosed {
+				isClosed = true
+			}
+			if update.isSettled && isClosed {
+				isSettled = true
+				isClosed = false
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("final state is not delivered, settled: %t, closed: %t", isSettled, isClosed)
+		}
+	}
+
+	te.lock.Lock()
+	assert.EqualValues(t, TableStateStatus_TableClosed, te.table.State.Status)
+	te.lock.Unlock()
+}
+
+// failingGameBackend fails to create games like a remote backend that is down
+type failingGameBackend struct {
+	*NativeGameBackend
+}
+
+func (b *failingGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
+	return nil, errors.New("backend is down")
+}
+
+func TestTableEngine_SwapGameBackend(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithGameBackend(&failingGameBackend{NativeGameBackend: NewNativeGameBackend()}))
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	te.lock.Lock()
+	assert.Error(t, te.startGame())
+	te.lock.Unlock()
+
+	// fail over, the hand is dealt again with the native backend
+	assert.NoError(t, te.SwapGameBackend(NewNativeGameBackend()))
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+	assert.ErrorIs(t, te.SwapGameBackend(NewNativeGameBackend()), ErrTableSwapGameBackendInvalidState)
+
+	// everybody folds to the BB
+	driveTestHand(t, te, foldToBBTestWager(te), nil)
+
+	// the blinds went to the BB
+	bankrolls := make(map[string]int64)
+	for _, standing := range te.GetStandings() {
+		bankrolls[standing.PlayerID] = standing.Bankroll
+	}
+	assert.Equal(t, int64(4000), bankrolls["P1"]+bankrolls["P2"]+bankrolls["P3"]+bankrolls["P4"])
+	assert.NotEqual(t, map[string]int64{"P1": 1000, "P2": 1000, "P3": 1000, "P4": 1000}, bankrolls)
+	assert.NoError(t, te.SwapGameBackend(NewNativeGameBackend()))
+}
+
+func TestBlindPosting(t *testing.T) {
+	gs := &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{
+			{Idx: 0, Positions: []string{Position_Dealer}, StackSize: 1000},
+			{Idx: 1, Positions: []string{Position_SB}, StackSize: 5},
+		},
+	}
+	gs.Meta.Ante = 10
+	gs.Meta.Blind = pokerlib.BlindSetting{Dealer: 5, SB: 10, BB: 20}
+
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_AnteRequested]
+	position, amount := blindPosting(gs, 0)
+	assert.Equal(t, Position_Ante, position)
+	assert.Equal(t, int64(10), amount)
+
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_BlindsRequested]
+	position, amount = blindPosting(gs, 0)
+	assert.Equal(t, Position_Dealer, position)
+	assert.Equal(t, int64(5), amount)
+
+	// short SB posts all-in
+	position, amount = blindPosting(gs, 1)
+	assert.Equal(t, Position_SB, position)
+	assert.Equal(t, int64(5), amount)
+
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted]
+	position, _ = blindPosting(gs, 0)
+	assert.Empty(t, position)
+}
+
+// misdealGameBackend deals duplicate cards in the first misdeals games
+type misdealGameBackend struct {
+	*NativeGameBackend
+	misdeals int
+}
+
+func (b *misdealGameBackend) CreateGame(opts *pokerlib.GameOptions) (*pokerlib.GameState, error) {
+	gs, err := b.NativeGameBackend.CreateGame(opts)
+	if err != nil || b.misdeals == 0 {
+		return gs, err
+	}
+
+	b.misdeals--
+	for _, p := range gs.Players {
+		p.HoleCards = []string{"SA", fmt.Sprintf("H%d", p.Idx+2)}
+	}
+	return gs, nil
+}
+
+type misdeal struct {
+	gameCount int
+	reason    string
+}
+
+func newTestMisdealTableEngine(t *testing.T, misdealCount int) (*tableEngine, chan misdeal) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithGameBackend(&misdealGameBackend{NativeGameBackend: NewNativeGameBackend(), misdeals: misdealCount}))
+	openTestNextGame(t, te)
+
+	misdeals := make(chan misdeal, 8)
+	te.OnMisdeal(func(gameCount int, reason string) {
+		misdeals <- misdeal{gameCount: gameCount, reason: reason}
+	})
+	return te, misdeals
+}
+
+func TestTableEngine_Misdeal_Redeal(t *testing.T) {
+	te, misdeals := newTestMisdealTableEngine(t, 1)
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	misdealtGame := te.game
+	te.lock.Unlock()
+
+	select {
+	case m := <-misdeals:
+		assert.Equal(t, te.table.State.GameCount, m.gameCount)
+		assert.Equal(t, "duplicate card SA", m.reason)
+	case <-time.After(time.Second):
+		t.Fatal("misdeal is not detected")
+	}
+
+	// the hand is dealt again, nobody's chips moved
+	assert.Eventually(t, func() bool {
+		te.lock.Lock()
+		defer te.lock.Unlock()
+		return te.game != misdealtGame && te.table.State.GameState != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, misdeals, 0)
+	for _, player := range te.table.State.PlayerStates {
+		assert.Equal(t, int64(1000), player.Bankroll)
+	}
+}
+
+func TestTableEngine_Misdeal_LimitReached(t *testing.T) {
+	te, misdeals := newTestMisdealTableEngine(t, maxMisdealRedeals+1)
+	assert.NoError(t, te.startGame())
+
+	select {
+	case tableErr := <-te.ErrorChannel():
+		assert.ErrorIs(t, tableErr, ErrTableMisdealLimitReached)
+	case <-time.After(time.Second):
+		t.Fatal("misdealt hand is not voided")
+	}
+	assert.Len(t, misdeals, maxMisdealRedeals+1)
+}
+
+func TestValidateDeal(t *testing.T) {
+	gs := &pokerlib.GameState{
+		Players: []*pokerlib.PlayerState{{Idx: 0}, {Idx: 1}},
+	}
+	assert.Empty(t, validateDeal(gs, 2))
+
+	gs.Players[0].HoleCards = []string{"SA", "SK"}
+	assert.Equal(t, "player 1 has 0 hole cards, expected 2", validateDeal(gs, 2))
+
+	gs.Players[1].HoleCards = []string{"HA", "HK", "HQ"}
+	assert.Equal(t, "player 1 has 3 hole cards, expected 2", validateDeal(gs, 2))
+
+	gs.Players[1].HoleCards = []string{"HA", "HK"}
+	gs.Status.Board = []string{"D2", "D3", "D4"}
+	assert.Empty(t, validateDeal(gs, 2))
+
+	gs.Status.Board = []string{"D2", "D3", "HK"}
+	assert.Equal(t, "duplicate card HK", validateDeal(gs, 2))
+}
+
+// bustTestPlayer busts the player between hands
+func bustTestPlayer(t *testing.T, te *tableEngine, playerID string) {
+	te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)].Bankroll = 0
+	assert.NoError(t, te.sm.UpdatePlayerHasChips(playerID, false))
+}
+
+func TestTableEngine_OpenGame_HeadsUpAfterBust(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 9, []int{1, 4, 6})
+	openTestNextGame(t, te)
+
+	bustTestPlayer(t, te, "P6")
+	openTestNextGame(t, te)
+
+	// the button posts the sb, the other player the bb
+	assert.Equal(t, 4, te.sm.CurrentDealerSeatID())
+	assert.Equal(t, 4, te.sm.CurrentSBSeatID())
+	assert.Equal(t, 1, te.sm.CurrentBBSeatID())
+	assert.Equal(t, []int{te.table.FindPlayerIdx("P4"), te.table.FindPlayerIdx("P1")}, te.table.State.GamePlayerIndexes)
+	assert.Equal(t, []string{Position_Dealer, Position_SB}, te.table.State.PlayerStates[te.table.FindPlayerIdx("P4")].Positions)
+	assert.Equal(t, []string{Position_BB}, te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Positions)
+	assert.Empty(t, te.table.State.PlayerStates[te.table.FindPlayerIdx("P6")].Positions)
+}
+
+func TestTableEngine_OpenGame_SinglePlayerAfterBust(t *testing.T) {
+	te := newTestPositionsTableEngine(t, 9, []int{1, 6})
+	openTestNextGame(t, te)
+
+	starved := 0
+	te.OnTableStarved(func(tableID string) {
+		starved++
+	})
+
+	bustTestPlayer(t, te, "P1")
+	for _, player := range te.table.State.PlayerStates {
+		player.IsParticipated = player.PlayerID == "P6"
+	}
+
+	// no dealer/sb/bb for the last player
+	assert.NotPanics(t, func() {
+		assert.Empty(t, te.calcGamePlayerIndexes(te.table.Meta.Rule, 9, te.sm.CurrentDealerSeatID(), te.sm.CurrentSBSeatID(), te.sm.CurrentBBSeatID(), te.table.State.SeatMap, te.table.State.PlayerStates))
+	})
+
+	// no hand opens, the table is starved right away instead of retrying
+	_, err := te.openGame(te.table)
+	assert.ErrorIs(t, err, ErrTableOpenGameFailedNotEnoughPlayers)
+	assert.NoError(t, te.tableGameOpen())
+	assert.Equal(t, 1, starved)
+	assert.Nil(t, te.table.State.GameState)
+	reason, attempts := te.GetLastOpenGameFailure()
+	assert.Equal(t, ErrTableOpenGameFailedNotEnoughPlayers.Error(), reason)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTableEngine_UpdatePlayerPositions_SinglePlayer(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(newTestTableSetting())
+	assert.NoError(t, err)
+	assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: "P1", RedeemChips: 1000, Seat: 1}))
+	assert.NoError(t, te.PlayerJoin("P1"))
+
+	// positions can't be initialized for a single player
+	assert.NotPanics(t, func() {
+		te.updatePlayerPositions(te.table.Meta.TableMaxSeatCount, te.table.State.PlayerStates)
+	})
+	assert.Empty(t, te.table.State.PlayerStates[0].Positions)
+}
+
+func TestTableEngine_HealthCheck(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	// idle tables are healthy however long ago they were updated
+	te.table.UpdateAt = time.Now().Add(-time.Hour).Unix()
+	health := te.HealthCheck()
+	assert.True(t, health.IsHealthy)
+	assert.False(t, health.IsHandRunning)
+	assert.GreaterOrEqual(t, health.SecondsSinceUpdate, int64(3600))
+
+	// P2 is to act in a playing hand
+	te.game = NewGame(&stubGameBackend{}, pokerlib.NewStardardGameOptions())
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P3"), te.table.FindPlayerIdx("P4"), te.table.FindPlayerIdx("P1")}
+	te.table.State.GameState = newPreflopRaisedGameState()
+	te.table.UpdateAt = time.Now().Unix()
+	health = te.HealthCheck()
+	assert.True(t, health.IsHealthy)
+	assert.True(t, health.IsHandProgressing)
+	assert.True(t, health.IsEngineAlive)
+	assert.Equal(t, "P1", health.PendingActionPlayerID)
+
+	// stuck: no updates for longer than the stall timeout
+	te.table.UpdateAt = time.Now().Add(-time.Duration(te.options.HealthStallTimeout+1) * time.Second).Unix()
+	health = te.HealthCheck()
+	assert.False(t, health.IsHealthy)
+	assert.False(t, health.IsHandProgressing)
+	assert.True(t, health.IsEngineAlive)
+	assert.Equal(t, TableStateStatus(TableStateStatus_TableGamePlaying), health.Status)
+
+	// the game stopped handling states in the middle of the hand
+	te.table.UpdateAt = time.Now().Unix()
+	te.game.Close()
+	health = te.HealthCheck()
+	assert.False(t, health.IsHealthy)
+	assert.False(t, health.IsEngineAlive)
+}
+
+func TestTableEngine_GetActionTimer(t *testing.T) {
+	te, gs := newTestInsuranceTableEngine(t)
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+	te.table.Meta.ActionTime = 10
+
+	playerID, startAt, endAt, extended := te.GetActionTimer()
+	assert.Equal(t, "", playerID)
+	assert.Equal(t, int64(0), startAt)
+	assert.Equal(t, int64(0), endAt)
+	assert.False(t, extended)
+
+	// P2 to act on the flop
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted]
+	gs.Status.CurrentPlayer = 1
+	gs.Players[1].AllowedActions = []string{string(WagerAction_Fold), string(WagerAction_Call), string(WagerAction_Raise)}
+	te.updateCurrentActionEndAt(pokerlib.GameEvent_RoundStarted, gs)
+
+	playerID, startAt, endAt, extended = te.GetActionTimer()
+	assert.Equal(t, "P2", playerID)
+	assert.NotZero(t, startAt)
+	assert.Equal(t, startAt+10, endAt)
+	assert.False(t, extended)
+
+	extendedEndAt, err := te.PlayerExtendActionDeadline("P2", 5)
+	assert.NoError(t, err)
+
+	playerID, extendedStartAt, endAt, extended := te.GetActionTimer()
+	assert.Equal(t, "P2", playerID)
+	assert.Equal(t, startAt, extendedStartAt)
+	assert.Equal(t, extendedEndAt, endAt)
+	assert.Equal(t, startAt+15, endAt)
+	assert.True(t, extended)
+
+	// the next action timer starts over
+	te.updateCurrentActionEndAt(pokerlib.GameEvent_RoundStarted, gs)
+	_, _, _, extended = te.GetActionTimer()
+	assert.False(t, extended)
+}
+
+func TestTableEngine_SettleGame_HandResults(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	playerIDs := []string{"P1", "P2", "P3", "P4"}
+	te.table.State.GamePlayerIndexes = make([]int, 0)
+	for _, playerID := range playerIDs {
+		te.table.State.GamePlayerIndexes = append(te.table.State.GamePlayerIndexes, te.table.FindPlayerIdx(playerID))
+	}
+
+	// P1 folds preflop, P2 folds on the flop, P3 beats P4 at showdown
+	gs := &pokerlib.GameState{GameID: "game"}
+	gs.Status.Round = GameRound_River
+	gs.Players = []*pokerlib.PlayerState{
+		{Idx: 0, Fold: true, Combination: &pokerlib.CombinationInfo{}},
+		{Idx: 1, Fold: true, Combination: &pokerlib.CombinationInfo{}},
+		{Idx: 2, Combination: &pokerlib.CombinationInfo{Power: 200}},
+		{Idx: 3, Combination: &pokerlib.CombinationInfo{Power: 100}},
+	}
+	gs.Result = &settlement.Result{Players: []*settlement.PlayerResult{
+		{Idx: 0, Final: 980, Changed: -20},
+		{Idx: 1, Final: 940, Changed: -60},
+		{Idx: 2, Final: 1380, Changed: 380},
+		{Idx: 3, Final: 700, Changed: -300},
+	}}
+	te.table.State.GameState = gs
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].GameStatistics.FoldRound = GameRound_Preflop
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].GameStatistics.FoldRound = GameRound_Flop
+	for _, playerState := range te.table.State.PlayerStates {
+		playerState.StackAtHandStart = 1000
+	}
+
+	var settledGameCount int
+	var results []PlayerHandResult
+	te.OnGameSettled(func(gameCount int, handResults []PlayerHandResult) {
+		settledGameCount = gameCount
+		results = handResults
+	})
+	te.settleGame()
+
+	assert.Equal(t, te.table.State.GameCount, settledGameCount)
+	assert.Equal(t, []PlayerHandResult{
+		{PlayerID: "P1", GamePlayerIdx: 0, SawFlop: false, WentToShowdown: false, Won: false, Changed: -20, Net: -20},
+		{PlayerID: "P2", GamePlayerIdx: 1, SawFlop: true, WentToShowdown: false, Won: false, Changed: -60, Net: -60},
+		{PlayerID: "P3", GamePlayerIdx: 2, SawFlop: true, WentToShowdown: true, Won: true, Changed: 380, Net: 380},
+		{PlayerID: "P4", GamePlayerIdx: 3, SawFlop: true, WentToShowdown: true, Won: false, Changed: -300, Net: -300},
+	}, results)
+}
+
+func TestTableEngine_StackAtHandStart(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Bankroll = 800
+	te.UpdateBlind(1, 5, 0, 10, 20)
+	openTestNextGame(t, te)
+
+	bankrolls := map[string]int64{"P1": 1000, "P2": 800, "P3": 1000, "P4": 1000}
+	for _, playerState := range te.table.State.PlayerStates {
+		assert.Equal(t, bankrolls[playerState.PlayerID], playerState.StackAtHandStart, playerState.PlayerID)
+	}
+
+	// antes & blinds are posted from the stack the hand started with
+	te.lock.Lock()
+	defer te.lock.Unlock()
+	assert.NoError(t, te.startGame())
+	gs := te.game.GetGameState()
+	for _, p := range gs.Players {
+		playerState := te.table.State.PlayerStates[te.table.FindPlayerIndexFromGamePlayerIndex(p.Idx)]
+		assert.Equal(t, p.InitialStackSize, playerState.StackAtHandStart, playerState.PlayerID)
+		assert.Equal(t, bankrolls[playerState.PlayerID], playerState.StackAtHandStart, playerState.PlayerID)
+	}
+	te.game.Close()
+}
+
+func TestTableEngine_AdvanceRound(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.ManualRoundAdvance = true
+	te := newTestPlayingTableEngine(t, options)
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	assert.ErrorIs(t, te.AdvanceRound(), ErrTableNoRoundToAdvance)
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// everybody checks down, every closed round waits to be advanced
+	closedRounds := make([]string, 0)
+	boards := make([][]string, 0)
+	driveTestHand(t, te, checkDownTestWager(te), func(status TableStateStatus, gs *pokerlib.GameState) bool {
+		if gs == nil || gs.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundClosed] {
+			return status == TableStateStatus_TableGameStandby
+		}
+
+		closedRounds = append(closedRounds, gs.Status.Round)
+		boards = append(boards, gs.Status.Board)
+
+		// nothing is dealt until the round is advanced
+		time.Sleep(20 * time.Millisecond)
+		te.lock.Lock()
+		assert.Equal(t, gs.Status.Round, te.table.State.GameState.Status.Round)
+		assert.Equal(t, pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundClosed], te.table.State.GameState.Status.CurrentEvent)
+		te.lock.Unlock()
+
+		assert.NoError(t, te.AdvanceRound())
+		return false
+	})
+
+	assert.Equal(t, []string{GameRound_Preflop, GameRound_Flop, GameRound_Turn, GameRound_River}, closedRounds)
+	boardSizes := make([]int, 0)
+	for _, board := range boards {
+		boardSizes = append(boardSizes, len(board))
+	}
+	assert.Equal(t, []int{0, 3, 4, 5}, boardSizes)
+}
+
+func TestIsUnset(t *testing.T) {
+	// clients compare seats & indexes against -1
+	assert.Equal(t, -1, UnsetValue)
+	assert.True(t, IsUnset(UnsetValue))
+	assert.True(t, IsUnset(-1))
+	assert.False(t, IsUnset(0))
+
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	assert.True(t, IsUnset(te.table.FindPlayerIdx("P9")))
+	assert.True(t, IsUnset(te.table.FindGamePlayerIdx("P9")))
+	assert.True(t, IsUnset(te.table.FindPlayerIndexFromGamePlayerIndex(-1)))
+}
+
+func TestTableEngine_ActionWarning(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.ActionWarningSeconds = 1
+	te := newTestPlayingTableEngine(t, options)
+	te.table.Meta.ActionTime = 2
+	te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+
+	gs := &pokerlib.GameState{GameID: "game"}
+	gs.Status.Round = GameRound_Flop
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundStarted]
+	gs.Status.CurrentPlayer = 1
+	gs.Players = []*pokerlib.PlayerState{
+		{Idx: 0},
+		{Idx: 1, AllowedActions: []string{string(WagerAction_Fold), string(WagerAction_Check), string(WagerAction_Bet)}},
+	}
+	te.table.State.GameState = gs
+
+	warnings := make(chan string, 1)
+	var warnedAt time.Time
+	te.OnActionWarning(func(playerID string, secondsLeft int) {
+		assert.Equal(t, 1, secondsLeft)
+		warnedAt = time.Now()
+		warnings <- playerID
+	})
+
+	// fires a second before the 2 seconds deadline
+	startAt := time.Now()
+	te.updateCurrentActionEndAt(pokerlib.GameEvent_RoundStarted, gs)
+	select {
+	case playerID := <-warnings:
+		assert.Equal(t, "P2", playerID)
+		assert.InDelta(t, time.Second, warnedAt.Sub(startAt), float64(200*time.Millisecond))
+	case <-time.After(3 * time.Second):
+		t.Fatal("action warning is not fired")
+	}
+
+	// cancelled once the player acts
+	te.updateCurrentActionEndAt(pokerlib.GameEvent_RoundStarted, gs)
+	gs.Players[1].Acted = true
+	gs.Status.CurrentEvent = pokerlib.GameEventSymbols[pokerlib.GameEvent_RoundClosed]
+	te.updateCurrentActionEndAt(pokerlib.GameEvent_RoundClosed, gs)
+	select {
+	case <-warnings:
+		t.Fatal("action warning is fired after the player acted")
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
+func TestTableEngine_SettleGame_ChipConservation(t *testing.T) {
+	newSettlingTableEngine := func(finals ...int64) *tableEngine {
+		te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithChipConservationCheck())
+		te.table.State.GamePlayerIndexes = []int{te.table.FindPlayerIdx("P1"), te.table.FindPlayerIdx("P2")}
+		te.table.State.GameState = &pokerlib.GameState{
+			GameID:  "game",
+			Players: []*pokerlib.PlayerState{{Idx: 0, Fold: true}, {Idx: 1, Combination: &pokerlib.CombinationInfo{Power: 100}}},
+			Result: &settlement.Result{
+				Players: []*settlement.PlayerResult{{Idx: 0, Final: finals[0], Changed: finals[0] - 1000}, {Idx: 1, Final: finals[1], Changed: finals[1] - 1000}},
+			},
+		}
+		te.handChips = 2000
+		return te
+	}
+
+	// P2 wins P1's chips
+	te := newSettlingTableEngine(900, 1100)
+	te.settleGame()
+	select {
+	case tableErr := <-te.ErrorChannel():
+		t.Fatalf("unexpected error: %v", tableErr)
+	default:
+	}
+
+	// corrupted result, P2 wins more than P1 lost
+	te = newSettlingTableEngine(900, 1200)
+	te.settleGame()
+	tableErr := <-te.ErrorChannel()
+	assert.ErrorIs(t, tableErr, ErrChipConservationViolated)
+	assert.Contains(t, tableErr.Error(), "settled 2100 chips, 2000 before the hand")
+
+	// the backend rakes 20
+	te = newSettlingTableEngine(900, 1080)
+	te.settleGame()
+	select {
+	case tableErr := <-te.ErrorChannel():
+		t.Fatalf("unexpected error: %v", tableErr)
+	default:
+	}
+
+	// the final bankrolls are checked, the settlement hook creates 50 chips
+	te = newSettlingTableEngine(900, 1100)
+	te.settlementHook = func(result *settlement.Result, table *Table) error {
+		table.State.PlayerStates[table.FindPlayerIdx("P2")].Bankroll += 50
+		return nil
+	}
+	te.settleGame()
+	tableErr = <-te.ErrorChannel()
+	assert.ErrorIs(t, tableErr, ErrChipConservationViolated)
+	assert.Contains(t, tableErr.Error(), "settled 2050 chips, 2000 before the hand")
+
+	// insurance is paid by the table
+	te = newSettlingTableEngine(900, 1100)
+	te.table.State.Insurances = []*TableInsurance{{PlayerID: "P1", Amount: 10, Coverage: 100}}
+	te.settleGame()
+	select {
+	case tableErr := <-te.ErrorChannel():
+		t.Fatalf("unexpected error: %v", tableErr)
+	default:
+	}
+	assert.Equal(t, int64(990), te.table.State.PlayerStates[te.table.FindPlayerIdx("P1")].Bankroll)
+
+	// no-op unless enabled
+	te = newSettlingTableEngine(900, 1200)
+	te.chipConservationCheck = false
+	te.settleGame()
+	select {
+	case tableErr := <-te.ErrorChannel():
+		t.Fatalf("unexpected error: %v", tableErr)
+	default:
+	}
+}
+
+func TestTableEngine_PlayerAddOn(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+	te.table.Meta.AddOnLevel = 3
+	te.table.State.BlindState = &TableBlindState{Level: 3, SB: 10, BB: 20}
+
+	addOns := make(map[string]int64)
+	te.OnPlayerAddOn(func(playerID string, chips int64) {
+		addOns[playerID] += chips
+	})
+
+	// not before the break
+	assert.ErrorIs(t, te.PlayerAddOn(JoinPlayer{PlayerID: "P1", RedeemChips: 500}), ErrTableAddOnNotAllowed)
+
+	// the break after level 3
+	te.setBlindState(TableBlindState{Level: -1, SB: 10, BB: 20})
+	assert.NoError(t, te.PlayerAddOn(JoinPlayer{PlayerID: "P1", RedeemChips: 500}))
+	assert.ErrorIs(t, te.PlayerAddOn(JoinPlayer{PlayerID: "P1", RedeemChips: 0}), ErrTableInvalidRedeemChips)
+	assert.ErrorIs(t, te.PlayerAddOn(JoinPlayer{PlayerID: "P9", RedeemChips: 500}), ErrTablePlayerNotFound)
+	assert.Equal(t, map[string]int64{"P1": 500}, addOns)
+
+	// tracked apart from the buy-ins
+	stats, err := te.GetPlayerSessionStats("P1")
+	assert.NoError(t, err)
+	assert.Equal(t, SessionStats{PlayerID: "P1", Bankroll: 1500, BuyInTotal: 1000, AddOnCount: 1, AddOnTotal: 500, SessionNet: 0}, stats)
+
+	// not after the break nor during the next one
+	te.setBlindState(TableBlindState{Level: 4, SB: 20, BB: 40})
+	assert.ErrorIs(t, te.PlayerAddOn(JoinPlayer{PlayerID: "P2", RedeemChips: 500}), ErrTableAddOnNotAllowed)
+	te.setBlindState(TableBlindState{Level: -1, SB: 20, BB: 40})
+	assert.ErrorIs(t, te.PlayerAddOn(JoinPlayer{PlayerID: "P2", RedeemChips: 500}), ErrTableAddOnNotAllowed)
+	assert.Equal(t, map[string]int64{"P1": 500}, addOns)
+}
+
+func TestTableEngine_PlayerReEntry(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	te.table.State.Status = TableStateStatus_TableGameStandby
+	te.table.Meta.ReEntryEndLevel = 3
+
+	bust := func(playerID string) {
+		playerState := te.table.State.PlayerStates[te.table.FindPlayerIdx(playerID)]
+		playerState.Bankroll = 0
+		playerState.GameStatistics.ActionTimes = 5
+	}
+
+	// disabled by default
+	bust("P2")
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTableReEntryNotAllowed)
+
+	te.table.Meta.MaxReEntries = 2
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P1", RedeemChips: 1000, Seat: UnsetValue}), ErrTablePlayerNotEliminated)
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 0, Seat: UnsetValue}), ErrTableInvalidRedeemChips)
+
+	// P2 re-enters at a new seat with fresh chips & stats
+	assert.NoError(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: 8}))
+	p2 := te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")]
+	assert.Equal(t, 8, p2.Seat)
+	assert.Equal(t, int64(1000), p2.Bankroll)
+	assert.Equal(t, 1, p2.ReEntryCount)
+	assert.Equal(t, 0, p2.GameStatistics.ActionTimes)
+	assert.False(t, p2.IsIn)
+	assert.Len(t, te.table.State.PlayerStates, 4)
+	assert.Equal(t, te.table.FindPlayerIdx("P2"), te.table.State.SeatMap[8])
+	assert.True(t, IsUnset(te.table.State.SeatMap[2]))
+
+	// the second re-entry after leaving the table, then no re-entry is left
+	bust("P2")
+	assert.NoError(t, te.PlayersLeave([]string{"P2"}))
+	assert.NoError(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}))
+	assert.Equal(t, 2, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].ReEntryCount)
+
+	bust("P2")
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTableReEntryLimitReached)
+
+	// only eliminated players re-enter
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P9", RedeemChips: 1000, Seat: UnsetValue}), ErrTablePlayerNotEliminated)
+	assert.True(t, IsUnset(te.table.FindPlayerIdx("P9")))
+
+	// re-entry closes after ReEntryEndLevel
+	bust("P3")
+	te.setBlindState(TableBlindState{Level: 4, SB: 20, BB: 40})
+	assert.ErrorIs(t, te.PlayerReEntry(JoinPlayer{PlayerID: "P3", RedeemChips: 1000, Seat: UnsetValue}), ErrTableReEntryNotAllowed)
+}
+
+func TestTableEngine_PlayerReEntry_SharedLedger(t *testing.T) {
+	ledger := NewReEntryLedger()
+	newTable := func() *tableEngine {
+		te := newTestPlayingTableEngine(t, NewTableEngineOptions(), WithReEntryLedger(ledger))
+		te.table.State.Status = TableStateStatus_TableGameStandby
+		te.table.Meta.CompetitionID = "mtt"
+		te.table.Meta.MaxReEntries = 2
+		return te
+	}
+	table1, table2 := newTable(), newTable()
+
+	// P2 busts & re-enters at table 1
+	table1.table.State.PlayerStates[table1.table.FindPlayerIdx("P2")].Bankroll = 0
+	assert.NoError(t, table1.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}))
+
+	// rebalanced to table 2, the count follows the player
+	assert.NoError(t, table1.PlayersLeave([]string{"P2"}))
+	assert.NoError(t, table2.PlayersLeave([]string{"P2"}))
+	assert.ErrorIs(t, table2.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTablePlayerNotEliminated)
+	_, err := table2.UpdateTablePlayers([]JoinPlayer{{PlayerID: "P2", RedeemChips: 1000, Seat: 3}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, table2.table.State.PlayerStates[table2.table.FindPlayerIdx("P2")].ReEntryCount)
+
+	// busts at table 2, leaves & re-enters at table 1
+	table2.table.State.PlayerStates[table2.table.FindPlayerIdx("P2")].Bankroll = 0
+	assert.NoError(t, table2.PlayersLeave([]string{"P2"}))
+	assert.NoError(t, table1.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}))
+	assert.Equal(t, 2, table1.table.State.PlayerStates[table1.table.FindPlayerIdx("P2")].ReEntryCount)
+	assert.Equal(t, 2, ledger.Count("mtt", "P2"))
+
+	// no re-entry left on any table
+	table1.table.State.PlayerStates[table1.table.FindPlayerIdx("P2")].Bankroll = 0
+	assert.NoError(t, table1.PlayersLeave([]string{"P2"}))
+	assert.ErrorIs(t, table2.PlayerReEntry(JoinPlayer{PlayerID: "P2", RedeemChips: 1000, Seat: UnsetValue}), ErrTableReEntryLimitReached)
+}
+
+func TestTableEngine_IsWalkSituation(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	playerIDs := []string{"P3", "P4", "P1", "P2"}
+	te.table.State.GamePlayerIndexes = make([]int, 0)
+	for _, playerID := range playerIDs {
+		te.table.State.GamePlayerIndexes = append(te.table.State.GamePlayerIndexes, te.table.FindPlayerIdx(playerID))
+	}
+
+	_, isWalk := te.IsWalkSituation()
+	assert.False(t, isWalk)
+
+	gs := &pokerlib.GameState{GameID: "game"}
+	gs.Status.Round = GameRound_Preflop
+	gs.Players = []*pokerlib.PlayerState{
+		{Idx: 0, Positions: []string{Position_Dealer}},
+		{Idx: 1, Positions: []string{Position_SB}},
+		{Idx: 2, Positions: []string{Position_BB}},
+		{Idx: 3, Positions: []string{Position_UG}},
+	}
+	te.table.State.GameState = gs
+	te.table.State.Status = TableStateStatus_TableGamePlaying
+
+	// everybody folds to the BB one by one
+	for _, gamePlayerIdx := range []int{3, 0, 1} {
+		_, isWalk = te.IsWalkSituation()
+		assert.False(t, isWalk)
+		gs.Players[gamePlayerIdx].Fold = true
+	}
+	bbPlayerID, isWalk := te.IsWalkSituation()
+	assert.True(t, isWalk)
+	assert.Equal(t, "P1", bbPlayerID)
+
+	// the BB is left after the flop
+	gs.Status.Round = GameRound_Flop
+	_, isWalk = te.IsWalkSituation()
+	assert.False(t, isWalk)
+
+	// the BB folds
+	gs.Status.Round = GameRound_Preflop
+	gs.Players[2].Fold = true
+	gs.Players[3].Fold = false
+	_, isWalk = te.IsWalkSituation()
+	assert.False(t, isWalk)
+}
+
+// buttonDrawGameBackend deals the given cards to draw for the button
+type buttonDrawGameBackend struct {
+	*NativeGameBackend
+	cards map[string]string
+}
+
+func (b *buttonDrawGameBackend) DealForButton(playerIDs []string) (map[string]string, error) {
+	return b.cards, nil
+}
+
+func TestTableEngine_InitialButtonMode(t *testing.T) {
+	newTestTableEngine := func(mode string, opts ...TableEngineOpt) *tableEngine {
+		te := NewTableEngine(NewTableEngineOptions(), append([]TableEngineOpt{WithGameBackend(NewNativeGameBackend())}, opts...)...).(*tableEngine)
+		setting := newTestTableSetting()
+		setting.Meta.InitialButtonMode = mode
+		_, err := te.CreateTable(setting)
+		assert.NoError(t, err)
+
+		for playerID, seat := range map[string]int{"P1": 0, "P2": 2, "P3": 4, "P4": 6} {
+			assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+			assert.NoError(t, te.PlayerJoin(playerID))
+		}
+		return te
+	}
+
+	t.Run(InitialButtonMode_Random, func(t *testing.T) {
+		te := newTestTableEngine(InitialButtonMode_Random)
+		openTestNextGame(t, te)
+		assert.Contains(t, []int{0, 2, 4, 6}, te.sm.CurrentDealerSeatID())
+		assert.Equal(t, (te.sm.CurrentDealerSeatID()+2)%8, te.sm.CurrentSBSeatID())
+		assert.Equal(t, (te.sm.CurrentDealerSeatID()+4)%8, te.sm.CurrentBBSeatID())
+	})
+
+	t.Run(InitialButtonMode_FixedSeat, func(t *testing.T) {
+		// the first occupied seat is the bb
+		te := newTestTableEngine(InitialButtonMode_FixedSeat)
+		openTestNextGame(t, te)
+		assert.Equal(t, 4, te.sm.CurrentDealerSeatID())
+		assert.Equal(t, 6, te.sm.CurrentSBSeatID())
+		assert.Equal(t, 0, te.sm.CurrentBBSeatID())
+	})
+
+	t.Run(InitialButtonMode_HighCard, func(t *testing.T) {
+		// P2 & P3 draw aces, the spade beats the club
+		te := newTestTableEngine(InitialButtonMode_HighCard, WithGameBackend(&buttonDrawGameBackend{
+			NativeGameBackend: NewNativeGameBackend(),
+			cards:             map[string]string{"P1": "HK", "P2": "SA", "P3": "CA", "P4": "D2"},
+		}))
+		openTestNextGame(t, te)
+		assert.Equal(t, 2, te.sm.CurrentDealerSeatID())
+		assert.Equal(t, 4, te.sm.CurrentSBSeatID())
+		assert.Equal(t, 6, te.sm.CurrentBBSeatID())
+		assert.ElementsMatch(t, []string{Position_Dealer}, te.table.State.PlayerStates[te.table.FindPlayerIdx("P2")].Positions)
+	})
+
+	t.Run("high_card_invalid_cards", func(t *testing.T) {
+		te := newTestTableEngine(InitialButtonMode_HighCard, WithGameBackend(&buttonDrawGameBackend{
+			NativeGameBackend: NewNativeGameBackend(),
+			cards:             map[string]string{"P1": "HK", "P2": "CA", "P3": "SA"},
+		}))
+		_, err := te.openGame(te.table)
+		assert.ErrorContains(t, err, ErrTableInvalidButtonCards.Error())
+		assert.False(t, te.sm.IsInitPositions())
+	})
+}
+
+func TestNativeGameBackend_DealForButton(t *testing.T) {
+	playerIDs := []string{"P1", "P2", "P3", "P4"}
+	cards, err := NewNativeGameBackend().DealForButton(playerIDs)
+	assert.NoError(t, err)
+	assert.Len(t, cards, len(playerIDs))
+
+	dealt := make(map[string]bool)
+	for _, playerID := range playerIDs {
+		assert.False(t, IsUnset(buttonCardRank(cards[playerID])), cards[playerID])
+		dealt[cards[playerID]] = true
+	}
+	assert.Len(t, dealt, len(playerIDs))
+}
+
+func TestTableEngine_GetTableSnapshot(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// a reader polls the table while the hand plays (run with -race)
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			table, err := te.GetTableSnapshot()
+			assert.NoError(t, err)
+			for _, playerState := range table.State.PlayerStates {
+				_ = playerState.Bankroll
+			}
+			if table.State.GameState != nil {
+				_ = table.State.GameState.Status.CurrentPlayer
+			}
+		}
+	}()
+
+	// everybody folds to the BB
+	driveTestHand(t, te, foldToBBTestWager(te), nil)
+	close(done)
+	<-readerDone
+
+	// a copy, the table can't be changed through it
+	table, err := te.GetTableSnapshot()
+	assert.NoError(t, err)
+	table.State.PlayerStates[0].Bankroll = 0
+	assert.NotEqual(t, int64(0), te.table.State.PlayerStates[0].Bankroll)
+}
+
+func TestTableEngine_GameStateCallbacksDeliveredAfterUnlock(t *testing.T) {
+	te := newTestPlayingTableEngine(t, NewTableEngineOptions())
+
+	var mu sync.Mutex
+	delivered := make([]*Table, 0)
+	te.OnTableUpdated(func(table *Table) {
+		// a locking method called from the callback doesn't deadlock
+		assert.NoError(t, te.WithReadSnapshot(func(*Table) {}))
+
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, table)
+	})
+	countDelivered := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(delivered)
+	}
+
+	te.lock.LockDeferringEmits()
+	te.emitEvent("GameUpdated", "")
+	serial := te.table.UpdateSerial
+	assert.Zero(t, countDelivered())
+	te.lock.Unlock()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, delivered, 1) {
+		// a copy of the table as of the update
+		assert.NotSame(t, te.table, delivered[0])
+		assert.Equal(t, serial, delivered[0].UpdateSerial)
+	}
+}
+
+func TestTableEngine_CoalescedEvents(t *testing.T) {
+	testCases := []struct {
+		name               string
+		opts               []TableEngineOpt
+		joinTimeoutUpdates int
+		isLiveTable        bool
+	}{
+		{name: "default", joinTimeoutUpdates: 3, isLiveTable: true},
+		{name: "coalesced", opts: []TableEngineOpt{WithCoalescedEvents()}, joinTimeoutUpdates: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			te := NewTableEngine(NewTableEngineOptions(), append([]TableEngineOpt{WithGameBackend(NewNativeGameBackend())}, tc.opts...)...).(*tableEngine)
+			_, err := te.CreateTable(newTestTableSetting())
+			assert.NoError(t, err)
+
+			var mu sync.Mutex
+			serials := make([]int, 0)
+			liveTables := 0
+			te.OnTableUpdated(func(table *Table) {
+				mu.Lock()
+				defer mu.Unlock()
+				serials = append(serials, table.UpdateSerial)
+				if table == te.table {
+					liveTables++
+				}
+			})
+			countUpdates := func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(serials)
+			}
+
+			// a single update either way
+			for playerID, seat := range map[string]int{"P1": 0, "P2": 2, "P3": 4} {
+				updates := countUpdates()
+				assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+				assert.Equal(t, updates+1, countUpdates(), playerID)
+			}
+
+			// the join timer is up, every player who hasn't joined is seated at once
+			updates := countUpdates()
+			te.autoReadyJoinPlayers(te.rg)
+			assert.Eventually(t, func() bool {
+				return countUpdates() == updates+tc.joinTimeoutUpdates
+			}, time.Second, 10*time.Millisecond)
+
+			te.lock.Lock()
+			for _, playerState := range te.table.State.PlayerStates {
+				assert.True(t, playerState.IsIn, playerState.PlayerID)
+			}
+			te.lock.Unlock()
+			assert.Equal(t, updates+tc.joinTimeoutUpdates, countUpdates())
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i := 1; i < len(serials); i++ {
+				assert.Greater(t, serials[i], serials[i-1])
+			}
+
+			// updates held back under the lock are delivered once it is released, with a copy of the table
+			if tc.isLiveTable {
+				assert.Equal(t, len(serials), liveTables)
+			} else {
+				assert.Zero(t, liveTables)
+			}
+		})
+	}
+}
+
+// slowGameBackend takes the given time to fold, and to deal the next round for the first slowNextCalls calls
+type slowGameBackend struct {
+	*NativeGameBackend
+	foldDelay     time.Duration
+	nextDelay     time.Duration
+	slowNextCalls atomic.Int32
+}
+
+func (b *slowGameBackend) Fold(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	time.Sleep(b.foldDelay)
+	return b.NativeGameBackend.Fold(gs)
+}
+
+func (b *slowGameBackend) Next(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	if b.slowNextCalls.Add(-1) >= 0 {
+		time.Sleep(b.nextDelay)
+	}
+	return b.NativeGameBackend.Next(gs)
+}
+
+// contextGameBackend folds once its context is done & reports the context error to cancelled
+type contextGameBackend struct {
+	*NativeGameBackend
+	ctx       context.Context
+	cancelled chan error
+}
+
+func (b *contextGameBackend) WithContext(ctx context.Context) GameBackend {
+	return &contextGameBackend{NativeGameBackend: b.NativeGameBackend, ctx: ctx, cancelled: b.cancelled}
+}
+
+func (b *contextGameBackend) Fold(gs *pokerlib.GameState) (*pokerlib.GameState, error) {
+	<-b.ctx.Done()
+	b.cancelled <- b.ctx.Err()
+	return nil, b.ctx.Err()
+}
+
+func TestTableEngine_BackendCallTimeout(t *testing.T) {
+	options := NewTableEngineOptions()
+	options.BackendCallTimeout = 50
+	te := newTestPlayingTableEngine(t, options, WithGameBackend(&slowGameBackend{NativeGameBackend: NewNativeGameBackend(), foldDelay: time.Second}))
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// everybody is ready & the blinds are paid, up to the first wager
+	var actorPlayerID string
+	driveTestHand(t, te, func(playerID string, gs *pokerlib.GameState) {
+		if gs.HasAction(gs.Status.CurrentPlayer, string(WagerAction_Fold)) {
+			actorPlayerID = playerID
+		}
+	}, func(status TableStateStatus, gs *pokerlib.GameState) bool {
+		return actorPlayerID != ""
+	})
+
+	// the fold is abandoned instead of holding the table
+	startAt := time.Now()
+	err := te.PlayerFold(actorPlayerID)
+	assert.ErrorIs(t, err, ErrBackendTimeout)
+	assert.Less(t, time.Since(startAt), 500*time.Millisecond)
+
+	// the hand goes on, the player may act again
+	assert.True(t, te.IsPlayerTurn(actorPlayerID))
+	assert.NoError(t, te.PlayerCall(actorPlayerID))
+
+	// no limit by default
+	gb := NewNativeGameBackend()
+	assert.Equal(t, GameBackend(gb), withBackendCallTimeout(gb, 0))
+}
+
+func TestTableEngine_BackendCallTimeout_Context(t *testing.T) {
+	cancelled := make(chan error, 1)
+	gb := withBackendCallTimeout(&contextGameBackend{NativeGameBackend: NewNativeGameBackend(), cancelled: cancelled}, 20*time.Millisecond)
+
+	_, err := gb.Fold(nil)
+	assert.ErrorIs(t, err, ErrBackendTimeout)
+
+	// the backend sees the call is abandoned
+	select {
+	case err := <-cancelled:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("abandoned call is not cancelled")
+	}
+}
+
+func TestTableEngine_BackendCallTimeout_AutomaticTransition(t *testing.T) {
+	testCases := []struct {
+		name          string
+		slowNextCalls int32
+		misdealt      bool
+	}{
+		{name: "retried", slowNextCalls: maxBackendTimeoutRetries, misdealt: false},
+		{name: "hand dealt again", slowNextCalls: maxBackendTimeoutRetries + 1, misdealt: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := NewTableEngineOptions()
+			options.BackendCallTimeout = 50
+			gb := &slowGameBackend{NativeGameBackend: NewNativeGameBackend(), nextDelay: 200 * time.Millisecond}
+			gb.slowNextCalls.Store(tc.slowNextCalls)
+			te := newTestPlayingTableEngine(t, options, WithGameBackend(gb))
+			openTestNextGame(t, te)
+			te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+			misdeals := make(chan string, 8)
+			te.OnMisdeal(func(gameCount int, reason string) {
+				misdeals <- reason
+			})
+
+			te.lock.Lock()
+			assert.NoError(t, te.startGame())
+			te.lock.Unlock()
+
+			// the hand is played to the end either way
+			driveTestHand(t, te, checkDownTestWager(te), nil)
+
+			if !tc.misdealt {
+				assert.Len(t, misdeals, 0)
+				return
+			}
+
+			assert.Len(t, misdeals, 1)
+			assert.Contains(t, <-misdeals, ErrBackendTimeout.Error())
+
+			tableErr := <-te.ErrorChannel()
+			assert.ErrorIs(t, tableErr, ErrBackendTimeout)
+			assert.Equal(t, TableErrorSeverity_Recoverable, tableErr.Severity)
+		})
+	}
+}
+
+func TestTableEngine_WithReadSnapshot(t *testing.T) {
+	te := NewTableEngine(NewTableEngineOptions()).(*tableEngine)
+	assert.ErrorIs(t, te.WithReadSnapshot(func(t *Table) {}), ErrTableNotCreated)
+
+	te = newTestPlayingTableEngine(t, NewTableEngineOptions())
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// analytics read the table while the hand plays (run with -race)
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+	reads := 0
+	go func() {
+		defer close(readerDone)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			assert.NoError(t, te.WithReadSnapshot(func(table *Table) {
+				reads++
+				for _, playerIdx := range table.State.GamePlayerIndexes {
+					assert.Less(t, playerIdx, len(table.State.PlayerStates))
+				}
+			}))
+		}
+	}()
+
+	// everybody folds to the BB
+	driveTestHand(t, te, foldToBBTestWager(te), nil)
+	close(done)
+	<-readerDone
+	assert.Greater(t, reads, 0)
+
+	// a copy, the table can't be changed through it
+	assert.NoError(t, te.WithReadSnapshot(func(table *Table) {
+		table.State.PlayerStates[0].Bankroll = 0
+	}))
+	assert.NotEqual(t, int64(0), te.table.State.PlayerStates[0].Bankroll)
+}
+
+func TestTableEngine_HoleCardsCount(t *testing.T) {
+	tableSetting := newTestTableSetting()
+	tableSetting.Meta.HoleCardsCount = 5
+
+	// 5 hole cards for 9 seats, the board & the burns need 53 cards
+	te := NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err := te.CreateTable(tableSetting)
+	assert.ErrorIs(t, err, ErrTableInvalidCreateSetting)
+
+	tableSetting.Meta.RequiredHoleCardsCount = 6
+	tableSetting.Meta.TableMaxSeatCount = 8
+	assert.ErrorIs(t, tableSetting.Validate(), ErrTableInvalidCreateSetting)
+
+	// 5-card Omaha as a custom game on the standard deck
+	tableSetting.Meta.RequiredHoleCardsCount = 2
+	te = NewTableEngine(NewTableEngineOptions(), WithGameBackend(NewNativeGameBackend())).(*tableEngine)
+	_, err = te.CreateTable(tableSetting)
+	assert.NoError(t, err)
+
+	for playerID, seat := range map[string]int{"P1": 0, "P2": 2, "P3": 4, "P4": 6} {
+		assert.NoError(t, te.PlayerReserve(JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: seat}))
+		assert.NoError(t, te.PlayerJoin(playerID))
+	}
+	assert.NoError(t, te.sm.InitPositions(false))
+	openTestNextGame(t, te)
+	te.table.State.StartAt = time.Now().Unix() // far from MaxDuration
+
+	results := make(chan []PlayerHandResult, 1)
+	te.OnGameSettled(func(gameCount int, handResults []PlayerHandResult) {
+		results <- handResults
+	})
+
+	te.lock.Lock()
+	assert.NoError(t, te.startGame())
+	te.lock.Unlock()
+
+	// everybody checks down to the showdown
+	holeCards := make(map[int]int)
+	driveTestHand(t, te, checkDownTestWager(te), func(status TableStateStatus, gs *pokerlib.GameState) bool {
+		if gs != nil {
+			assert.Equal(t, 5, gs.Meta.HoleCardsCount)
+			assert.Equal(t, 2, gs.Meta.RequiredHoleCardsCount)
+			for _, p := range gs.Players {
+				if len(p.HoleCards) > 0 {
+					holeCards[p.Idx] = len(p.HoleCards)
+				}
+			}
+		}
+		return len(results) > 0
+	})
+
+	handResults := <-results
+	assert.Len(t, handResults, 4)
+	for _, result := range handResults {
+		assert.True(t, result.WentToShowdown)
+	}
+	assert.Equal(t, map[int]int{0: 5, 1: 5, 2: 5, 3: 5}, holeCards)
+}
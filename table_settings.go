@@ -1,9 +1,26 @@
 package pokertable
 
+import (
+	"fmt"
+
+	"github.com/d-protocol/pokerlib"
+)
+
+/*
+RuleMaxSeats is the most seats a table of the rule may have, key: CompetitionRule_*
+  - Operators may adjust it before creating tables, rules left out have no limit
+*/
+var RuleMaxSeats = map[string]int{
+	CompetitionRule_Default:   10,
+	CompetitionRule_ShortDeck: 6,
+	CompetitionRule_Omaha:     10,
+}
+
 type TablePlayerGameAction struct {
 	CompetitionID    string   `json:"competition_id"`
 	TableID          string   `json:"table_id"`
 	GameID           string   `json:"game_id"`
+	GlobalHandID     string   `json:"global_hand_id"`
 	GameCount        int      `json:"game_count"`
 	UpdateAt         int64    `json:"update_at"`
 	PlayerID         string   `json:"player_id"`
@@ -17,13 +34,111 @@ type TablePlayerGameAction struct {
 	StackSize        int64    `json:"stack_size"`
 	Pot              int64    `json:"pot"`
 	Wager            int64    `json:"wager"`
+	Sequence         int64    `json:"sequence"`  // Monotonic per table (never resets between hands), orders actions within the same second
+	IsForced         bool     `json:"is_forced"` // Ante or blind posted by the game, not decided by the player (even when it puts the player all-in)
+}
+
+type ActionRequired struct {
+	GameCount      int      `json:"game_count"`
+	PlayerID       string   `json:"player_id"`
+	Round          string   `json:"round"`
+	AllowedActions []string `json:"allowed_actions"`
+	ActionEndAt    int64    `json:"action_end_at"`    // Unix timestamp the player times out
+	Pot            int64    `json:"pot"`              // Chips in the middle, including the wagers of the round
+	CallAmount     int64    `json:"call_amount"`      // Chips needed to call, capped at the player's stack, 0 if the player may check
+	PotOdds        float64  `json:"pot_odds"`         // CallAmount / (Pot + CallAmount), 0 if there is nothing to call
+	Equity         *float64 `json:"equity,omitempty"` // Player's share of the pot over every runout, see TableEngineOptions.ActionRequiredEquity
 }
 
 type TableSetting struct {
-	TableID     string          `json:"table_id"`
-	Meta        TableMeta       `json:"table_meta"`
-	JoinPlayers []JoinPlayer    `json:"join_players"`
-	Blind       TableBlindState `json:"blind"`
+	TableID     string            `json:"table_id"`
+	Meta        TableMeta         `json:"table_meta"`
+	JoinPlayers []JoinPlayer      `json:"join_players"`
+	Blind       TableBlindState   `json:"blind"`
+	BlindLevels []TableBlindState `json:"blind_levels"` // Upcoming blind levels, switched in order when the current level's EndTime is reached
+}
+
+/*
+Validate checks the table setting before a table is created
+  - Join players must fit in the seats
+  - Seats must not exceed the limit of the rule, see RuleMaxSeats
+  - Hole cards of every seat and the board must fit in the deck of the rule, see TableMeta.HoleCardsCount
+  - Join players must redeem chips, see InvalidRedeemChipsError
+*/
+func (ts TableSetting) Validate() error {
+	if len(ts.JoinPlayers) > ts.Meta.TableMaxSeatCount {
+		return fmt.Errorf("%w: %d join players exceed %d seats", ErrTableInvalidCreateSetting, len(ts.JoinPlayers), ts.Meta.TableMaxSeatCount)
+	}
+
+	if maxSeats, exist := RuleMaxSeats[ts.Meta.Rule]; exist && ts.Meta.TableMaxSeatCount > maxSeats {
+		return fmt.Errorf("%w: %s tables have at most %d seats, got %d", ErrTableInvalidCreateSetting, ts.Meta.Rule, maxSeats, ts.Meta.TableMaxSeatCount)
+	}
+
+	if err := ts.Meta.validateHoleCards(); err != nil {
+		return err
+	}
+	return validateRedeemChips(ts.JoinPlayers)
+}
+
+// holeCardsCounts returns the hole cards dealt and required by the table, the table meta overrides take precedence over the rule defaults
+func (tm TableMeta) holeCardsCounts() (holeCardsCount int, requiredHoleCardsCount int) {
+	holeCardsCount, requiredHoleCardsCount = 2, 0
+	if tm.Rule == CompetitionRule_Omaha {
+		holeCardsCount, requiredHoleCardsCount = 4, 2
+	}
+
+	if tm.HoleCardsCount > 0 {
+		holeCardsCount = tm.HoleCardsCount
+	}
+	if tm.RequiredHoleCardsCount > 0 {
+		requiredHoleCardsCount = tm.RequiredHoleCardsCount
+	}
+	return holeCardsCount, requiredHoleCardsCount
+}
+
+// validateHoleCards rejects hole cards a full table can't be dealt, 5 board cards and a burn before each street are dealt from the same deck
+func (tm TableMeta) validateHoleCards() error {
+	if tm.HoleCardsCount < 0 || tm.RequiredHoleCardsCount < 0 {
+		return fmt.Errorf("%w: negative hole cards count", ErrTableInvalidCreateSetting)
+	}
+
+	holeCardsCount, requiredHoleCardsCount := tm.holeCardsCounts()
+	if requiredHoleCardsCount > holeCardsCount || requiredHoleCardsCount > 5 {
+		return fmt.Errorf("%w: %d required hole cards out of %d", ErrTableInvalidCreateSetting, requiredHoleCardsCount, holeCardsCount)
+	}
+
+	deckSize := len(pokerlib.NewStandardDeckCards())
+	if tm.Rule == CompetitionRule_ShortDeck {
+		deckSize = len(pokerlib.NewShortDeckCards())
+	}
+	if dealt := holeCardsCount*tm.TableMaxSeatCount + 5 + 3; dealt > deckSize {
+		return fmt.Errorf("%w: %d hole cards for %d seats need %d cards, the deck has %d", ErrTableInvalidCreateSetting, holeCardsCount, tm.TableMaxSeatCount, dealt, deckSize)
+	}
+	return nil
+}
+
+// InvalidRedeemChipsError reports the join player without positive redeem chips, it unwraps to ErrTableInvalidRedeemChips
+type InvalidRedeemChipsError struct {
+	PlayerID    string
+	RedeemChips int64
+}
+
+func (e *InvalidRedeemChipsError) Error() string {
+	return fmt.Sprintf("%s: player %s redeems %d chips", ErrTableInvalidRedeemChips.Error(), e.PlayerID, e.RedeemChips)
+}
+
+func (e *InvalidRedeemChipsError) Unwrap() error {
+	return ErrTableInvalidRedeemChips
+}
+
+// validateRedeemChips rejects the first join player who wouldn't have chips to play with
+func validateRedeemChips(players []JoinPlayer) error {
+	for _, player := range players {
+		if player.RedeemChips <= 0 {
+			return &InvalidRedeemChipsError{PlayerID: player.PlayerID, RedeemChips: player.RedeemChips}
+		}
+	}
+	return nil
 }
 
 type JoinPlayer struct {
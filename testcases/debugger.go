@@ -29,15 +29,15 @@ func DebugPrintTableGameOpened(t pokertable.Table) {
 	fmt.Println("[Table Players]")
 	for _, player := range t.State.PlayerStates {
 		seat := "X"
-		if player.Seat != -1 {
+		if !pokertable.IsUnset(player.Seat) {
 			seat = strconv.Itoa(player.Seat)
 		}
 		fmt.Printf("seat: %s [%v], in: %s, participated: %s, player: %s\n", seat, player.Positions, boolToString(player.IsIn), boolToString(player.IsParticipated), player.PlayerID)
 	}
 
-	if t.State.CurrentDealerSeat != -1 {
+	if !pokertable.IsUnset(t.State.CurrentDealerSeat) {
 		dealerPlayerIndex := t.State.SeatMap[t.State.CurrentDealerSeat]
-		if dealerPlayerIndex == -1 {
+		if pokertable.IsUnset(dealerPlayerIndex) {
 			fmt.Println("[Table Current Dealer] X")
 		} else {
 			fmt.Println("[Table Current Dealer] ", t.State.PlayerStates[dealerPlayerIndex].PlayerID)
@@ -46,9 +46,9 @@ func DebugPrintTableGameOpened(t pokertable.Table) {
 		fmt.Println("[Table Current Dealer] X")
 	}
 
-	if t.State.CurrentBBSeat != -1 {
+	if !pokertable.IsUnset(t.State.CurrentBBSeat) {
 		bbPlayerIndex := t.State.SeatMap[t.State.CurrentBBSeat]
-		if bbPlayerIndex == -1 {
+		if pokertable.IsUnset(bbPlayerIndex) {
 			fmt.Println("[Table Current BB] X")
 		} else {
 			fmt.Println("[Table Current BB] ", t.State.PlayerStates[bbPlayerIndex].PlayerID)
@@ -62,7 +62,7 @@ func DebugPrintTableGameOpened(t pokertable.Table) {
 		playerID := "X"
 		positions := []string{"Unknown"}
 		bankroll := "X"
-		if playerIndex != -1 {
+		if !pokertable.IsUnset(playerIndex) {
 			playerID = t.State.PlayerStates[playerIndex].PlayerID
 			positions = t.State.PlayerStates[playerIndex].Positions
 			bankroll = fmt.Sprintf("%d", t.State.PlayerStates[playerIndex].Bankroll)
@@ -86,7 +86,7 @@ func DebugPrintTableGameOpened(t pokertable.Table) {
 	for _, playerIdx := range t.State.GamePlayerIndexes {
 		player := t.State.PlayerStates[playerIdx]
 		seat := "X"
-		if player.Seat != -1 {
+		if !pokertable.IsUnset(player.Seat) {
 			seat = strconv.Itoa(player.Seat)
 		}
 		fmt.Printf("seat: %s [%v], player: %s, bankroll: %d\n", seat, player.Positions, player.PlayerID, player.Bankroll)
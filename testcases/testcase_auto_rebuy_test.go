@@ -0,0 +1,150 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_AutoRebuy(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	targetStack := int64(1000)
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: targetStack,
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Mode = pokertable.CompetitionMode_Cash
+	tableSetting.Meta.MaxDuration = 600
+	tableSetting.Meta.AutoRebuyToStack = targetStack
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var once sync.Once
+	var mu sync.Mutex
+	settledBankrolls := make(map[string]int64)
+	nextHandBankrolls := make(map[string]int64)
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			// stop after checking the second hand
+			if table.State.GameCount != 1 {
+				if table.State.GameCount == 2 {
+					mu.Lock()
+					for _, player := range table.State.PlayerStates {
+						nextHandBankrolls[player.PlayerID] = player.Bankroll
+					}
+					mu.Unlock()
+					once.Do(wg.Done)
+				}
+				return
+			}
+
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				} else if funk.Contains(actions, "pass") {
+					assert.Nil(t, tableEngine.PlayerPass(playerID), fmt.Sprintf("%s pass error", playerID))
+				}
+			}
+		case pokertable.TableStateStatus_TableGameSettled:
+			if table.State.GameCount != 1 || table.State.GameState.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_GameClosed] {
+				return
+			}
+
+			mu.Lock()
+			for _, player := range table.State.PlayerStates {
+				settledBankrolls[player.PlayerID] = player.Bankroll
+			}
+			mu.Unlock()
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in & turn on auto-rebuy
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, tableEngine.PlayerSetAutoRebuy(joinPlayer.PlayerID, true), fmt.Sprintf("%s set auto rebuy error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// someone lost chips in the first hand and is topped back up, winners keep their stack
+	lostChips := false
+	for _, playerID := range playerIDs {
+		settled := settledBankrolls[playerID]
+		if settled < targetStack {
+			lostChips = true
+			assert.Equal(t, targetStack, nextHandBankrolls[playerID], fmt.Sprintf("%s is not topped up", playerID))
+		} else {
+			assert.Equal(t, settled, nextHandBankrolls[playerID])
+		}
+	}
+	assert.True(t, lostChips)
+}
@@ -0,0 +1,74 @@
+package testcases
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_BlindLevel_AutoAdvance(t *testing.T) {
+	var mu sync.Mutex
+	levels := make([]int, 0)
+	callbacks := pokertable.NewTableEngineCallbacks()
+	callbacks.OnTableStateUpdated = func(event string, table *pokertable.Table) {
+		if event == pokertable.TableStateEvent_BlindUpdated {
+			mu.Lock()
+			defer mu.Unlock()
+			levels = append(levels, table.State.BlindState.Level)
+		}
+	}
+
+	now := time.Now().Unix()
+	setting := NewDefaultTableSetting()
+	setting.Blind.EndTime = now + 1
+	setting.BlindLevels = []pokertable.TableBlindState{
+		{Level: 2, SB: 20, BB: 40, EndTime: now + 2},
+		{Level: -1}, // break
+	}
+
+	manager := pokertable.NewManager()
+	_, err := manager.CreateTable(nil, callbacks, setting)
+	assert.Nil(t, err, "create table failed")
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(levels) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int{2, -1}, levels)
+}
+
+func TestTable_BlindLevel_CancelledOnClose(t *testing.T) {
+	var mu sync.Mutex
+	blindUpdatedCount := 0
+	callbacks := pokertable.NewTableEngineCallbacks()
+	callbacks.OnTableStateUpdated = func(event string, table *pokertable.Table) {
+		if event == pokertable.TableStateEvent_BlindUpdated {
+			mu.Lock()
+			defer mu.Unlock()
+			blindUpdatedCount++
+		}
+	}
+
+	setting := NewDefaultTableSetting()
+	setting.Blind.EndTime = time.Now().Unix() + 1
+	setting.BlindLevels = []pokertable.TableBlindState{
+		{Level: 2, SB: 20, BB: 40},
+	}
+
+	manager := pokertable.NewManager()
+	table, err := manager.CreateTable(nil, callbacks, setting)
+	assert.Nil(t, err, "create table failed")
+	assert.Nil(t, manager.CloseTable(table.ID))
+
+	time.Sleep(1500 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 0, blindUpdatedCount)
+}
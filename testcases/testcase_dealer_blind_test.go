@@ -0,0 +1,188 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_DealerBlind(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck", "Lottie"}
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: 1000,
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Blind.Dealer = 20
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var once sync.Once
+	var done int32
+	var mu sync.Mutex
+	dealerPlayerID := ""
+	sbPlayerID := ""
+	bbPlayerID := ""
+	preflopActors := make([]string, 0)
+	blindActions := make(map[string]int64)
+	bankrolls := make(map[string]int64)
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		// only the first hand is checked
+		if atomic.LoadInt32(&done) == 1 || table.State.GameCount != 1 {
+			return
+		}
+
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				mu.Lock()
+				dealerPlayerID = findPlayerID(table, "dealer")
+				sbPlayerID = findPlayerID(table, "sb")
+				bbPlayerID = findPlayerID(table, "bb")
+				mu.Unlock()
+				assert.Nil(t, tableEngine.PlayerPay(dealerPlayerID, blind.Dealer), fmt.Sprintf("%s pay dealer blind error", dealerPlayerID))
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+
+				mu.Lock()
+				isDealer := playerID == dealerPlayerID
+				isFirstActor := false
+				if table.State.GameState.Status.Round == pokertable.GameRound_Preflop {
+					if len(blindActions) == 0 {
+						for gamePlayerIdx, playerIdx := range table.State.GamePlayerIndexes {
+							blindActions[table.State.PlayerStates[playerIdx].PlayerID] = table.State.GameState.GetPlayer(gamePlayerIdx).Wager
+						}
+					}
+					isFirstActor = len(preflopActors) == 0
+					preflopActors = append(preflopActors, playerID)
+				}
+				mu.Unlock()
+
+				// utg raises and the dealer gives up the posted blind
+				if table.State.GameState.Status.Round == pokertable.GameRound_Preflop {
+					if isFirstActor {
+						assert.Nil(t, tableEngine.PlayerRaise(playerID, 60), fmt.Sprintf("%s raise error", playerID))
+						return
+					}
+
+					if isDealer {
+						assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+						return
+					}
+				}
+
+				if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				} else if funk.Contains(actions, "pass") {
+					assert.Nil(t, tableEngine.PlayerPass(playerID), fmt.Sprintf("%s pass error", playerID))
+				}
+			}
+		case pokertable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_GameClosed] {
+				return
+			}
+
+			mu.Lock()
+			for _, player := range table.State.PlayerStates {
+				bankrolls[player.PlayerID] = player.Bankroll
+			}
+			mu.Unlock()
+			atomic.StoreInt32(&done, 1)
+			once.Do(wg.Done)
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, int64(20), tableEngine.GetTable().State.GameBlindState.Dealer)
+
+	// all three blinds are posted
+	assert.Equal(t, int64(20), blindActions[dealerPlayerID])
+	assert.Equal(t, int64(10), blindActions[sbPlayerID])
+	assert.Equal(t, int64(20), blindActions[bbPlayerID])
+
+	// preflop action starts after the bb, the dealer acts in turn
+	utgPlayerID := ""
+	for _, playerID := range playerIDs {
+		if playerID != dealerPlayerID && playerID != sbPlayerID && playerID != bbPlayerID {
+			utgPlayerID = playerID
+		}
+	}
+	assert.Equal(t, []string{utgPlayerID, dealerPlayerID, sbPlayerID, bbPlayerID}, preflopActors)
+
+	// the folded dealer blind stays in the pot
+	assert.Equal(t, int64(980), bankrolls[dealerPlayerID])
+	total := int64(0)
+	for _, bankroll := range bankrolls {
+		total += bankroll
+	}
+	assert.Equal(t, int64(4000), total)
+}
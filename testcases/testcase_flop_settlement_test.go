@@ -135,7 +135,7 @@ func TestTableGame_Flop_Settlement(t *testing.T) {
 
 	// Start game
 	time.Sleep(time.Microsecond * 100)
-	err = tableEngine.StartTableGame()
+	err = tableEngine.StartTableGameIfNotStarted()
 	assert.Nil(t, err)
 
 	wg.Wait()
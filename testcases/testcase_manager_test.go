@@ -0,0 +1,171 @@
+package testcases
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_TableLifecycle(t *testing.T) {
+	manager := pokertable.NewManager()
+	assert.Empty(t, manager.GetTables())
+
+	table, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+	assert.Equal(t, table.ID, tableEngine.GetTable().ID)
+
+	tables := manager.GetTables()
+	assert.Len(t, tables, 1)
+	assert.Equal(t, table.ID, tables[0].ID)
+
+	// a copy, the table can't be changed through it
+	tables[0].State.GameCount = 100
+	snapshot, err := tableEngine.GetTableSnapshot()
+	assert.Nil(t, err)
+	assert.NotEqual(t, 100, snapshot.State.GameCount)
+
+	// release table
+	assert.Nil(t, manager.ReleaseTable(table.ID))
+	_, err = manager.GetTableEngine(table.ID)
+	assert.ErrorIs(t, err, pokertable.ErrManagerTableNotFound)
+	assert.Empty(t, manager.GetTables())
+	assert.ErrorIs(t, manager.ReleaseTable(table.ID), pokertable.ErrManagerTableNotFound)
+}
+
+func TestManager_Reset(t *testing.T) {
+	manager := pokertable.NewManager()
+	for i := 0; i < 3; i++ {
+		_, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+		assert.Nil(t, err, "create table failed")
+	}
+	assert.Len(t, manager.GetTables(), 3)
+
+	manager.Reset()
+	assert.Empty(t, manager.GetTables())
+}
+
+func TestManager_ConcurrentAccess(t *testing.T) {
+	manager := pokertable.NewManager()
+	tableCount := 50
+
+	var wg sync.WaitGroup
+	tableIDs := make(chan string, tableCount)
+	for i := 0; i < tableCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			table, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+			assert.Nil(t, err, "create table failed")
+			tableIDs <- table.ID
+			_ = manager.GetTables()
+		}()
+	}
+	wg.Wait()
+	close(tableIDs)
+	assert.Len(t, manager.GetTables(), tableCount)
+
+	for tableID := range tableIDs {
+		wg.Add(1)
+		go func(tableID string) {
+			defer wg.Done()
+			_, err := manager.GetTableEngine(tableID)
+			assert.Nil(t, err, "get table engine failed")
+			assert.Nil(t, manager.ReleaseTable(tableID))
+			_ = manager.GetTables()
+		}(tableID)
+	}
+	wg.Wait()
+	assert.Empty(t, manager.GetTables())
+}
+
+func TestManager_MaxTables(t *testing.T) {
+	manager := pokertable.NewManager(pokertable.WithMaxTables(2))
+
+	table, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+	_, err = manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+	assert.Equal(t, 2, manager.ActiveTableCount())
+
+	_, err = manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.ErrorIs(t, err, pokertable.ErrManagerAtCapacity)
+	assert.Len(t, manager.GetTables(), 2)
+
+	// closed table frees up capacity
+	assert.Nil(t, manager.CloseTable(table.ID))
+	assert.Equal(t, 1, manager.ActiveTableCount())
+	_, err = manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+}
+
+func TestManager_ReapClosedTables(t *testing.T) {
+	gracePeriod := 50 * time.Millisecond
+	manager := pokertable.NewManager(pokertable.WithClosedTableGracePeriod(gracePeriod))
+
+	closedTable, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+	openTable, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// table is closed by the engine itself
+	tableEngine, err := manager.GetTableEngine(closedTable.ID)
+	assert.Nil(t, err, "get table engine failed")
+	assert.Nil(t, tableEngine.CloseTable())
+	assert.Equal(t, 1, manager.ActiveTableCount())
+
+	// kept during the grace period
+	_, err = manager.GetTableEngine(closedTable.ID)
+	assert.Nil(t, err, "closed table should be kept during the grace period")
+
+	assert.Eventually(t, func() bool {
+		_, err := manager.GetTableEngine(closedTable.ID)
+		return err == pokertable.ErrManagerTableNotFound
+	}, 20*gracePeriod, gracePeriod/5)
+
+	_, err = manager.GetTableEngine(openTable.ID)
+	assert.Nil(t, err, "open table should not be reaped")
+	assert.Len(t, manager.GetTables(), 1)
+}
+
+func TestManager_OnAnyTableUpdated(t *testing.T) {
+	manager := pokertable.NewManager()
+
+	// table created before subscribing
+	table1, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	var mu sync.Mutex
+	updatedTableIDs := make(map[string]int)
+	manager.OnAnyTableUpdated(func(tableID string, table *pokertable.Table) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, tableID, table.ID)
+		updatedTableIDs[tableID]++
+	})
+
+	// table created after subscribing
+	tableUpdatedCount := 0
+	callbacks := pokertable.NewTableEngineCallbacks()
+	callbacks.OnTableUpdated = func(table *pokertable.Table) {
+		tableUpdatedCount++
+	}
+	table2, err := manager.CreateTable(nil, callbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	assert.Nil(t, manager.PlayerReserve(table1.ID, pokertable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pokertable.UnsetValue}))
+	assert.Nil(t, manager.PlayerReserve(table2.ID, pokertable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: pokertable.UnsetValue}))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Greater(t, updatedTableIDs[table1.ID], 0)
+	assert.Greater(t, updatedTableIDs[table2.ID], 0)
+
+	// per-table callbacks still work
+	assert.Greater(t, tableUpdatedCount, 0)
+}
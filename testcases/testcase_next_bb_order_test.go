@@ -0,0 +1,36 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_NextBBOrder_JoinAndLeave(t *testing.T) {
+	manager := pokertable.NewManager()
+	table, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+	assert.Empty(t, tableEngine.GetNextBBOrder())
+
+	// players buy in
+	assert.Nil(t, tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: 2}))
+	assert.Nil(t, tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: 5}))
+	assert.Equal(t, []string{"Fred", "Jeffrey"}, tableEngine.GetNextBBOrder())
+
+	// new player joins
+	assert.Nil(t, tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Chuck", RedeemChips: 1000, Seat: 0}))
+	assert.Equal(t, []string{"Chuck", "Fred", "Jeffrey"}, tableEngine.GetNextBBOrder())
+
+	// player leaves
+	assert.Nil(t, tableEngine.PlayersLeave([]string{"Fred"}))
+	assert.Equal(t, []string{"Chuck", "Jeffrey"}, tableEngine.GetNextBBOrder())
+
+	// returns a copy
+	nextBBOrder := tableEngine.GetNextBBOrder()
+	nextBBOrder[0] = "Fred"
+	assert.Equal(t, []string{"Chuck", "Jeffrey"}, tableEngine.GetNextBBOrder())
+}
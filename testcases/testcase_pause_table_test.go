@@ -0,0 +1,133 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_PauseTable_DeferredUntilHandSettles(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: 1000,
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var mu sync.Mutex
+	var doneOnce sync.Once
+	var pauseRequested int32
+	statuses := make([]pokertable.TableStateStatus, 0)
+	var isPauseDeferred bool
+	var pauseErr error
+	tableEngineCallbacks.OnTableStateUpdated = func(event string, table *pokertable.Table) {
+		if event != pokertable.TableStateEvent_StatusUpdated && event != pokertable.TableStateEvent_GameSettled {
+			return
+		}
+
+		mu.Lock()
+		statuses = append(statuses, table.State.Status)
+		mu.Unlock()
+		if table.State.Status == pokertable.TableStateStatus_TablePausing {
+			doneOnce.Do(wg.Done)
+		}
+	}
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				// pause mid-hand
+				if atomic.CompareAndSwapInt32(&pauseRequested, 0, 1) {
+					deferred, err := tableEngine.PauseTable()
+					mu.Lock()
+					isPauseDeferred, pauseErr = deferred, err
+					mu.Unlock()
+				}
+
+				// the hand goes on, everyone folds to the BB
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	// the hand settles before the table pauses
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Nil(t, pauseErr)
+	assert.True(t, isPauseDeferred)
+	assert.Equal(t, []pokertable.TableStateStatus{pokertable.TableStateStatus_TableGameSettled, pokertable.TableStateStatus_TablePausing}, statuses)
+	assert.Equal(t, 1, tableEngine.GetTable().State.GameCount)
+}
@@ -0,0 +1,146 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_PlayerAutoSitOut_MaxConsecutiveTimeouts(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	afkPlayerID := "Chuck"
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: 1000,
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineOption.MaxConsecutiveTimeouts = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var once sync.Once
+	var done int32
+	var mu sync.Mutex
+	afkPlayerActions := make([]string, 0)
+	autoSitOutPlayerIDs := make([]string, 0)
+	tableEngineCallbacks.OnGamePlayerActionUpdated = func(gameAction pokertable.TablePlayerGameAction) {
+		mu.Lock()
+		defer mu.Unlock()
+		if gameAction.PlayerID == afkPlayerID && gameAction.Action != "pass" && gameAction.Action != "pay" {
+			afkPlayerActions = append(afkPlayerActions, gameAction.Action)
+		}
+	}
+	tableEngineCallbacks.OnPlayerAutoSitOut = func(playerID string) {
+		mu.Lock()
+		autoSitOutPlayerIDs = append(autoSitOutPlayerIDs, playerID)
+		mu.Unlock()
+
+		atomic.StoreInt32(&done, 1)
+		once.Do(wg.Done)
+	}
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		if atomic.LoadInt32(&done) == 1 {
+			return
+		}
+
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerIdx := range table.State.GamePlayerIndexes {
+					playerID := table.State.PlayerStates[playerIdx].PlayerID
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+
+				// the afk player never acts before the action timer expires
+				if playerID == afkPlayerID && !funk.Contains(actions, "pass") {
+					assert.Nil(t, tableEngine.PlayerActionTimeout(playerID), fmt.Sprintf("%s action timeout error", playerID))
+					return
+				}
+
+				if funk.Contains(actions, "pass") {
+					assert.Nil(t, tableEngine.PlayerPass(playerID), fmt.Sprintf("%s pass error", playerID))
+				} else if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				}
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	// sat out right after the second timeout in a row
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{afkPlayerID}, autoSitOutPlayerIDs)
+	assert.Len(t, afkPlayerActions, tableEngineOption.MaxConsecutiveTimeouts)
+
+	playerState := tableEngine.GetTable().State.PlayerStates[tableEngine.GetTable().FindPlayerIdx(afkPlayerID)]
+	assert.False(t, playerState.IsIn)
+	assert.Equal(t, 0, playerState.TimeoutCount)
+}
@@ -0,0 +1,153 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_PlayerDisconnect_FastFold(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: 1000,
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var once sync.Once
+	var mu sync.Mutex
+	var disconnecting int32
+	disconnectedPlayerID := ""
+	disconnectedPlayerActions := make([]string, 0)
+	tableEngineCallbacks.OnGamePlayerActionUpdated = func(gameAction pokertable.TablePlayerGameAction) {
+		mu.Lock()
+		defer mu.Unlock()
+		if gameAction.PlayerID == disconnectedPlayerID && gameAction.Action != "pass" {
+			disconnectedPlayerActions = append(disconnectedPlayerActions, gameAction.Action)
+		}
+	}
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		// ignore updates emitted by PlayerDisconnect itself
+		if atomic.LoadInt32(&disconnecting) == 1 {
+			return
+		}
+
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+
+				// the engine acts for the disconnected player
+				mu.Lock()
+				isDisconnected := playerID == disconnectedPlayerID
+				mu.Unlock()
+				if isDisconnected {
+					return
+				}
+
+				if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				}
+
+				// the sb is the next actor preflop (3 players) and loses connection
+				mu.Lock()
+				shouldDisconnect := disconnectedPlayerID == "" && table.State.GameState.Status.Round == pokertable.GameRound_Preflop
+				if shouldDisconnect {
+					disconnectedPlayerID = findPlayerID(table, "sb")
+				}
+				mu.Unlock()
+				if shouldDisconnect {
+					atomic.StoreInt32(&disconnecting, 1)
+					assert.Nil(t, tableEngine.PlayerDisconnect(disconnectedPlayerID))
+					atomic.StoreInt32(&disconnecting, 0)
+				}
+			}
+		case pokertable.TableStateStatus_TableGameSettled:
+			if table.State.GameCount != 1 || table.State.GameState.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_GameClosed] {
+				return
+			}
+			once.Do(wg.Done)
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	// folded on the first turn without waiting for the action timer
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{string(pokertable.WagerAction_Fold)}, disconnectedPlayerActions)
+
+	assert.Nil(t, tableEngine.PlayerReconnect(disconnectedPlayerID))
+	playerIdx := tableEngine.GetTable().FindPlayerIdx(disconnectedPlayerID)
+	assert.False(t, tableEngine.GetTable().State.PlayerStates[playerIdx].IsDisconnected)
+}
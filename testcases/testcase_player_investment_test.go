@@ -0,0 +1,142 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_PlayerInvestment(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: 1000,
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var mu sync.Mutex
+	var once sync.Once
+	bbPlayerID := ""
+	investments := make(map[string]int64) // key: round, value: BB investment when the round starts
+	hasFlopBet := false
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok || table.State.GameCount != 1 {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				round := table.State.GameState.Status.Round
+				mu.Lock()
+				if bbPlayerID == "" {
+					bbPlayerID = findPlayerID(table, "bb")
+				}
+				if _, exist := investments[round]; !exist {
+					investment, err := tableEngine.GetPlayerInvestment(bbPlayerID)
+					assert.Nil(t, err, fmt.Sprintf("%s get investment error", bbPlayerID))
+					investments[round] = investment
+				}
+				shouldBet := round == pokertable.GameRound_Flop && !hasFlopBet
+				hasFlopBet = hasFlopBet || shouldBet
+				mu.Unlock()
+
+				// everyone calls preflop, bets BB once on the flop, then checks down
+				playerID, actions := currentPlayerMove(table)
+				if shouldBet && funk.Contains(actions, "bet") {
+					assert.Nil(t, tableEngine.PlayerBet(playerID, table.State.BlindState.BB), fmt.Sprintf("%s bet error", playerID))
+				} else if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				}
+			}
+		case pokertable.TableStateStatus_TableGameSettled:
+			if table.State.GameCount != 1 || table.State.GameState.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_GameClosed] {
+				return
+			}
+
+			once.Do(wg.Done)
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// not in a hand yet
+	_, err = tableEngine.GetPlayerInvestment(playerIDs[0])
+	assert.ErrorIs(t, err, pokertable.ErrGameNotStarted)
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	// accumulated across betting rounds
+	mu.Lock()
+	defer mu.Unlock()
+	bb := NewDefaultTableSetting().Blind.BB
+	assert.Equal(t, map[string]int64{
+		pokertable.GameRound_Preflop: bb,
+		pokertable.GameRound_Flop:    bb,
+		pokertable.GameRound_Turn:    bb * 2,
+		pokertable.GameRound_River:   bb * 2,
+	}, investments)
+}
@@ -0,0 +1,44 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_PlayerReserve_SeatOccupied(t *testing.T) {
+	manager := pokertable.NewManager()
+	table, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	err = tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: 3})
+	assert.Nil(t, err, "Fred reserve error")
+
+	err = tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: 3})
+	assert.ErrorIs(t, err, pokertable.ErrTableSeatOccupied)
+	assert.Contains(t, err.Error(), "seat 3")
+	assert.Equal(t, pokertable.UnsetValue, tableEngine.GetTable().FindPlayerIdx("Jeffrey"))
+
+	// fallback to a random seat
+	err = tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: pokertable.UnsetValue})
+	assert.Nil(t, err, "Jeffrey reserve error")
+}
+
+func TestTable_PlayerReserve_SeatOutOfRange(t *testing.T) {
+	manager := pokertable.NewManager()
+	setting := NewDefaultTableSetting()
+	table, err := manager.CreateTable(nil, nil, setting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	err = tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: setting.Meta.TableMaxSeatCount})
+	assert.ErrorIs(t, err, pokertable.ErrTableSeatOutOfRange)
+	assert.Contains(t, err.Error(), "seat 9")
+	assert.Equal(t, pokertable.UnsetValue, tableEngine.GetTable().FindPlayerIdx("Fred"))
+}
@@ -123,7 +123,7 @@ func TestTableGame_Preflop_Settlement(t *testing.T) {
 
 	// Start game
 	time.Sleep(time.Microsecond * 100)
-	err = tableEngine.StartTableGame()
+	err = tableEngine.StartTableGameIfNotStarted()
 	assert.Nil(t, err)
 
 	wg.Wait()
@@ -0,0 +1,43 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_SeatOpened_PlayerLeave(t *testing.T) {
+	openedSeats := make([]int, 0)
+	var openedTableID string
+	callbacks := pokertable.NewTableEngineCallbacks()
+	callbacks.OnSeatOpened = func(tableID string, seat int) {
+		openedTableID = tableID
+		openedSeats = append(openedSeats, seat)
+	}
+
+	manager := pokertable.NewManager()
+	setting := NewDefaultTableSetting()
+	setting.Meta.Role = pokertable.TableRole_MustMove
+	table, err := manager.CreateTable(nil, callbacks, setting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+	assert.Equal(t, pokertable.TableRole_MustMove, tableEngine.GetTable().Meta.Role)
+
+	assert.Nil(t, tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: 2}))
+	assert.Nil(t, tableEngine.PlayerReserve(pokertable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: 5}))
+	assert.Empty(t, openedSeats)
+
+	assert.Nil(t, tableEngine.PlayersLeave([]string{"Jeffrey"}))
+	assert.Equal(t, []int{5}, openedSeats)
+	assert.Equal(t, table.ID, openedTableID)
+}
+
+func TestTable_Role_DefaultNormal(t *testing.T) {
+	manager := pokertable.NewManager()
+	table, err := manager.CreateTable(nil, nil, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+	assert.Equal(t, pokertable.TableRole_Normal, table.Meta.Role)
+}
@@ -0,0 +1,172 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/d-protocol/pokerlib"
+	"github.com/d-protocol/pokerlib/settlement"
+	"github.com/d-protocol/pokertable"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+)
+
+func TestTableGame_ShortAnte(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions: Chuck can't cover the ante
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	shortPlayerID := "Chuck"
+	redeemChips := map[string]int64{
+		"Fred":    1000,
+		"Jeffrey": 1000,
+		"Chuck":   30,
+	}
+	players := funk.Map(playerIDs, func(playerID string) pokertable.JoinPlayer {
+		return pokertable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: redeemChips[playerID],
+			Seat:        pokertable.UnsetValue,
+		}
+	}).([]pokertable.JoinPlayer)
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Blind.Ante = 50
+
+	// create manager & table
+	var tableEngine pokertable.TableEngine
+	manager := pokertable.NewManager()
+	tableEngineOption := pokertable.NewTableEngineOptions()
+	tableEngineOption.GameContinueInterval = 1
+	tableEngineOption.OpenGameTimeout = 2
+	tableEngineCallbacks := pokertable.NewTableEngineCallbacks()
+
+	var once sync.Once
+	var mu sync.Mutex
+	anteActions := make(map[string]pokertable.TablePlayerGameAction)
+	var result *settlement.Result
+	bankrolls := make(map[string]int64)
+	tableEngineCallbacks.OnTableUpdated = func(table *pokertable.Table) {
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			// the short player busts out after the first hand
+			if table.State.GameCount != 1 {
+				return
+			}
+
+			event, ok := pokerlib.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerlib.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerlib.GameEvent_AnteRequested:
+				for _, playerID := range playerIDs {
+					ante := table.State.BlindState.Ante
+					assert.Nil(t, tableEngine.PlayerPay(playerID, ante), fmt.Sprintf("%s pay ante error", playerID))
+				}
+			case pokerlib.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerlib.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				} else if funk.Contains(actions, "pass") {
+					assert.Nil(t, tableEngine.PlayerPass(playerID), fmt.Sprintf("%s pass error", playerID))
+				}
+			}
+		case pokertable.TableStateStatus_TableGameSettled:
+			if table.State.GameCount != 1 || table.State.GameState.Status.CurrentEvent != pokerlib.GameEventSymbols[pokerlib.GameEvent_GameClosed] {
+				return
+			}
+
+			mu.Lock()
+			result = table.State.GameState.Result
+			for _, player := range table.State.PlayerStates {
+				bankrolls[player.PlayerID] = player.Bankroll
+			}
+			mu.Unlock()
+			once.Do(wg.Done)
+		}
+	}
+	tableEngineCallbacks.OnGamePlayerActionUpdated = func(gameAction pokertable.TablePlayerGameAction) {
+		if gameAction.Round != "ante" {
+			return
+		}
+
+		mu.Lock()
+		anteActions[gameAction.PlayerID] = gameAction
+		mu.Unlock()
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pokertable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableEngineCallbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+
+		go func(player pokertable.JoinPlayer) {
+			time.Sleep(time.Microsecond * 10)
+			assert.Nil(t, tableEngine.PlayerJoin(player.PlayerID), fmt.Sprintf("%s join error", player.PlayerID))
+		}(joinPlayer)
+	}
+
+	// Start game
+	time.Sleep(time.Microsecond * 100)
+	err = tableEngine.StartTableGameIfNotStarted()
+	assert.Nil(t, err)
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the short player posts the rest of the stack as ante and goes all-in
+	assert.Equal(t, "allin", anteActions[shortPlayerID].Action)
+	assert.Equal(t, int64(30), anteActions[shortPlayerID].Chips)
+	for _, playerID := range playerIDs {
+		if playerID != shortPlayerID {
+			assert.Equal(t, "pay", anteActions[playerID].Action)
+			assert.Equal(t, int64(50), anteActions[playerID].Chips)
+		}
+	}
+
+	// the short player is only eligible for the main pot made of the partial antes
+	assert.NotNil(t, result)
+	assert.Len(t, result.Pots, 2)
+	assert.Equal(t, int64(90), result.Pots[0].Total)
+	assert.Equal(t, int64(80), result.Pots[1].Total)
+	assert.LessOrEqual(t, bankrolls[shortPlayerID], int64(90))
+
+	total := int64(0)
+	for _, bankroll := range bankrolls {
+		total += bankroll
+	}
+	assert.Equal(t, int64(2030), total)
+}